@@ -57,36 +57,613 @@ type ClusterDetails struct {
 
 	// ID is an identifier for your cluster; this is only used when your node is running on an AWS Outpost.
 	ID string `json:"id,omitempty"`
+
+	// HostsRefreshInterval is how often the API server addresses that are written to `/etc/hosts` when `enableOutpost`
+	// is set are refreshed. By default, they are only refreshed by `nodeadm init`. The last known addresses are kept
+	// while the API server cannot be resolved, such as when the Outpost is disconnected from its parent region.
+	HostsRefreshInterval *metav1.Duration `json:"hostsRefreshInterval,omitempty"`
+
+	// Auth determines how `kubelet` authenticates with your cluster.
+	Auth ClusterAuthOptions `json:"auth,omitempty"`
+}
+
+// ClusterAuthOptions control the credentials in the kubeconfig generated for `kubelet`.
+// With the `BootstrapToken` mode, or when `enableOutpost` is set, the kubeconfig is a bootstrap kubeconfig
+// that `kubelet` uses to request a client certificate.
+type ClusterAuthOptions struct {
+	// Mode is the authentication mode. Defaults to `EKSGetToken`.
+	Mode ClusterAuthMode `json:"mode,omitempty"`
+
+	// RoleARN is an IAM role that is assumed to get tokens with the `EKSGetToken` and `AWSIAMAuthenticator` modes.
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// BootstrapToken is a [bootstrap token](https://kubernetes.io/docs/reference/access-authn-authz/bootstrap-tokens/),
+	// such as `abcdef.0123456789abcdef`, for the `BootstrapToken` mode.
+	BootstrapToken string `json:"bootstrapToken,omitempty"`
+
+	// ClientCertificate is the path of a PEM-encoded client certificate for the `ClientCertificate` mode.
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+
+	// ClientKey is the path of the PEM-encoded private key of the `clientCertificate`.
+	ClientKey string `json:"clientKey,omitempty"`
 }
 
+// ClusterAuthMode specifies how `kubelet` authenticates with the cluster.
+// +kubebuilder:validation:Enum={EKSGetToken, AWSIAMAuthenticator, BootstrapToken, ClientCertificate}
+type ClusterAuthMode string
+
+const (
+	// ClusterAuthModeEKSGetToken gets tokens with `aws eks get-token`, which requires the AWS CLI.
+	ClusterAuthModeEKSGetToken ClusterAuthMode = "EKSGetToken"
+
+	// ClusterAuthModeAWSIAMAuthenticator gets tokens with `aws-iam-authenticator token`, using the cluster `id` when it is set.
+	ClusterAuthModeAWSIAMAuthenticator ClusterAuthMode = "AWSIAMAuthenticator"
+
+	// ClusterAuthModeBootstrapToken uses a bootstrap token for TLS bootstrapping, for self-managed control planes.
+	ClusterAuthModeBootstrapToken ClusterAuthMode = "BootstrapToken"
+
+	// ClusterAuthModeClientCertificate uses a client certificate, for self-managed control planes.
+	ClusterAuthModeClientCertificate ClusterAuthMode = "ClientCertificate"
+)
+
 // KubeletOptions are additional parameters passed to `kubelet`.
 type KubeletOptions struct {
 	// Config is a [`KubeletConfiguration`](https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/)
-	// that will be merged with the defaults.
+	// that will be merged with the defaults. Unknown fields and a subset of the `kubelet`'s own validation rules are
+	// checked before any file is written, so some invalid values are only reported when `kubelet` starts.
 	Config map[string]runtime.RawExtension `json:"config,omitempty"`
 
+	// ConfigDropIns are additional `KubeletConfiguration` documents, each written to its own file in the
+	// [drop-in directory](https://kubernetes.io/docs/tasks/administer-cluster/kubelet-config-file/#kubelet-conf-d)
+	// on `kubelet` 1.29 and later, or merged into the configuration file in order on earlier versions.
+	// They are applied after `config`. Drop-ins previously written by `nodeadm` that are no longer configured
+	// are removed, while drop-ins written by other tools are left in place.
+	ConfigDropIns []KubeletConfigDropIn `json:"configDropIns,omitempty"`
+
 	// Flags are [command-line `kubelet` arguments](https://kubernetes.io/docs/reference/command-line-tools-reference/kubelet/).
-	// that will be appended to the defaults.
+	// that will be merged with the defaults. A flag replaces a default flag of the same name.
+	// Flags that the `kubelet` version deprecates in favor of a `KubeletConfiguration` field,
+	// such as `--max-pods`, are moved into the configuration. An element may hold several flags, which are split
+	// like shell words. Values cannot contain whitespace, because the `kubelet` unit splits its arguments on whitespace.
 	Flags []string `json:"flags,omitempty"`
+
+	// Readiness determines how `nodeadm` waits for `kubelet` to become ready after it has been started.
+	Readiness KubeletReadinessOptions `json:"readiness,omitempty"`
+
+	// Systemd are settings applied to the `kubelet` systemd unit.
+	Systemd SystemdOptions `json:"systemd,omitempty"`
+
+	// MaxPods determines how the maximum number of pods on the node is calculated.
+	MaxPods MaxPodsOptions `json:"maxPods,omitempty"`
+
+	// ReservedResources determines the resources set aside for system and Kubernetes daemons,
+	// which are subtracted from the node's allocatable resources.
+	ReservedResources ReservedResourcesOptions `json:"reservedResources,omitempty"`
+
+	// ImageCredentialProviders configure the [credential providers](https://kubernetes.io/docs/tasks/administer-cluster/kubelet-credential-provider/)
+	// that `kubelet` uses to pull private images.
+	ImageCredentialProviders ImageCredentialProviderOptions `json:"imageCredentialProviders,omitempty"`
+
+	// GracefulShutdown configures [graceful node shutdown](https://kubernetes.io/docs/concepts/cluster-administration/node-shutdown/#graceful-node-shutdown),
+	// which delays the shutdown of the node until its pods have been terminated. The `systemd-logind` `InhibitDelayMaxSec`
+	// setting is raised to the total grace period.
+	GracefulShutdown GracefulShutdownOptions `json:"gracefulShutdown,omitempty"`
+
+	// NodeName determines the name of the Node object that `kubelet` registers. Strategies other than `PrivateDNSName`
+	// require `kubelet` 1.26 or later.
+	NodeName NodeNameOptions `json:"nodeName,omitempty"`
+
+	// NodeIP selects the addresses that `kubelet` advertises for the node. Defaults to the address of the primary
+	// network interface in the cluster's IP family, as reported by the instance metadata service.
+	NodeIP NodeIPOptions `json:"nodeIP,omitempty"`
+}
+
+// GracefulShutdownOptions set the grace periods for terminating pods when the node shuts down, either for
+// critical and other pods, or by pod priority.
+type GracefulShutdownOptions struct {
+	// GracePeriod is the total time that the shutdown of the node is delayed for pods to terminate.
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// CriticalPodsGracePeriod is the part of the `gracePeriod` reserved for terminating critical pods, after the other
+	// pods have been terminated.
+	CriticalPodsGracePeriod *metav1.Duration `json:"criticalPodsGracePeriod,omitempty"`
+
+	// ByPodPriority sets the grace periods of pods by their priority class value, instead of `gracePeriod` and
+	// `criticalPodsGracePeriod`. The total grace period is the sum of the grace periods.
+	ByPodPriority []ShutdownGracePeriodByPodPriority `json:"byPodPriority,omitempty"`
+}
+
+// ShutdownGracePeriodByPodPriority is the grace period of pods with a priority class value of at least `priority`,
+// and less than the next higher priority.
+type ShutdownGracePeriodByPodPriority struct {
+	// Priority is the lowest priority class value of the pods.
+	Priority int32 `json:"priority"`
+
+	// GracePeriodSeconds is the grace period of the pods, in seconds.
+	GracePeriodSeconds int64 `json:"gracePeriodSeconds"`
+}
+
+// NodeNameOptions determine the name of the node.
+type NodeNameOptions struct {
+	// Strategy determines how the node name is chosen. Defaults to `InstanceID` when the `InstanceIdNodeName`
+	// feature gate is enabled, and to `PrivateDNSName` otherwise.
+	Strategy NodeNameStrategy `json:"strategy,omitempty"`
+
+	// Template is a [Go template](https://pkg.go.dev/text/template) of the node name with the `Template` strategy,
+	// such as `{{ .ID }}.{{ .Region }}.compute.internal`. The instance's `ID`, `Region`, `Type`, `AvailabilityZone`
+	// and `PrivateDNSName` may be used.
+	Template string `json:"template,omitempty"`
+}
+
+// NodeNameStrategy specifies how the node name is chosen.
+// +kubebuilder:validation:Enum={PrivateDNSName, InstanceID, ResourceName, Hostname, Template}
+type NodeNameStrategy string
+
+const (
+	// NodeNameStrategyPrivateDNSName uses the instance's private DNS name from the EC2 API, which may take
+	// up to 3 minutes to become available.
+	NodeNameStrategyPrivateDNSName NodeNameStrategy = "PrivateDNSName"
+
+	// NodeNameStrategyInstanceID uses the instance ID.
+	NodeNameStrategyInstanceID NodeNameStrategy = "InstanceID"
+
+	// NodeNameStrategyResourceName uses the `hostname` from the instance metadata service, which is the
+	// resource name when the subnet uses resource-based naming.
+	NodeNameStrategyResourceName NodeNameStrategy = "ResourceName"
+
+	// NodeNameStrategyHostname uses the hostname of the operating system.
+	NodeNameStrategyHostname NodeNameStrategy = "Hostname"
+
+	// NodeNameStrategyTemplate renders the `template`.
+	NodeNameStrategyTemplate NodeNameStrategy = "Template"
+)
+
+// KubeletConfigDropIn is a named `KubeletConfiguration` drop-in.
+type KubeletConfigDropIn struct {
+	// Name identifies the drop-in, and may contain lowercase letters, digits and `-`.
+	Name string `json:"name"`
+
+	// Priority orders the drop-ins, from 1 to 99. Drop-ins with a higher priority are applied later and
+	// override those with a lower priority. Drop-ins with the same priority are applied in order of name.
+	Priority int32 `json:"priority"`
+
+	// Config is a [`KubeletConfiguration`](https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/).
+	Config map[string]runtime.RawExtension `json:"config"`
+}
+
+// NodeIPOptions select the node's addresses from the network interfaces present on the host. At most one of
+// `interface`, `mac` and `deviceIndex` may be set. When none are set, the primary network interface is used,
+// or every network interface when `cidrs` are set.
+type NodeIPOptions struct {
+	// Interface is the name of a network interface, such as `ens6`.
+	Interface string `json:"interface,omitempty"`
+
+	// MAC is the MAC address of a network interface.
+	MAC string `json:"mac,omitempty"`
+
+	// DeviceIndex is the device index of an ENI, where 0 is the primary ENI.
+	DeviceIndex *int32 `json:"deviceIndex,omitempty"`
+
+	// CIDRs restrict the addresses to these subnets, such as `10.0.64.0/18`.
+	CIDRs []string `json:"cidrs,omitempty"`
+
+	// DualStack advertises both an IPv4 and an IPv6 address, with the address in the cluster's IP family first.
+	DualStack bool `json:"dualStack,omitempty"`
+}
+
+// MaxPodsOptions control the `maxPods` setting generated for `kubelet`, which also determines the
+// memory reserved by the `EKS` reserved resources policy. Values set in the `kubelet` configuration take precedence.
+type MaxPodsOptions struct {
+	// Policy determines how the maximum number of pods is calculated. Defaults to `EKS`.
+	Policy MaxPodsPolicy `json:"policy,omitempty"`
+
+	// Value is the maximum number of pods with the `Fixed` policy.
+	Value *int32 `json:"value,omitempty"`
+
+	// CNIVersion is the version of the Amazon VPC CNI plugin, such as `1.18.0`. Required by the `Calculated` policy.
+	CNIVersion string `json:"cniVersion,omitempty"`
+
+	// CNICustomNetworking should be set when the VPC CNI uses custom networking, which leaves the primary ENI unused for pods.
+	CNICustomNetworking bool `json:"cniCustomNetworking,omitempty"`
+
+	// CNIPrefixDelegation should be set when the VPC CNI assigns prefixes to ENIs. It has no effect for
+	// VPC CNI versions before `1.9.0` or instance types that are not built on the Nitro System.
+	CNIPrefixDelegation bool `json:"cniPrefixDelegation,omitempty"`
+
+	// CNIMaxENI is the maximum number of ENIs that the VPC CNI attaches, when less than the instance type's limit.
+	CNIMaxENI *int32 `json:"cniMaxENI,omitempty"`
+}
+
+// MaxPodsPolicy specifies how the maximum number of pods is calculated.
+// +kubebuilder:validation:Enum={EKS, Calculated, Fixed}
+type MaxPodsPolicy string
+
+const (
+	// MaxPodsPolicyEKS uses the maximum number of pods for the instance type when every ENI IP address is assigned to a pod.
+	MaxPodsPolicyEKS MaxPodsPolicy = "EKS"
+
+	// MaxPodsPolicyCalculated calculates the maximum number of pods from the VPC CNI settings, in the same way as the
+	// `max-pods-calculator.sh` script. The result is limited to 110 pods, or 250 pods for instance types with more than 30 vCPUs.
+	MaxPodsPolicyCalculated MaxPodsPolicy = "Calculated"
+
+	// MaxPodsPolicyFixed uses the provided value.
+	MaxPodsPolicyFixed MaxPodsPolicy = "Fixed"
+)
+
+// ReservedResourcesOptions control the [node allocatable](https://kubernetes.io/docs/tasks/administer-cluster/reserve-compute-resources/)
+// settings generated for `kubelet`. Values set in the `kubelet` configuration take precedence.
+type ReservedResourcesOptions struct {
+	// Policy determines how the reservations are calculated. Defaults to `EKS`.
+	Policy ReservationPolicy `json:"policy,omitempty"`
+
+	// KubeReserved are the resources reserved for Kubernetes daemons, keyed by `cpu`, `memory`,
+	// `ephemeral-storage` or `pid`. With the `EKS` policy, these replace individual calculated values.
+	KubeReserved map[string]string `json:"kubeReserved,omitempty"`
+
+	// SystemReserved are the resources reserved for operating system daemons, keyed like `kubeReserved`.
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+
+	// KubeReservedCgroup is the cgroup of Kubernetes daemons. Defaults to `/runtime`; an empty value unsets it.
+	KubeReservedCgroup *string `json:"kubeReservedCgroup,omitempty"`
+
+	// SystemReservedCgroup is the cgroup of operating system daemons. Defaults to `/system`; an empty value unsets it.
+	SystemReservedCgroup *string `json:"systemReservedCgroup,omitempty"`
+
+	// EvictionHard are the hard eviction thresholds, such as `memory.available: 100Mi`. They replace the defaults.
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+
+	// EnforceNodeAllocatable are the reservations that `kubelet` enforces with cgroups.
+	// Any of `pods`, `system-reserved`, `kube-reserved` or `none`.
+	EnforceNodeAllocatable []string `json:"enforceNodeAllocatable,omitempty"`
+}
+
+// ReservationPolicy specifies how reserved resources are calculated.
+// +kubebuilder:validation:Enum={EKS, Fixed, Percentage}
+type ReservationPolicy string
+
+const (
+	// ReservationPolicyEKS calculates `kubeReserved` from the instance's CPU count and maximum pods.
+	ReservationPolicyEKS ReservationPolicy = "EKS"
+
+	// ReservationPolicyFixed uses the reserved quantities as provided, such as `500m` or `1Gi`.
+	ReservationPolicyFixed ReservationPolicy = "Fixed"
+
+	// ReservationPolicyPercentage reserves percentages of the node's capacity, such as `5%`.
+	// Memory capacity is read from `/proc/meminfo` and storage capacity from the `kubelet` root filesystem.
+	ReservationPolicyPercentage ReservationPolicy = "Percentage"
+)
+
+// ImageCredentialProviderOptions control the credential provider configuration generated for `kubelet`.
+// The API version of the configuration is selected according to the `kubelet` version.
+type ImageCredentialProviderOptions struct {
+	// BinDir is the directory that contains every provider executable. Defaults to `/etc/eks/image-credential-provider`.
+	BinDir string `json:"binDir,omitempty"`
+
+	// Providers replace the default provider, which is `ecr-credential-provider` for Amazon ECR images.
+	Providers []ImageCredentialProvider `json:"providers,omitempty"`
+}
+
+// ImageCredentialProvider is a credential provider executable that `kubelet` invokes for matching images.
+type ImageCredentialProvider struct {
+	// Name is the file name of the provider executable in the `binDir`.
+	Name string `json:"name"`
+
+	// MatchImages are the image patterns the provider handles, such as `*.dkr.ecr.*.vpce.amazonaws.com`.
+	// Globs may be used in domain segments, and each glob matches a single segment. Defaults to the Amazon ECR
+	// hosts of every partition, including FIPS endpoints, when the name is `ecr-credential-provider`.
+	MatchImages []string `json:"matchImages,omitempty"`
+
+	// DefaultCacheDuration is how long `kubelet` caches credentials when the provider does not specify it. Defaults to 12h.
+	DefaultCacheDuration *metav1.Duration `json:"defaultCacheDuration,omitempty"`
+
+	// Args are passed to the provider executable.
+	Args []string `json:"args,omitempty"`
+
+	// Env are environment variables set for the provider executable, in addition to the `kubelet` environment.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// KubeletReadinessOptions control the checks `nodeadm` performs before considering `kubelet` ready.
+type KubeletReadinessOptions struct {
+	// Timeout is the maximum amount of time to wait for `kubelet` to become ready. Defaults to 3m.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// WaitForNodeRegistration additionally waits until this node's `Node` object
+	// has been registered with the cluster's kube-apiserver.
+	WaitForNodeRegistration *bool `json:"waitForNodeRegistration,omitempty"`
 }
 
 // ContainerdOptions are additional parameters passed to `containerd`.
 type ContainerdOptions struct {
 	// Config is an inline [`containerd` configuration TOML](https://github.com/containerd/containerd/blob/main/docs/man/containerd-config.toml.5.md)
-	// that will be merged with the defaults.
+	// that will be merged with the defaults. The defaults are a version 3 config for `containerd` 2.0 and later, and a
+	// version 2 config before. A version 2 config, or a config without a `version`, is translated into version 3 for
+	// `containerd` 2.0 and later, unless it uses settings that were removed.
 	Config string `json:"config,omitempty"`
 
 	// BaseRuntimeSpec is the OCI runtime specification upon which all containers will be based.
 	// The provided spec will be merged with the default spec; so that a partial spec may be provided.
 	// For more information, see: https://github.com/opencontainers/runtime-spec
 	BaseRuntimeSpec map[string]runtime.RawExtension `json:"baseRuntimeSpec,omitempty"`
+
+	// BaseRuntimeSpecPresets are curated changes to the default spec, which are applied before the `baseRuntimeSpec` is
+	// merged. The resulting spec is validated against the OCI runtime specification, and unknown fields are rejected.
+	BaseRuntimeSpecPresets []BaseRuntimeSpecPreset `json:"baseRuntimeSpecPresets,omitempty"`
+
+	// Snapshotter selects how `containerd` unpacks and stores the layers of images, such as lazily with SOCI or stargz.
+	Snapshotter SnapshotterOptions `json:"snapshotter,omitempty"`
+
+	// Runtimes are additional runtimes, such as gVisor or Kata Containers, that pods select with a RuntimeClass whose
+	// `handler` is the runtime's `name`. The `runc` runtime is always configured, and remains the default.
+	Runtimes []ContainerRuntime `json:"runtimes,omitempty"`
+
+	// Registries configure how `containerd` pulls images from each registry, such as through mirrors. They are
+	// written as [`hosts.toml`](https://github.com/containerd/containerd/blob/main/docs/hosts.md) files to
+	// `/etc/containerd/certs.d`. Files previously written by `nodeadm` for registries that are no longer configured
+	// are removed.
+	Registries []RegistryOptions `json:"registries,omitempty"`
+
+	// Readiness determines how `nodeadm` waits for `containerd` to become ready after it has been started.
+	Readiness ContainerdReadinessOptions `json:"readiness,omitempty"`
+
+	// SandboxImagePull determines how `nodeadm` pulls the sandbox image after `containerd` has become ready.
+	SandboxImagePull SandboxImagePullOptions `json:"sandboxImagePull,omitempty"`
+
+	// PreloadImages are images that `nodeadm` pulls or imports after `containerd` has become ready, so that pods using
+	// them start without waiting for a pull.
+	PreloadImages PreloadImagesOptions `json:"preloadImages,omitempty"`
+
+	// Systemd are settings applied to the `containerd` systemd unit.
+	Systemd SystemdOptions `json:"systemd,omitempty"`
+}
+
+// BaseRuntimeSpecPreset is a curated change to the base runtime spec.
+// +kubebuilder:validation:Enum={RaisedNoFile, UnlimitedMemlock, DropNetRaw}
+type BaseRuntimeSpecPreset string
+
+const (
+	// BaseRuntimeSpecPresetRaisedNoFile raises the soft `RLIMIT_NOFILE` of containers to their hard limit of 1048576.
+	BaseRuntimeSpecPresetRaisedNoFile BaseRuntimeSpecPreset = "RaisedNoFile"
+
+	// BaseRuntimeSpecPresetUnlimitedMemlock removes the `RLIMIT_MEMLOCK` of containers, such as for eBPF or RDMA.
+	BaseRuntimeSpecPresetUnlimitedMemlock BaseRuntimeSpecPreset = "UnlimitedMemlock"
+
+	// BaseRuntimeSpecPresetDropNetRaw drops `CAP_NET_RAW` from the default capabilities of containers.
+	BaseRuntimeSpecPresetDropNetRaw BaseRuntimeSpecPreset = "DropNetRaw"
+)
+
+// SnapshotterOptions select the `containerd` snapshotter.
+type SnapshotterOptions struct {
+	// Name is the snapshotter that image layers are unpacked with. Defaults to `overlayfs`. `soci` and `stargz` select
+	// the [SOCI](https://github.com/awslabs/soci-snapshotter) and [stargz](https://github.com/containerd/stargz-snapshotter)
+	// snapshotters, which load images lazily and run as proxy plugins of `containerd`.
+	Name string `json:"name,omitempty"`
+
+	// Proxy configures a snapshotter that runs as a separate daemon, which `containerd` connects to as a proxy plugin.
+	// It overrides the defaults for `soci` and `stargz`.
+	Proxy ProxySnapshotterOptions `json:"proxy,omitempty"`
+}
+
+// ProxySnapshotterOptions configure a proxy snapshotter. Unpacked layers are kept in the content store for proxy
+// snapshotters, which need them to resolve layers lazily.
+type ProxySnapshotterOptions struct {
+	// Address is the path of the socket that the snapshotter listens on. Defaults to
+	// `/run/soci-snapshotter-grpc/soci-snapshotter-grpc.sock` for `soci`, and
+	// `/run/containerd-stargz-grpc/containerd-stargz-grpc.sock` for `stargz`.
+	Address string `json:"address,omitempty"`
+
+	// Daemon is the systemd unit of the snapshotter, which `nodeadm` starts before `containerd`. Defaults to
+	// `soci-snapshotter` for `soci`, and `stargz-snapshotter` for `stargz`. The `process` daemon manager only runs these defaults.
+	Daemon string `json:"daemon,omitempty"`
+}
+
+// ContainerRuntime is a runtime handler of the `containerd` CRI plugin.
+type ContainerRuntime struct {
+	// Name is the handler of the runtime, which RuntimeClasses refer to.
+	Name string `json:"name"`
+
+	// Type is the `runtime_type` of the runtime, such as `io.containerd.runsc.v1` for gVisor or `io.containerd.kata.v2`
+	// for Kata Containers. The shim binary that it implies, such as `containerd-shim-runsc-v1`, must be on the `PATH`.
+	Type string `json:"type"`
+
+	// Path is the absolute path of the shim binary, when it is not on the `PATH`.
+	Path string `json:"path,omitempty"`
+
+	// Options are passed to the shim, as the `options` of the runtime in the `containerd` configuration.
+	Options map[string]runtime.RawExtension `json:"options,omitempty"`
+
+	// BaseRuntimeSpec is merged into the base runtime spec of `runc`, which includes the `baseRuntimeSpec` of
+	// `containerd`, to form the base runtime spec of this runtime. Containers of runtimes without it use the base runtime
+	// spec of `runc`.
+	BaseRuntimeSpec map[string]runtime.RawExtension `json:"baseRuntimeSpec,omitempty"`
+}
+
+// RegistryOptions configure the hosts of a registry.
+type RegistryOptions struct {
+	// Name is the registry's host and optional port, such as `docker.io` or `registry.example.com:5000`, or `_default`
+	// for every registry without its own configuration.
+	Name string `json:"name"`
+
+	// Server is the URL of the registry itself, which is used after the mirrors. Defaults to `https://<name>`, or
+	// `https://registry-1.docker.io` for `docker.io`.
+	Server string `json:"server,omitempty"`
+
+	// ServerTLS are the TLS settings of the `server`.
+	ServerTLS RegistryTLSOptions `json:"serverTLS,omitempty"`
+
+	// Mirrors are tried in order before the `server`.
+	Mirrors []RegistryMirror `json:"mirrors,omitempty"`
+}
+
+// RegistryMirror is a host that serves the images of a registry, such as a pull-through cache.
+type RegistryMirror struct {
+	// Host is the URL of the mirror, such as `https://cache.example.com:5000`.
+	Host string `json:"host"`
+
+	// Capabilities are the operations that the mirror supports. Defaults to `pull`, `resolve` and `push`.
+	Capabilities []RegistryCapability `json:"capabilities,omitempty"`
+
+	// OverridePath should be set when the `host` includes the full path of the registry API, instead of the
+	// `/v2` prefix being added.
+	OverridePath bool `json:"overridePath,omitempty"`
+
+	// TLS are the TLS settings of the mirror.
+	TLS RegistryTLSOptions `json:"tls,omitempty"`
+}
+
+// RegistryCapability is an operation that a registry host supports.
+// +kubebuilder:validation:Enum={pull, resolve, push}
+type RegistryCapability string
+
+const (
+	// RegistryCapabilityPull is fetching manifests and blobs by digest.
+	RegistryCapabilityPull RegistryCapability = "pull"
+
+	// RegistryCapabilityResolve is resolving image tags to digests.
+	RegistryCapabilityResolve RegistryCapability = "resolve"
+
+	// RegistryCapabilityPush is pushing images.
+	RegistryCapabilityPush RegistryCapability = "push"
+)
+
+// RegistryTLSOptions are the TLS settings of a registry host.
+type RegistryTLSOptions struct {
+	// CertificateAuthority is a base64-encoded CA bundle that is trusted in addition to the system's CAs.
+	CertificateAuthority []byte `json:"certificateAuthority,omitempty"`
+
+	// ClientCertificate is the path of a PEM-encoded client certificate for mutual TLS.
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+
+	// ClientKey is the path of the PEM-encoded private key of the `clientCertificate`.
+	ClientKey string `json:"clientKey,omitempty"`
+
+	// InsecureSkipVerify disables verifying the host's certificate. It should only be used for development.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ContainerdReadinessOptions control the checks `nodeadm` performs before considering `containerd` ready.
+type ContainerdReadinessOptions struct {
+	// Timeout is the maximum amount of time to wait for `containerd` to become ready. Defaults to 1m.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Conditions are the [CRI runtime conditions](https://github.com/kubernetes/cri-api/blob/v0.29.1/pkg/apis/runtime/v1/constants.go)
+	// that must be true before `containerd` is considered ready. Defaults to `RuntimeReady`.
+	Conditions []RuntimeCondition `json:"conditions,omitempty"`
+}
+
+// SandboxImagePullOptions control how `nodeadm` pulls the sandbox image. The credentials for the pull come from the
+// first `kubelet` image credential provider whose `matchImages` match the image, and the image is pulled anonymously
+// when none does.
+type SandboxImagePullOptions struct {
+	// Timeout is the maximum amount of time to spend pulling the sandbox image, including every attempt. Defaults to 5m.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Attempts is the number of times the pull is attempted. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Backoff is the wait after the first failed attempt, which doubles after each further attempt. Defaults to 2s.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// PreloadImagesOptions are the images that `nodeadm` caches in `containerd`, such as those of the CNI plugin,
+// `kube-proxy` or other DaemonSets.
+type PreloadImagesOptions struct {
+	// Images are pulled through the CRI, with the same credentials as the sandbox image.
+	Images []PreloadImage `json:"images,omitempty"`
+
+	// Archives are OCI or Docker image tarballs on the node that are imported into the `k8s.io` namespace before the
+	// images are pulled, for nodes without access to a registry.
+	Archives []PreloadImageArchive `json:"archives,omitempty"`
+
+	// Parallelism is the maximum number of images that are pulled at once. Defaults to 4.
+	// +kubebuilder:validation:Minimum=1
+	Parallelism int32 `json:"parallelism,omitempty"`
+
+	// Timeout is the maximum amount of time to spend importing and pulling the images. Defaults to 10m.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// PreloadImage is an image to pull.
+type PreloadImage struct {
+	// Image is the reference of the image, such as `registry.k8s.io/kube-proxy:v1.29.0`.
+	Image string `json:"image"`
+
+	// Pinned images are not removed by the `kubelet` image garbage collection.
+	Pinned bool `json:"pinned,omitempty"`
+}
+
+// PreloadImageArchive is an image tarball to import.
+type PreloadImageArchive struct {
+	// Path is the absolute path of the tarball, in the format written by `docker save` or `ctr images export`, optionally compressed with gzip.
+	// The tarball must name at least one image, or the import fails.
+	Path string `json:"path"`
+
+	// Pinned images are not removed by the `kubelet` image garbage collection.
+	Pinned bool `json:"pinned,omitempty"`
+}
+
+// RuntimeCondition is a condition reported by the container runtime through the CRI `Status` call.
+// +kubebuilder:validation:Enum={RuntimeReady, NetworkReady}
+type RuntimeCondition string
+
+const (
+	// RuntimeReady means the runtime is up and ready to accept basic containers.
+	RuntimeReady RuntimeCondition = "RuntimeReady"
+
+	// NetworkReady means the runtime network is up and ready to accept containers which require network.
+	NetworkReady RuntimeCondition = "NetworkReady"
+)
+
+// SystemdOptions are settings for a daemon's systemd unit. They are written to a
+// [drop-in](https://www.freedesktop.org/software/systemd/man/latest/systemd.unit.html) that
+// overrides the unit file shipped with the AMI.
+type SystemdOptions struct {
+	// After are additional units that the daemon is ordered after when they are started together.
+	After []string `json:"after,omitempty"`
+
+	// Environment are additional environment variables set for the daemon's process.
+	Environment map[string]string `json:"environment,omitempty"`
+
+	// LimitNOFILE is the limit on the number of open file descriptors, such as `1048576` or `infinity`.
+	LimitNOFILE string `json:"limitNOFILE,omitempty"`
+
+	// MemoryHigh is the memory throttling limit of the daemon's cgroup, such as `4G` or `80%`.
+	MemoryHigh string `json:"memoryHigh,omitempty"`
+
+	// CPUWeight is the relative CPU weight of the daemon's cgroup, between `1` and `10000` or `idle`.
+	CPUWeight string `json:"cpuWeight,omitempty"`
+
+	// Slice is the slice unit the daemon's cgroup is placed in, such as `runtime.slice`.
+	Slice string `json:"slice,omitempty"`
+
+	// Restart determines when systemd restarts the daemon. One of `no`, `always`, `on-success`,
+	// `on-failure`, `on-abnormal`, `on-abort` or `on-watchdog`.
+	Restart string `json:"restart,omitempty"`
 }
 
 // InstanceOptions determines how the node's operating system and devices are configured.
 type InstanceOptions struct {
 	LocalStorage LocalStorageOptions `json:"localStorage,omitempty"`
+
+	// DaemonManager determines how `nodeadm init` runs daemons, and is overridden by its `--daemon-manager` flag.
+	// Defaults to `systemd`.
+	DaemonManager DaemonManager `json:"daemonManager,omitempty"`
 }
 
+// DaemonManager specifies how daemons are run.
+// +kubebuilder:validation:Enum={systemd, process}
+type DaemonManager string
+
+const (
+	// DaemonManagerSystemd runs daemons as systemd units.
+	DaemonManagerSystemd DaemonManager = "systemd"
+
+	// DaemonManagerProcess runs daemons as child processes of `nodeadm`, which keeps running to supervise them.
+	// Systemd unit settings, such as `spec.kubelet.systemd`, are not supported.
+	DaemonManagerProcess DaemonManager = "process"
+)
+
 // LocalStorageOptions control how [EC2 instance stores](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/InstanceStorage.html)
 // are used when available.
 type LocalStorageOptions struct {
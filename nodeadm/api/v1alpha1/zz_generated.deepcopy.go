@@ -5,9 +5,25 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAuthOptions) DeepCopyInto(out *ClusterAuthOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAuthOptions.
+func (in *ClusterAuthOptions) DeepCopy() *ClusterAuthOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAuthOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDetails) DeepCopyInto(out *ClusterDetails) {
 	*out = *in
@@ -21,6 +37,12 @@ func (in *ClusterDetails) DeepCopyInto(out *ClusterDetails) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.HostsRefreshInterval != nil {
+		in, out := &in.HostsRefreshInterval, &out.HostsRefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	out.Auth = in.Auth
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDetails.
@@ -33,6 +55,35 @@ func (in *ClusterDetails) DeepCopy() *ClusterDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRuntime) DeepCopyInto(out *ContainerRuntime) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.BaseRuntimeSpec != nil {
+		in, out := &in.BaseRuntimeSpec, &out.BaseRuntimeSpec
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRuntime.
+func (in *ContainerRuntime) DeepCopy() *ContainerRuntime {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRuntime)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerdOptions) DeepCopyInto(out *ContainerdOptions) {
 	*out = *in
@@ -43,6 +94,30 @@ func (in *ContainerdOptions) DeepCopyInto(out *ContainerdOptions) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.BaseRuntimeSpecPresets != nil {
+		in, out := &in.BaseRuntimeSpecPresets, &out.BaseRuntimeSpecPresets
+		*out = make([]BaseRuntimeSpecPreset, len(*in))
+		copy(*out, *in)
+	}
+	out.Snapshotter = in.Snapshotter
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make([]ContainerRuntime, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]RegistryOptions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Readiness.DeepCopyInto(&out.Readiness)
+	in.SandboxImagePull.DeepCopyInto(&out.SandboxImagePull)
+	in.PreloadImages.DeepCopyInto(&out.PreloadImages)
+	in.Systemd.DeepCopyInto(&out.Systemd)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdOptions.
@@ -55,6 +130,120 @@ func (in *ContainerdOptions) DeepCopy() *ContainerdOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdReadinessOptions) DeepCopyInto(out *ContainerdReadinessOptions) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RuntimeCondition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdReadinessOptions.
+func (in *ContainerdReadinessOptions) DeepCopy() *ContainerdReadinessOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdReadinessOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GracefulShutdownOptions) DeepCopyInto(out *GracefulShutdownOptions) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CriticalPodsGracePeriod != nil {
+		in, out := &in.CriticalPodsGracePeriod, &out.CriticalPodsGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ByPodPriority != nil {
+		in, out := &in.ByPodPriority, &out.ByPodPriority
+		*out = make([]ShutdownGracePeriodByPodPriority, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulShutdownOptions.
+func (in *GracefulShutdownOptions) DeepCopy() *GracefulShutdownOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GracefulShutdownOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageCredentialProvider) DeepCopyInto(out *ImageCredentialProvider) {
+	*out = *in
+	if in.MatchImages != nil {
+		in, out := &in.MatchImages, &out.MatchImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultCacheDuration != nil {
+		in, out := &in.DefaultCacheDuration, &out.DefaultCacheDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCredentialProvider.
+func (in *ImageCredentialProvider) DeepCopy() *ImageCredentialProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageCredentialProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageCredentialProviderOptions) DeepCopyInto(out *ImageCredentialProviderOptions) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ImageCredentialProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageCredentialProviderOptions.
+func (in *ImageCredentialProviderOptions) DeepCopy() *ImageCredentialProviderOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageCredentialProviderOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceOptions) DeepCopyInto(out *InstanceOptions) {
 	*out = *in
@@ -71,6 +260,28 @@ func (in *InstanceOptions) DeepCopy() *InstanceOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfigDropIn) DeepCopyInto(out *KubeletConfigDropIn) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfigDropIn.
+func (in *KubeletConfigDropIn) DeepCopy() *KubeletConfigDropIn {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletConfigDropIn)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeletOptions) DeepCopyInto(out *KubeletOptions) {
 	*out = *in
@@ -81,11 +292,26 @@ func (in *KubeletOptions) DeepCopyInto(out *KubeletOptions) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.ConfigDropIns != nil {
+		in, out := &in.ConfigDropIns, &out.ConfigDropIns
+		*out = make([]KubeletConfigDropIn, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Flags != nil {
 		in, out := &in.Flags, &out.Flags
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.Readiness.DeepCopyInto(&out.Readiness)
+	in.Systemd.DeepCopyInto(&out.Systemd)
+	in.MaxPods.DeepCopyInto(&out.MaxPods)
+	in.ReservedResources.DeepCopyInto(&out.ReservedResources)
+	in.ImageCredentialProviders.DeepCopyInto(&out.ImageCredentialProviders)
+	in.GracefulShutdown.DeepCopyInto(&out.GracefulShutdown)
+	out.NodeName = in.NodeName
+	in.NodeIP.DeepCopyInto(&out.NodeIP)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletOptions.
@@ -98,6 +324,31 @@ func (in *KubeletOptions) DeepCopy() *KubeletOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletReadinessOptions) DeepCopyInto(out *KubeletReadinessOptions) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.WaitForNodeRegistration != nil {
+		in, out := &in.WaitForNodeRegistration, &out.WaitForNodeRegistration
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletReadinessOptions.
+func (in *KubeletReadinessOptions) DeepCopy() *KubeletReadinessOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletReadinessOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalStorageOptions) DeepCopyInto(out *LocalStorageOptions) {
 	*out = *in
@@ -113,6 +364,31 @@ func (in *LocalStorageOptions) DeepCopy() *LocalStorageOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxPodsOptions) DeepCopyInto(out *MaxPodsOptions) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CNIMaxENI != nil {
+		in, out := &in.CNIMaxENI, &out.CNIMaxENI
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxPodsOptions.
+func (in *MaxPodsOptions) DeepCopy() *MaxPodsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxPodsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeConfig) DeepCopyInto(out *NodeConfig) {
 	*out = *in
@@ -196,3 +472,316 @@ func (in *NodeConfigSpec) DeepCopy() *NodeConfigSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeIPOptions) DeepCopyInto(out *NodeIPOptions) {
+	*out = *in
+	if in.DeviceIndex != nil {
+		in, out := &in.DeviceIndex, &out.DeviceIndex
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeIPOptions.
+func (in *NodeIPOptions) DeepCopy() *NodeIPOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeIPOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeNameOptions) DeepCopyInto(out *NodeNameOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeNameOptions.
+func (in *NodeNameOptions) DeepCopy() *NodeNameOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeNameOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreloadImage) DeepCopyInto(out *PreloadImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreloadImage.
+func (in *PreloadImage) DeepCopy() *PreloadImage {
+	if in == nil {
+		return nil
+	}
+	out := new(PreloadImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreloadImageArchive) DeepCopyInto(out *PreloadImageArchive) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreloadImageArchive.
+func (in *PreloadImageArchive) DeepCopy() *PreloadImageArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(PreloadImageArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreloadImagesOptions) DeepCopyInto(out *PreloadImagesOptions) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]PreloadImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Archives != nil {
+		in, out := &in.Archives, &out.Archives
+		*out = make([]PreloadImageArchive, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreloadImagesOptions.
+func (in *PreloadImagesOptions) DeepCopy() *PreloadImagesOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PreloadImagesOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySnapshotterOptions) DeepCopyInto(out *ProxySnapshotterOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySnapshotterOptions.
+func (in *ProxySnapshotterOptions) DeepCopy() *ProxySnapshotterOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySnapshotterOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]RegistryCapability, len(*in))
+		copy(*out, *in)
+	}
+	in.TLS.DeepCopyInto(&out.TLS)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryOptions) DeepCopyInto(out *RegistryOptions) {
+	*out = *in
+	in.ServerTLS.DeepCopyInto(&out.ServerTLS)
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]RegistryMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryOptions.
+func (in *RegistryOptions) DeepCopy() *RegistryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryTLSOptions) DeepCopyInto(out *RegistryTLSOptions) {
+	*out = *in
+	if in.CertificateAuthority != nil {
+		in, out := &in.CertificateAuthority, &out.CertificateAuthority
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryTLSOptions.
+func (in *RegistryTLSOptions) DeepCopy() *RegistryTLSOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryTLSOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservedResourcesOptions) DeepCopyInto(out *ReservedResourcesOptions) {
+	*out = *in
+	if in.KubeReserved != nil {
+		in, out := &in.KubeReserved, &out.KubeReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SystemReserved != nil {
+		in, out := &in.SystemReserved, &out.SystemReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KubeReservedCgroup != nil {
+		in, out := &in.KubeReservedCgroup, &out.KubeReservedCgroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.SystemReservedCgroup != nil {
+		in, out := &in.SystemReservedCgroup, &out.SystemReservedCgroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnforceNodeAllocatable != nil {
+		in, out := &in.EnforceNodeAllocatable, &out.EnforceNodeAllocatable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservedResourcesOptions.
+func (in *ReservedResourcesOptions) DeepCopy() *ReservedResourcesOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservedResourcesOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SandboxImagePullOptions) DeepCopyInto(out *SandboxImagePullOptions) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SandboxImagePullOptions.
+func (in *SandboxImagePullOptions) DeepCopy() *SandboxImagePullOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SandboxImagePullOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShutdownGracePeriodByPodPriority) DeepCopyInto(out *ShutdownGracePeriodByPodPriority) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShutdownGracePeriodByPodPriority.
+func (in *ShutdownGracePeriodByPodPriority) DeepCopy() *ShutdownGracePeriodByPodPriority {
+	if in == nil {
+		return nil
+	}
+	out := new(ShutdownGracePeriodByPodPriority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotterOptions) DeepCopyInto(out *SnapshotterOptions) {
+	*out = *in
+	out.Proxy = in.Proxy
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotterOptions.
+func (in *SnapshotterOptions) DeepCopy() *SnapshotterOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotterOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemdOptions) DeepCopyInto(out *SystemdOptions) {
+	*out = *in
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemdOptions.
+func (in *SystemdOptions) DeepCopy() *SystemdOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemdOptions)
+	in.DeepCopyInto(out)
+	return out
+}
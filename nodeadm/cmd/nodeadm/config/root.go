@@ -7,5 +7,6 @@ import (
 func NewConfigCommand() cli.Command {
 	container := cli.NewCommandContainer("config", "Manage configuration")
 	container.AddCommand(NewCheckCommand())
+	container.AddCommand(NewShowCommand())
 	return container.AsCommand()
 }
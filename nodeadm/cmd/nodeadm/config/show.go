@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/configprovider"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+)
+
+type showCmd struct {
+	cmd *flaggy.Subcommand
+}
+
+func NewShowCommand() cli.Command {
+	cmd := flaggy.NewSubcommand("show")
+	cmd.Description = "Show the resolved configuration, including values generated for this instance"
+	return &showCmd{
+		cmd: cmd,
+	}
+}
+
+func (c *showCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *showCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	log.Info("Loading configuration", zap.String("source", opts.ConfigSource))
+	provider, err := configprovider.BuildConfigProvider(opts.ConfigSource)
+	if err != nil {
+		return err
+	}
+	nodeConfig, err := provider.Provide()
+	if err != nil {
+		return err
+	}
+	if err := configprovider.EnrichConfig(log, nodeConfig); err != nil {
+		return err
+	}
+	if err := api.ValidateNodeConfig(nodeConfig); err != nil {
+		return err
+	}
+	if err := kubelet.ResolveReservedResources(nodeConfig); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(nodeConfig)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
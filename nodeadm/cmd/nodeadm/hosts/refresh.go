@@ -0,0 +1,53 @@
+package hosts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/integrii/flaggy"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+)
+
+type refreshCmd struct {
+	cmd      *flaggy.Subcommand
+	hostname string
+	interval time.Duration
+}
+
+func NewRefreshCommand() cli.Command {
+	cmd := refreshCmd{}
+	cmd.cmd = flaggy.NewSubcommand("refresh")
+	cmd.cmd.String(&cmd.hostname, "n", "hostname", "the hostname whose addresses are managed in /etc/hosts")
+	cmd.cmd.Duration(&cmd.interval, "i", "interval", "keep running and refresh the addresses at this interval")
+	cmd.cmd.Description = "Refresh the managed addresses of a hostname in /etc/hosts"
+	return &cmd
+}
+
+func (c *refreshCmd) Flaggy() *flaggy.Subcommand {
+	return c.cmd
+}
+
+func (c *refreshCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
+	root, err := cli.IsRunningAsRoot()
+	if err != nil {
+		return err
+	} else if !root {
+		return cli.ErrMustRunAsRoot
+	}
+	if c.hostname == "" {
+		return fmt.Errorf("hostname is required")
+	}
+	for {
+		err := system.UpdateHostsBlock(c.hostname)
+		if c.interval <= 0 {
+			return err
+		}
+		if err != nil {
+			log.Error("Failed to refresh /etc/hosts", zap.String("hostname", c.hostname), zap.Error(err))
+		}
+		time.Sleep(c.interval)
+	}
+}
@@ -0,0 +1,11 @@
+package hosts
+
+import (
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
+)
+
+func NewHostsCommand() cli.Command {
+	container := cli.NewCommandContainer("hosts", "Manage the entries nodeadm writes to /etc/hosts")
+	container.AddCommand(NewRefreshCommand())
+	return container.AsCommand()
+}
@@ -1,24 +1,26 @@
 package init
 
 import (
-	"context"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
-	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/integrii/flaggy"
 	"go.uber.org/zap"
 	"k8s.io/utils/strings/slices"
 
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ecr"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/cli"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/configprovider"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/dag"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/kubelet"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/snapshotter"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
 )
 
 const (
@@ -26,19 +28,24 @@ const (
 	runPhase    = "run"
 )
 
+// backupDir holds the previous versions of the files written by the last init
+const backupDir = "/var/lib/nodeadm/backup"
+
 func NewInitCommand() cli.Command {
 	init := initCmd{}
 	init.cmd = flaggy.NewSubcommand("init")
-	init.cmd.StringSlice(&init.daemons, "d", "daemon", "specify one or more of `containerd` and `kubelet`. This is intended for testing and should not be used in a production environment.")
+	init.cmd.StringSlice(&init.daemons, "d", "daemon", "specify one or more of `snapshotter`, `containerd` and `kubelet`. This is intended for testing and should not be used in a production environment.")
 	init.cmd.StringSlice(&init.skipPhases, "s", "skip", "phases of the bootstrap you want to skip")
+	init.cmd.String(&init.daemonManager, "m", "daemon-manager", "how daemons are run, either `systemd` or `process`, overriding `spec.instance.daemonManager`. With `process`, daemons are run as child processes and nodeadm keeps running to supervise them.")
 	init.cmd.Description = "Initialize this instance as a node in an EKS cluster"
 	return &init
 }
 
 type initCmd struct {
-	cmd        *flaggy.Subcommand
-	skipPhases []string
-	daemons    []string
+	cmd           *flaggy.Subcommand
+	skipPhases    []string
+	daemons       []string
+	daemonManager string
 }
 
 func (c *initCmd) Flaggy() *flaggy.Subcommand {
@@ -66,17 +73,29 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	log.Info("Loaded configuration", zap.Reflect("config", nodeConfig))
 
 	log.Info("Enriching configuration..")
-	if err := enrichConfig(log, nodeConfig); err != nil {
+	if err := configprovider.EnrichConfig(log, nodeConfig); err != nil {
 		return err
 	}
 
+	if c.daemonManager != "" {
+		nodeConfig.Spec.Instance.DaemonManager = api.DaemonManager(c.daemonManager)
+	}
+
 	zap.L().Info("Validating configuration..")
 	if err := api.ValidateNodeConfig(nodeConfig); err != nil {
 		return err
 	}
+	if err := kubelet.ValidateUserKubeletConfig(nodeConfig); err != nil {
+		return err
+	}
+	if err := kubelet.ResolveReservedResources(nodeConfig); err != nil {
+		return err
+	}
+	log.Info("Reserved resources resolved", zap.Reflect("reservedResources", nodeConfig.Status.ReservedResources))
 
-	log.Info("Creating daemon manager..")
-	daemonManager, err := daemon.NewDaemonManager()
+	daemonManagerType := api.GetDaemonManager(nodeConfig.Spec.Instance)
+	log.Info("Creating daemon manager..", zap.String("type", string(daemonManagerType)))
+	daemonManager, err := newDaemonManager(daemonManagerType)
 	if err != nil {
 		return err
 	}
@@ -85,88 +104,134 @@ func (c *initCmd) Run(log *zap.Logger, opts *cli.GlobalOptions) error {
 	aspects := []system.SystemAspect{
 		system.NewLocalDiskAspect(),
 		system.NewNetworkingAspect(),
+		system.NewReservedCgroupsAspect(daemonManager),
 	}
 
 	daemons := []daemon.Daemon{
+		snapshotter.NewSnapshotterDaemon(daemonManager),
 		containerd.NewContainerdDaemon(daemonManager),
 		kubelet.NewKubeletDaemon(daemonManager),
 	}
 
-	if !slices.Contains(c.skipPhases, configPhase) {
-		log.Info("Configuring daemons...")
-		for _, daemon := range daemons {
-			if len(c.daemons) > 0 && !slices.Contains(c.daemons, daemon.Name()) {
-				continue
-			}
-			nameField := zap.String("name", daemon.Name())
-
-			log.Info("Configuring daemon...", nameField)
-			if err := daemon.Configure(nodeConfig); err != nil {
-				return err
-			}
-			log.Info("Configured daemon", nameField)
+	var selectedDaemons []daemon.Daemon
+	for _, daemon := range daemons {
+		if len(c.daemons) == 0 || slices.Contains(c.daemons, daemon.Name()) {
+			selectedDaemons = append(selectedDaemons, daemon)
 		}
 	}
 
-	if !slices.Contains(c.skipPhases, runPhase) {
-		log.Info("Setting up system aspects...")
-		for _, aspect := range aspects {
-			nameField := zap.String("name", aspect.Name())
-			log.Info("Setting up system aspect..", nameField)
-			if err := aspect.Setup(nodeConfig); err != nil {
-				return err
-			}
-			log.Info("Set up system aspect", nameField)
+	transaction, err := util.BeginFileTransaction(backupDir)
+	if err != nil {
+		return err
+	}
+	if err := c.runPhases(log, nodeConfig, aspects, selectedDaemons); err != nil {
+		log.Error("Init failed, restoring files changed by this run..", zap.Error(err))
+		if rollbackErr := transaction.Rollback(); rollbackErr != nil {
+			log.Error("Failed to restore files", zap.Error(rollbackErr))
 		}
-		for _, daemon := range daemons {
-			if len(c.daemons) > 0 && !slices.Contains(c.daemons, daemon.Name()) {
-				continue
-			}
+		if reloadErr := daemon.ReloadRestoredDropIns(daemonManager, transaction.Files()); reloadErr != nil {
+			log.Error("Failed to reload restored unit drop-ins", zap.Error(reloadErr))
+		}
+		return err
+	}
+	transaction.Commit()
+
+	if !slices.Contains(c.skipPhases, runPhase) && daemonManagerType == api.DaemonManagerProcess {
+		// the daemons are child processes, which are stopped when the
+		// daemon manager is closed
+		log.Info("Supervising daemons until nodeadm is terminated..")
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		sig := <-signals
+		log.Info("Received signal, stopping daemons..", zap.String("signal", sig.String()))
+	}
 
-			nameField := zap.String("name", daemon.Name())
+	return nil
+}
 
-			log.Info("Ensuring daemon is running..", nameField)
-			if err := daemon.EnsureRunning(); err != nil {
-				return err
-			}
-			log.Info("Daemon is running", nameField)
+// runPhases runs the phases of init that were not skipped. Every file these
+// phases write is recorded, so that the files can be restored on failure.
+func (c *initCmd) runPhases(log *zap.Logger, nodeConfig *api.NodeConfig, aspects []system.SystemAspect, daemons []daemon.Daemon) error {
+	if !slices.Contains(c.skipPhases, configPhase) {
+		log.Info("Configuring daemons...")
+		if err := buildConfigGraph(nodeConfig, daemons).Run(); err != nil {
+			return err
+		}
+	}
 
-			log.Info("Running post-launch tasks..", nameField)
-			if err := daemon.PostLaunch(nodeConfig); err != nil {
-				return err
-			}
-			log.Info("Finished post-launch tasks", nameField)
+	if !slices.Contains(c.skipPhases, runPhase) {
+		log.Info("Setting up system aspects and running daemons...")
+		if err := buildRunGraph(nodeConfig, aspects, daemons).Run(); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// Various initializations and verifications of the NodeConfig and
-// perform in-place updates when allowed by the user
-func enrichConfig(log *zap.Logger, cfg *api.NodeConfig) error {
-	log.Info("Fetching instance details..")
-	imdsClient := imds.New(imds.Options{})
-	awsConfig, err := config.LoadDefaultConfig(context.TODO(), config.WithClientLogMode(aws.LogRetries), config.WithEC2IMDSRegion(func(o *config.UseEC2IMDSRegion) {
-		o.Client = imdsClient
-	}))
-	if err != nil {
-		return err
+// buildConfigGraph returns the steps that configure each daemon, which are
+// independent of each other.
+func buildConfigGraph(cfg *api.NodeConfig, daemons []daemon.Daemon) *dag.Graph {
+	g := dag.New()
+	for _, d := range daemons {
+		d := d
+		g.Add("configure:"+d.Name(), func() error { return d.Configure(cfg) })
 	}
-	instanceDetails, err := api.GetInstanceDetails(context.TODO(), cfg.Spec.FeatureGates, imdsClient, ec2.NewFromConfig(awsConfig))
-	if err != nil {
-		return err
+	return g
+}
+
+// buildRunGraph returns the steps that set up the system aspects and then
+// start each daemon. A daemon is started once every aspect has been set up and
+// the daemons it depends on are ready, and its post-launch tasks run alongside
+// the daemons that depend on it.
+func buildRunGraph(cfg *api.NodeConfig, aspects []system.SystemAspect, daemons []daemon.Daemon) *dag.Graph {
+	g := dag.New()
+	var aspectSteps []string
+	for _, a := range aspects {
+		a := a
+		var dependsOn []string
+		if dependent, ok := a.(system.DependentAspect); ok {
+			for _, dependency := range dependent.Dependencies() {
+				dependsOn = append(dependsOn, "setup:"+dependency)
+			}
+		}
+		g.Add("setup:"+a.Name(), func() error { return a.Setup(cfg) }, dependsOn...)
+		aspectSteps = append(aspectSteps, "setup:"+a.Name())
 	}
-	cfg.Status.Instance = *instanceDetails
-	log.Info("Instance details populated", zap.Reflect("details", instanceDetails))
-	log.Info("Fetching default options...")
-	eksRegistry, err := ecr.GetEKSRegistry(instanceDetails.Region)
-	if err != nil {
-		return err
+	var daemonNames []string
+	for _, d := range daemons {
+		daemonNames = append(daemonNames, d.Name())
+	}
+	for _, d := range daemons {
+		d := d
+		startDependsOn := append([]string{}, aspectSteps...)
+		if dependent, ok := d.(daemon.DependentDaemon); ok {
+			for _, dependency := range dependent.Dependencies() {
+				// dependencies on daemons that were not selected are ignored
+				if slices.Contains(daemonNames, dependency) {
+					startDependsOn = append(startDependsOn, "ready:"+dependency)
+				}
+			}
+		}
+		g.Add("start:"+d.Name(), d.EnsureRunning, startDependsOn...)
+		g.Add("ready:"+d.Name(), func() error { return d.WaitUntilReady(cfg) }, "start:"+d.Name())
+		g.Add("post-launch:"+d.Name(), func() error { return d.PostLaunch(cfg) }, "ready:"+d.Name())
 	}
-	cfg.Status.Defaults = api.DefaultOptions{
-		SandboxImage: eksRegistry.GetSandboxImage(),
+	return g
+}
+
+func newDaemonManager(daemonManagerType api.DaemonManager) (daemon.DaemonManager, error) {
+	switch daemonManagerType {
+	case api.DaemonManagerSystemd:
+		return daemon.NewDaemonManager()
+	case api.DaemonManagerProcess:
+		specs := map[string]daemon.ProcessSpec{
+			containerd.ContainerdDaemonName: containerd.ProcessSpec,
+			kubelet.KubeletDaemonName:       kubelet.ProcessSpec,
+		}
+		maps.Copy(specs, snapshotter.ProcessSpecs)
+		return daemon.NewProcessDaemonManager(specs), nil
+	default:
+		return nil, fmt.Errorf("unknown daemon manager: %s", daemonManagerType)
 	}
-	log.Info("Default options populated", zap.Reflect("defaults", cfg.Status.Defaults))
-	return nil
 }
@@ -10,6 +10,7 @@ import (
 
 	v1alpha1 "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
 	api "github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -21,6 +22,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ClusterAuthOptions)(nil), (*api.ClusterAuthOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterAuthOptions_To_api_ClusterAuthOptions(a.(*v1alpha1.ClusterAuthOptions), b.(*api.ClusterAuthOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ClusterAuthOptions)(nil), (*v1alpha1.ClusterAuthOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ClusterAuthOptions_To_v1alpha1_ClusterAuthOptions(a.(*api.ClusterAuthOptions), b.(*v1alpha1.ClusterAuthOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.ClusterDetails)(nil), (*api.ClusterDetails)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ClusterDetails_To_api_ClusterDetails(a.(*v1alpha1.ClusterDetails), b.(*api.ClusterDetails), scope)
 	}); err != nil {
@@ -31,6 +42,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerRuntime)(nil), (*api.ContainerRuntime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerRuntime_To_api_ContainerRuntime(a.(*v1alpha1.ContainerRuntime), b.(*api.ContainerRuntime), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerRuntime)(nil), (*v1alpha1.ContainerRuntime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerRuntime_To_v1alpha1_ContainerRuntime(a.(*api.ContainerRuntime), b.(*v1alpha1.ContainerRuntime), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdOptions)(nil), (*api.ContainerdOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(a.(*v1alpha1.ContainerdOptions), b.(*api.ContainerdOptions), scope)
 	}); err != nil {
@@ -41,6 +62,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ContainerdReadinessOptions)(nil), (*api.ContainerdReadinessOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerdReadinessOptions_To_api_ContainerdReadinessOptions(a.(*v1alpha1.ContainerdReadinessOptions), b.(*api.ContainerdReadinessOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ContainerdReadinessOptions)(nil), (*v1alpha1.ContainerdReadinessOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ContainerdReadinessOptions_To_v1alpha1_ContainerdReadinessOptions(a.(*api.ContainerdReadinessOptions), b.(*v1alpha1.ContainerdReadinessOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.GracefulShutdownOptions)(nil), (*api.GracefulShutdownOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_GracefulShutdownOptions_To_api_GracefulShutdownOptions(a.(*v1alpha1.GracefulShutdownOptions), b.(*api.GracefulShutdownOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.GracefulShutdownOptions)(nil), (*v1alpha1.GracefulShutdownOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_GracefulShutdownOptions_To_v1alpha1_GracefulShutdownOptions(a.(*api.GracefulShutdownOptions), b.(*v1alpha1.GracefulShutdownOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ImageCredentialProvider)(nil), (*api.ImageCredentialProvider)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ImageCredentialProvider_To_api_ImageCredentialProvider(a.(*v1alpha1.ImageCredentialProvider), b.(*api.ImageCredentialProvider), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ImageCredentialProvider)(nil), (*v1alpha1.ImageCredentialProvider)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ImageCredentialProvider_To_v1alpha1_ImageCredentialProvider(a.(*api.ImageCredentialProvider), b.(*v1alpha1.ImageCredentialProvider), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ImageCredentialProviderOptions)(nil), (*api.ImageCredentialProviderOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ImageCredentialProviderOptions_To_api_ImageCredentialProviderOptions(a.(*v1alpha1.ImageCredentialProviderOptions), b.(*api.ImageCredentialProviderOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ImageCredentialProviderOptions)(nil), (*v1alpha1.ImageCredentialProviderOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ImageCredentialProviderOptions_To_v1alpha1_ImageCredentialProviderOptions(a.(*api.ImageCredentialProviderOptions), b.(*v1alpha1.ImageCredentialProviderOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.InstanceOptions)(nil), (*api.InstanceOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_InstanceOptions_To_api_InstanceOptions(a.(*v1alpha1.InstanceOptions), b.(*api.InstanceOptions), scope)
 	}); err != nil {
@@ -51,6 +112,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.KubeletConfigDropIn)(nil), (*api.KubeletConfigDropIn)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeletConfigDropIn_To_api_KubeletConfigDropIn(a.(*v1alpha1.KubeletConfigDropIn), b.(*api.KubeletConfigDropIn), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.KubeletConfigDropIn)(nil), (*v1alpha1.KubeletConfigDropIn)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_KubeletConfigDropIn_To_v1alpha1_KubeletConfigDropIn(a.(*api.KubeletConfigDropIn), b.(*v1alpha1.KubeletConfigDropIn), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.KubeletOptions)(nil), (*api.KubeletOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions(a.(*v1alpha1.KubeletOptions), b.(*api.KubeletOptions), scope)
 	}); err != nil {
@@ -61,6 +132,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.KubeletReadinessOptions)(nil), (*api.KubeletReadinessOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeletReadinessOptions_To_api_KubeletReadinessOptions(a.(*v1alpha1.KubeletReadinessOptions), b.(*api.KubeletReadinessOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.KubeletReadinessOptions)(nil), (*v1alpha1.KubeletReadinessOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_KubeletReadinessOptions_To_v1alpha1_KubeletReadinessOptions(a.(*api.KubeletReadinessOptions), b.(*v1alpha1.KubeletReadinessOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.LocalStorageOptions)(nil), (*api.LocalStorageOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(a.(*v1alpha1.LocalStorageOptions), b.(*api.LocalStorageOptions), scope)
 	}); err != nil {
@@ -71,6 +152,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.MaxPodsOptions)(nil), (*api.MaxPodsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MaxPodsOptions_To_api_MaxPodsOptions(a.(*v1alpha1.MaxPodsOptions), b.(*api.MaxPodsOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.MaxPodsOptions)(nil), (*v1alpha1.MaxPodsOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_MaxPodsOptions_To_v1alpha1_MaxPodsOptions(a.(*api.MaxPodsOptions), b.(*v1alpha1.MaxPodsOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha1.NodeConfig)(nil), (*api.NodeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_NodeConfig_To_api_NodeConfig(a.(*v1alpha1.NodeConfig), b.(*api.NodeConfig), scope)
 	}); err != nil {
@@ -101,9 +192,177 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.NodeIPOptions)(nil), (*api.NodeIPOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(a.(*v1alpha1.NodeIPOptions), b.(*api.NodeIPOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeIPOptions)(nil), (*v1alpha1.NodeIPOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(a.(*api.NodeIPOptions), b.(*v1alpha1.NodeIPOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.NodeNameOptions)(nil), (*api.NodeNameOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodeNameOptions_To_api_NodeNameOptions(a.(*v1alpha1.NodeNameOptions), b.(*api.NodeNameOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeNameOptions)(nil), (*v1alpha1.NodeNameOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeNameOptions_To_v1alpha1_NodeNameOptions(a.(*api.NodeNameOptions), b.(*v1alpha1.NodeNameOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PreloadImage)(nil), (*api.PreloadImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PreloadImage_To_api_PreloadImage(a.(*v1alpha1.PreloadImage), b.(*api.PreloadImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PreloadImage)(nil), (*v1alpha1.PreloadImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PreloadImage_To_v1alpha1_PreloadImage(a.(*api.PreloadImage), b.(*v1alpha1.PreloadImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PreloadImageArchive)(nil), (*api.PreloadImageArchive)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PreloadImageArchive_To_api_PreloadImageArchive(a.(*v1alpha1.PreloadImageArchive), b.(*api.PreloadImageArchive), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PreloadImageArchive)(nil), (*v1alpha1.PreloadImageArchive)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PreloadImageArchive_To_v1alpha1_PreloadImageArchive(a.(*api.PreloadImageArchive), b.(*v1alpha1.PreloadImageArchive), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PreloadImagesOptions)(nil), (*api.PreloadImagesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PreloadImagesOptions_To_api_PreloadImagesOptions(a.(*v1alpha1.PreloadImagesOptions), b.(*api.PreloadImagesOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PreloadImagesOptions)(nil), (*v1alpha1.PreloadImagesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PreloadImagesOptions_To_v1alpha1_PreloadImagesOptions(a.(*api.PreloadImagesOptions), b.(*v1alpha1.PreloadImagesOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ProxySnapshotterOptions)(nil), (*api.ProxySnapshotterOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProxySnapshotterOptions_To_api_ProxySnapshotterOptions(a.(*v1alpha1.ProxySnapshotterOptions), b.(*api.ProxySnapshotterOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ProxySnapshotterOptions)(nil), (*v1alpha1.ProxySnapshotterOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ProxySnapshotterOptions_To_v1alpha1_ProxySnapshotterOptions(a.(*api.ProxySnapshotterOptions), b.(*v1alpha1.ProxySnapshotterOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryMirror)(nil), (*api.RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryMirror_To_api_RegistryMirror(a.(*v1alpha1.RegistryMirror), b.(*api.RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryMirror)(nil), (*v1alpha1.RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryMirror_To_v1alpha1_RegistryMirror(a.(*api.RegistryMirror), b.(*v1alpha1.RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryOptions)(nil), (*api.RegistryOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryOptions_To_api_RegistryOptions(a.(*v1alpha1.RegistryOptions), b.(*api.RegistryOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryOptions)(nil), (*v1alpha1.RegistryOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryOptions_To_v1alpha1_RegistryOptions(a.(*api.RegistryOptions), b.(*v1alpha1.RegistryOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.RegistryTLSOptions)(nil), (*api.RegistryTLSOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegistryTLSOptions_To_api_RegistryTLSOptions(a.(*v1alpha1.RegistryTLSOptions), b.(*api.RegistryTLSOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.RegistryTLSOptions)(nil), (*v1alpha1.RegistryTLSOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_RegistryTLSOptions_To_v1alpha1_RegistryTLSOptions(a.(*api.RegistryTLSOptions), b.(*v1alpha1.RegistryTLSOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ReservedResourcesOptions)(nil), (*api.ReservedResourcesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ReservedResourcesOptions_To_api_ReservedResourcesOptions(a.(*v1alpha1.ReservedResourcesOptions), b.(*api.ReservedResourcesOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ReservedResourcesOptions)(nil), (*v1alpha1.ReservedResourcesOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ReservedResourcesOptions_To_v1alpha1_ReservedResourcesOptions(a.(*api.ReservedResourcesOptions), b.(*v1alpha1.ReservedResourcesOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SandboxImagePullOptions)(nil), (*api.SandboxImagePullOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SandboxImagePullOptions_To_api_SandboxImagePullOptions(a.(*v1alpha1.SandboxImagePullOptions), b.(*api.SandboxImagePullOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SandboxImagePullOptions)(nil), (*v1alpha1.SandboxImagePullOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SandboxImagePullOptions_To_v1alpha1_SandboxImagePullOptions(a.(*api.SandboxImagePullOptions), b.(*v1alpha1.SandboxImagePullOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ShutdownGracePeriodByPodPriority)(nil), (*api.ShutdownGracePeriodByPodPriority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ShutdownGracePeriodByPodPriority_To_api_ShutdownGracePeriodByPodPriority(a.(*v1alpha1.ShutdownGracePeriodByPodPriority), b.(*api.ShutdownGracePeriodByPodPriority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ShutdownGracePeriodByPodPriority)(nil), (*v1alpha1.ShutdownGracePeriodByPodPriority)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ShutdownGracePeriodByPodPriority_To_v1alpha1_ShutdownGracePeriodByPodPriority(a.(*api.ShutdownGracePeriodByPodPriority), b.(*v1alpha1.ShutdownGracePeriodByPodPriority), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SnapshotterOptions)(nil), (*api.SnapshotterOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotterOptions_To_api_SnapshotterOptions(a.(*v1alpha1.SnapshotterOptions), b.(*api.SnapshotterOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SnapshotterOptions)(nil), (*v1alpha1.SnapshotterOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SnapshotterOptions_To_v1alpha1_SnapshotterOptions(a.(*api.SnapshotterOptions), b.(*v1alpha1.SnapshotterOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.SystemdOptions)(nil), (*api.SystemdOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SystemdOptions_To_api_SystemdOptions(a.(*v1alpha1.SystemdOptions), b.(*api.SystemdOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.SystemdOptions)(nil), (*v1alpha1.SystemdOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_SystemdOptions_To_v1alpha1_SystemdOptions(a.(*api.SystemdOptions), b.(*v1alpha1.SystemdOptions), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
+func autoConvert_v1alpha1_ClusterAuthOptions_To_api_ClusterAuthOptions(in *v1alpha1.ClusterAuthOptions, out *api.ClusterAuthOptions, s conversion.Scope) error {
+	out.Mode = api.ClusterAuthMode(in.Mode)
+	out.RoleARN = in.RoleARN
+	out.BootstrapToken = in.BootstrapToken
+	out.ClientCertificate = in.ClientCertificate
+	out.ClientKey = in.ClientKey
+	return nil
+}
+
+// Convert_v1alpha1_ClusterAuthOptions_To_api_ClusterAuthOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterAuthOptions_To_api_ClusterAuthOptions(in *v1alpha1.ClusterAuthOptions, out *api.ClusterAuthOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterAuthOptions_To_api_ClusterAuthOptions(in, out, s)
+}
+
+func autoConvert_api_ClusterAuthOptions_To_v1alpha1_ClusterAuthOptions(in *api.ClusterAuthOptions, out *v1alpha1.ClusterAuthOptions, s conversion.Scope) error {
+	out.Mode = v1alpha1.ClusterAuthMode(in.Mode)
+	out.RoleARN = in.RoleARN
+	out.BootstrapToken = in.BootstrapToken
+	out.ClientCertificate = in.ClientCertificate
+	out.ClientKey = in.ClientKey
+	return nil
+}
+
+// Convert_api_ClusterAuthOptions_To_v1alpha1_ClusterAuthOptions is an autogenerated conversion function.
+func Convert_api_ClusterAuthOptions_To_v1alpha1_ClusterAuthOptions(in *api.ClusterAuthOptions, out *v1alpha1.ClusterAuthOptions, s conversion.Scope) error {
+	return autoConvert_api_ClusterAuthOptions_To_v1alpha1_ClusterAuthOptions(in, out, s)
+}
+
 func autoConvert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in *v1alpha1.ClusterDetails, out *api.ClusterDetails, s conversion.Scope) error {
 	out.Name = in.Name
 	out.APIServerEndpoint = in.APIServerEndpoint
@@ -111,6 +370,10 @@ func autoConvert_v1alpha1_ClusterDetails_To_api_ClusterDetails(in *v1alpha1.Clus
 	out.CIDR = in.CIDR
 	out.EnableOutpost = (*bool)(unsafe.Pointer(in.EnableOutpost))
 	out.ID = in.ID
+	out.HostsRefreshInterval = (*v1.Duration)(unsafe.Pointer(in.HostsRefreshInterval))
+	if err := Convert_v1alpha1_ClusterAuthOptions_To_api_ClusterAuthOptions(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -126,6 +389,10 @@ func autoConvert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in *api.ClusterDe
 	out.CIDR = in.CIDR
 	out.EnableOutpost = (*bool)(unsafe.Pointer(in.EnableOutpost))
 	out.ID = in.ID
+	out.HostsRefreshInterval = (*v1.Duration)(unsafe.Pointer(in.HostsRefreshInterval))
+	if err := Convert_api_ClusterAuthOptions_To_v1alpha1_ClusterAuthOptions(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -134,9 +401,55 @@ func Convert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in *api.ClusterDetail
 	return autoConvert_api_ClusterDetails_To_v1alpha1_ClusterDetails(in, out, s)
 }
 
+func autoConvert_v1alpha1_ContainerRuntime_To_api_ContainerRuntime(in *v1alpha1.ContainerRuntime, out *api.ContainerRuntime, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = in.Type
+	out.Path = in.Path
+	out.Options = *(*api.InlineDocument)(unsafe.Pointer(&in.Options))
+	out.BaseRuntimeSpec = *(*api.InlineDocument)(unsafe.Pointer(&in.BaseRuntimeSpec))
+	return nil
+}
+
+// Convert_v1alpha1_ContainerRuntime_To_api_ContainerRuntime is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerRuntime_To_api_ContainerRuntime(in *v1alpha1.ContainerRuntime, out *api.ContainerRuntime, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerRuntime_To_api_ContainerRuntime(in, out, s)
+}
+
+func autoConvert_api_ContainerRuntime_To_v1alpha1_ContainerRuntime(in *api.ContainerRuntime, out *v1alpha1.ContainerRuntime, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = in.Type
+	out.Path = in.Path
+	out.Options = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.Options))
+	out.BaseRuntimeSpec = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.BaseRuntimeSpec))
+	return nil
+}
+
+// Convert_api_ContainerRuntime_To_v1alpha1_ContainerRuntime is an autogenerated conversion function.
+func Convert_api_ContainerRuntime_To_v1alpha1_ContainerRuntime(in *api.ContainerRuntime, out *v1alpha1.ContainerRuntime, s conversion.Scope) error {
+	return autoConvert_api_ContainerRuntime_To_v1alpha1_ContainerRuntime(in, out, s)
+}
+
 func autoConvert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.ContainerdOptions, out *api.ContainerdOptions, s conversion.Scope) error {
 	out.Config = in.Config
 	out.BaseRuntimeSpec = *(*api.InlineDocument)(unsafe.Pointer(&in.BaseRuntimeSpec))
+	out.BaseRuntimeSpecPresets = *(*[]api.BaseRuntimeSpecPreset)(unsafe.Pointer(&in.BaseRuntimeSpecPresets))
+	if err := Convert_v1alpha1_SnapshotterOptions_To_api_SnapshotterOptions(&in.Snapshotter, &out.Snapshotter, s); err != nil {
+		return err
+	}
+	out.Runtimes = *(*[]api.ContainerRuntime)(unsafe.Pointer(&in.Runtimes))
+	out.Registries = *(*[]api.RegistryOptions)(unsafe.Pointer(&in.Registries))
+	if err := Convert_v1alpha1_ContainerdReadinessOptions_To_api_ContainerdReadinessOptions(&in.Readiness, &out.Readiness, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SandboxImagePullOptions_To_api_SandboxImagePullOptions(&in.SandboxImagePull, &out.SandboxImagePull, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_PreloadImagesOptions_To_api_PreloadImagesOptions(&in.PreloadImages, &out.PreloadImages, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SystemdOptions_To_api_SystemdOptions(&in.Systemd, &out.Systemd, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -148,6 +461,24 @@ func Convert_v1alpha1_ContainerdOptions_To_api_ContainerdOptions(in *v1alpha1.Co
 func autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.ContainerdOptions, out *v1alpha1.ContainerdOptions, s conversion.Scope) error {
 	out.Config = in.Config
 	out.BaseRuntimeSpec = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.BaseRuntimeSpec))
+	out.BaseRuntimeSpecPresets = *(*[]v1alpha1.BaseRuntimeSpecPreset)(unsafe.Pointer(&in.BaseRuntimeSpecPresets))
+	if err := Convert_api_SnapshotterOptions_To_v1alpha1_SnapshotterOptions(&in.Snapshotter, &out.Snapshotter, s); err != nil {
+		return err
+	}
+	out.Runtimes = *(*[]v1alpha1.ContainerRuntime)(unsafe.Pointer(&in.Runtimes))
+	out.Registries = *(*[]v1alpha1.RegistryOptions)(unsafe.Pointer(&in.Registries))
+	if err := Convert_api_ContainerdReadinessOptions_To_v1alpha1_ContainerdReadinessOptions(&in.Readiness, &out.Readiness, s); err != nil {
+		return err
+	}
+	if err := Convert_api_SandboxImagePullOptions_To_v1alpha1_SandboxImagePullOptions(&in.SandboxImagePull, &out.SandboxImagePull, s); err != nil {
+		return err
+	}
+	if err := Convert_api_PreloadImagesOptions_To_v1alpha1_PreloadImagesOptions(&in.PreloadImages, &out.PreloadImages, s); err != nil {
+		return err
+	}
+	if err := Convert_api_SystemdOptions_To_v1alpha1_SystemdOptions(&in.Systemd, &out.Systemd, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -156,10 +487,107 @@ func Convert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in *api.Contain
 	return autoConvert_api_ContainerdOptions_To_v1alpha1_ContainerdOptions(in, out, s)
 }
 
+func autoConvert_v1alpha1_ContainerdReadinessOptions_To_api_ContainerdReadinessOptions(in *v1alpha1.ContainerdReadinessOptions, out *api.ContainerdReadinessOptions, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Conditions = *(*[]api.RuntimeCondition)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha1_ContainerdReadinessOptions_To_api_ContainerdReadinessOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerdReadinessOptions_To_api_ContainerdReadinessOptions(in *v1alpha1.ContainerdReadinessOptions, out *api.ContainerdReadinessOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerdReadinessOptions_To_api_ContainerdReadinessOptions(in, out, s)
+}
+
+func autoConvert_api_ContainerdReadinessOptions_To_v1alpha1_ContainerdReadinessOptions(in *api.ContainerdReadinessOptions, out *v1alpha1.ContainerdReadinessOptions, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Conditions = *(*[]v1alpha1.RuntimeCondition)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_api_ContainerdReadinessOptions_To_v1alpha1_ContainerdReadinessOptions is an autogenerated conversion function.
+func Convert_api_ContainerdReadinessOptions_To_v1alpha1_ContainerdReadinessOptions(in *api.ContainerdReadinessOptions, out *v1alpha1.ContainerdReadinessOptions, s conversion.Scope) error {
+	return autoConvert_api_ContainerdReadinessOptions_To_v1alpha1_ContainerdReadinessOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_GracefulShutdownOptions_To_api_GracefulShutdownOptions(in *v1alpha1.GracefulShutdownOptions, out *api.GracefulShutdownOptions, s conversion.Scope) error {
+	out.GracePeriod = (*v1.Duration)(unsafe.Pointer(in.GracePeriod))
+	out.CriticalPodsGracePeriod = (*v1.Duration)(unsafe.Pointer(in.CriticalPodsGracePeriod))
+	out.ByPodPriority = *(*[]api.ShutdownGracePeriodByPodPriority)(unsafe.Pointer(&in.ByPodPriority))
+	return nil
+}
+
+// Convert_v1alpha1_GracefulShutdownOptions_To_api_GracefulShutdownOptions is an autogenerated conversion function.
+func Convert_v1alpha1_GracefulShutdownOptions_To_api_GracefulShutdownOptions(in *v1alpha1.GracefulShutdownOptions, out *api.GracefulShutdownOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_GracefulShutdownOptions_To_api_GracefulShutdownOptions(in, out, s)
+}
+
+func autoConvert_api_GracefulShutdownOptions_To_v1alpha1_GracefulShutdownOptions(in *api.GracefulShutdownOptions, out *v1alpha1.GracefulShutdownOptions, s conversion.Scope) error {
+	out.GracePeriod = (*v1.Duration)(unsafe.Pointer(in.GracePeriod))
+	out.CriticalPodsGracePeriod = (*v1.Duration)(unsafe.Pointer(in.CriticalPodsGracePeriod))
+	out.ByPodPriority = *(*[]v1alpha1.ShutdownGracePeriodByPodPriority)(unsafe.Pointer(&in.ByPodPriority))
+	return nil
+}
+
+// Convert_api_GracefulShutdownOptions_To_v1alpha1_GracefulShutdownOptions is an autogenerated conversion function.
+func Convert_api_GracefulShutdownOptions_To_v1alpha1_GracefulShutdownOptions(in *api.GracefulShutdownOptions, out *v1alpha1.GracefulShutdownOptions, s conversion.Scope) error {
+	return autoConvert_api_GracefulShutdownOptions_To_v1alpha1_GracefulShutdownOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ImageCredentialProvider_To_api_ImageCredentialProvider(in *v1alpha1.ImageCredentialProvider, out *api.ImageCredentialProvider, s conversion.Scope) error {
+	out.Name = in.Name
+	out.MatchImages = *(*[]string)(unsafe.Pointer(&in.MatchImages))
+	out.DefaultCacheDuration = (*v1.Duration)(unsafe.Pointer(in.DefaultCacheDuration))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.Env = *(*map[string]string)(unsafe.Pointer(&in.Env))
+	return nil
+}
+
+// Convert_v1alpha1_ImageCredentialProvider_To_api_ImageCredentialProvider is an autogenerated conversion function.
+func Convert_v1alpha1_ImageCredentialProvider_To_api_ImageCredentialProvider(in *v1alpha1.ImageCredentialProvider, out *api.ImageCredentialProvider, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ImageCredentialProvider_To_api_ImageCredentialProvider(in, out, s)
+}
+
+func autoConvert_api_ImageCredentialProvider_To_v1alpha1_ImageCredentialProvider(in *api.ImageCredentialProvider, out *v1alpha1.ImageCredentialProvider, s conversion.Scope) error {
+	out.Name = in.Name
+	out.MatchImages = *(*[]string)(unsafe.Pointer(&in.MatchImages))
+	out.DefaultCacheDuration = (*v1.Duration)(unsafe.Pointer(in.DefaultCacheDuration))
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.Env = *(*map[string]string)(unsafe.Pointer(&in.Env))
+	return nil
+}
+
+// Convert_api_ImageCredentialProvider_To_v1alpha1_ImageCredentialProvider is an autogenerated conversion function.
+func Convert_api_ImageCredentialProvider_To_v1alpha1_ImageCredentialProvider(in *api.ImageCredentialProvider, out *v1alpha1.ImageCredentialProvider, s conversion.Scope) error {
+	return autoConvert_api_ImageCredentialProvider_To_v1alpha1_ImageCredentialProvider(in, out, s)
+}
+
+func autoConvert_v1alpha1_ImageCredentialProviderOptions_To_api_ImageCredentialProviderOptions(in *v1alpha1.ImageCredentialProviderOptions, out *api.ImageCredentialProviderOptions, s conversion.Scope) error {
+	out.BinDir = in.BinDir
+	out.Providers = *(*[]api.ImageCredentialProvider)(unsafe.Pointer(&in.Providers))
+	return nil
+}
+
+// Convert_v1alpha1_ImageCredentialProviderOptions_To_api_ImageCredentialProviderOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ImageCredentialProviderOptions_To_api_ImageCredentialProviderOptions(in *v1alpha1.ImageCredentialProviderOptions, out *api.ImageCredentialProviderOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ImageCredentialProviderOptions_To_api_ImageCredentialProviderOptions(in, out, s)
+}
+
+func autoConvert_api_ImageCredentialProviderOptions_To_v1alpha1_ImageCredentialProviderOptions(in *api.ImageCredentialProviderOptions, out *v1alpha1.ImageCredentialProviderOptions, s conversion.Scope) error {
+	out.BinDir = in.BinDir
+	out.Providers = *(*[]v1alpha1.ImageCredentialProvider)(unsafe.Pointer(&in.Providers))
+	return nil
+}
+
+// Convert_api_ImageCredentialProviderOptions_To_v1alpha1_ImageCredentialProviderOptions is an autogenerated conversion function.
+func Convert_api_ImageCredentialProviderOptions_To_v1alpha1_ImageCredentialProviderOptions(in *api.ImageCredentialProviderOptions, out *v1alpha1.ImageCredentialProviderOptions, s conversion.Scope) error {
+	return autoConvert_api_ImageCredentialProviderOptions_To_v1alpha1_ImageCredentialProviderOptions(in, out, s)
+}
+
 func autoConvert_v1alpha1_InstanceOptions_To_api_InstanceOptions(in *v1alpha1.InstanceOptions, out *api.InstanceOptions, s conversion.Scope) error {
 	if err := Convert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(&in.LocalStorage, &out.LocalStorage, s); err != nil {
 		return err
 	}
+	out.DaemonManager = api.DaemonManager(in.DaemonManager)
 	return nil
 }
 
@@ -172,6 +600,7 @@ func autoConvert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in *api.Instanc
 	if err := Convert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(&in.LocalStorage, &out.LocalStorage, s); err != nil {
 		return err
 	}
+	out.DaemonManager = v1alpha1.DaemonManager(in.DaemonManager)
 	return nil
 }
 
@@ -180,9 +609,58 @@ func Convert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in *api.InstanceOpt
 	return autoConvert_api_InstanceOptions_To_v1alpha1_InstanceOptions(in, out, s)
 }
 
+func autoConvert_v1alpha1_KubeletConfigDropIn_To_api_KubeletConfigDropIn(in *v1alpha1.KubeletConfigDropIn, out *api.KubeletConfigDropIn, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Priority = in.Priority
+	out.Config = *(*api.InlineDocument)(unsafe.Pointer(&in.Config))
+	return nil
+}
+
+// Convert_v1alpha1_KubeletConfigDropIn_To_api_KubeletConfigDropIn is an autogenerated conversion function.
+func Convert_v1alpha1_KubeletConfigDropIn_To_api_KubeletConfigDropIn(in *v1alpha1.KubeletConfigDropIn, out *api.KubeletConfigDropIn, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeletConfigDropIn_To_api_KubeletConfigDropIn(in, out, s)
+}
+
+func autoConvert_api_KubeletConfigDropIn_To_v1alpha1_KubeletConfigDropIn(in *api.KubeletConfigDropIn, out *v1alpha1.KubeletConfigDropIn, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Priority = in.Priority
+	out.Config = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.Config))
+	return nil
+}
+
+// Convert_api_KubeletConfigDropIn_To_v1alpha1_KubeletConfigDropIn is an autogenerated conversion function.
+func Convert_api_KubeletConfigDropIn_To_v1alpha1_KubeletConfigDropIn(in *api.KubeletConfigDropIn, out *v1alpha1.KubeletConfigDropIn, s conversion.Scope) error {
+	return autoConvert_api_KubeletConfigDropIn_To_v1alpha1_KubeletConfigDropIn(in, out, s)
+}
+
 func autoConvert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in *v1alpha1.KubeletOptions, out *api.KubeletOptions, s conversion.Scope) error {
 	out.Config = *(*api.InlineDocument)(unsafe.Pointer(&in.Config))
+	out.ConfigDropIns = *(*[]api.KubeletConfigDropIn)(unsafe.Pointer(&in.ConfigDropIns))
 	out.Flags = *(*[]string)(unsafe.Pointer(&in.Flags))
+	if err := Convert_v1alpha1_KubeletReadinessOptions_To_api_KubeletReadinessOptions(&in.Readiness, &out.Readiness, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SystemdOptions_To_api_SystemdOptions(&in.Systemd, &out.Systemd, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_MaxPodsOptions_To_api_MaxPodsOptions(&in.MaxPods, &out.MaxPods, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ReservedResourcesOptions_To_api_ReservedResourcesOptions(&in.ReservedResources, &out.ReservedResources, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_ImageCredentialProviderOptions_To_api_ImageCredentialProviderOptions(&in.ImageCredentialProviders, &out.ImageCredentialProviders, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_GracefulShutdownOptions_To_api_GracefulShutdownOptions(&in.GracefulShutdown, &out.GracefulShutdown, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_NodeNameOptions_To_api_NodeNameOptions(&in.NodeName, &out.NodeName, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(&in.NodeIP, &out.NodeIP, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -193,7 +671,32 @@ func Convert_v1alpha1_KubeletOptions_To_api_KubeletOptions(in *v1alpha1.KubeletO
 
 func autoConvert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in *api.KubeletOptions, out *v1alpha1.KubeletOptions, s conversion.Scope) error {
 	out.Config = *(*map[string]runtime.RawExtension)(unsafe.Pointer(&in.Config))
+	out.ConfigDropIns = *(*[]v1alpha1.KubeletConfigDropIn)(unsafe.Pointer(&in.ConfigDropIns))
 	out.Flags = *(*[]string)(unsafe.Pointer(&in.Flags))
+	if err := Convert_api_KubeletReadinessOptions_To_v1alpha1_KubeletReadinessOptions(&in.Readiness, &out.Readiness, s); err != nil {
+		return err
+	}
+	if err := Convert_api_SystemdOptions_To_v1alpha1_SystemdOptions(&in.Systemd, &out.Systemd, s); err != nil {
+		return err
+	}
+	if err := Convert_api_MaxPodsOptions_To_v1alpha1_MaxPodsOptions(&in.MaxPods, &out.MaxPods, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ReservedResourcesOptions_To_v1alpha1_ReservedResourcesOptions(&in.ReservedResources, &out.ReservedResources, s); err != nil {
+		return err
+	}
+	if err := Convert_api_ImageCredentialProviderOptions_To_v1alpha1_ImageCredentialProviderOptions(&in.ImageCredentialProviders, &out.ImageCredentialProviders, s); err != nil {
+		return err
+	}
+	if err := Convert_api_GracefulShutdownOptions_To_v1alpha1_GracefulShutdownOptions(&in.GracefulShutdown, &out.GracefulShutdown, s); err != nil {
+		return err
+	}
+	if err := Convert_api_NodeNameOptions_To_v1alpha1_NodeNameOptions(&in.NodeName, &out.NodeName, s); err != nil {
+		return err
+	}
+	if err := Convert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(&in.NodeIP, &out.NodeIP, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -202,6 +705,28 @@ func Convert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in *api.KubeletOption
 	return autoConvert_api_KubeletOptions_To_v1alpha1_KubeletOptions(in, out, s)
 }
 
+func autoConvert_v1alpha1_KubeletReadinessOptions_To_api_KubeletReadinessOptions(in *v1alpha1.KubeletReadinessOptions, out *api.KubeletReadinessOptions, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.WaitForNodeRegistration = (*bool)(unsafe.Pointer(in.WaitForNodeRegistration))
+	return nil
+}
+
+// Convert_v1alpha1_KubeletReadinessOptions_To_api_KubeletReadinessOptions is an autogenerated conversion function.
+func Convert_v1alpha1_KubeletReadinessOptions_To_api_KubeletReadinessOptions(in *v1alpha1.KubeletReadinessOptions, out *api.KubeletReadinessOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeletReadinessOptions_To_api_KubeletReadinessOptions(in, out, s)
+}
+
+func autoConvert_api_KubeletReadinessOptions_To_v1alpha1_KubeletReadinessOptions(in *api.KubeletReadinessOptions, out *v1alpha1.KubeletReadinessOptions, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.WaitForNodeRegistration = (*bool)(unsafe.Pointer(in.WaitForNodeRegistration))
+	return nil
+}
+
+// Convert_api_KubeletReadinessOptions_To_v1alpha1_KubeletReadinessOptions is an autogenerated conversion function.
+func Convert_api_KubeletReadinessOptions_To_v1alpha1_KubeletReadinessOptions(in *api.KubeletReadinessOptions, out *v1alpha1.KubeletReadinessOptions, s conversion.Scope) error {
+	return autoConvert_api_KubeletReadinessOptions_To_v1alpha1_KubeletReadinessOptions(in, out, s)
+}
+
 func autoConvert_v1alpha1_LocalStorageOptions_To_api_LocalStorageOptions(in *v1alpha1.LocalStorageOptions, out *api.LocalStorageOptions, s conversion.Scope) error {
 	out.Strategy = api.LocalStorageStrategy(in.Strategy)
 	return nil
@@ -222,6 +747,36 @@ func Convert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(in *api.Loc
 	return autoConvert_api_LocalStorageOptions_To_v1alpha1_LocalStorageOptions(in, out, s)
 }
 
+func autoConvert_v1alpha1_MaxPodsOptions_To_api_MaxPodsOptions(in *v1alpha1.MaxPodsOptions, out *api.MaxPodsOptions, s conversion.Scope) error {
+	out.Policy = api.MaxPodsPolicy(in.Policy)
+	out.Value = (*int32)(unsafe.Pointer(in.Value))
+	out.CNIVersion = in.CNIVersion
+	out.CNICustomNetworking = in.CNICustomNetworking
+	out.CNIPrefixDelegation = in.CNIPrefixDelegation
+	out.CNIMaxENI = (*int32)(unsafe.Pointer(in.CNIMaxENI))
+	return nil
+}
+
+// Convert_v1alpha1_MaxPodsOptions_To_api_MaxPodsOptions is an autogenerated conversion function.
+func Convert_v1alpha1_MaxPodsOptions_To_api_MaxPodsOptions(in *v1alpha1.MaxPodsOptions, out *api.MaxPodsOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MaxPodsOptions_To_api_MaxPodsOptions(in, out, s)
+}
+
+func autoConvert_api_MaxPodsOptions_To_v1alpha1_MaxPodsOptions(in *api.MaxPodsOptions, out *v1alpha1.MaxPodsOptions, s conversion.Scope) error {
+	out.Policy = v1alpha1.MaxPodsPolicy(in.Policy)
+	out.Value = (*int32)(unsafe.Pointer(in.Value))
+	out.CNIVersion = in.CNIVersion
+	out.CNICustomNetworking = in.CNICustomNetworking
+	out.CNIPrefixDelegation = in.CNIPrefixDelegation
+	out.CNIMaxENI = (*int32)(unsafe.Pointer(in.CNIMaxENI))
+	return nil
+}
+
+// Convert_api_MaxPodsOptions_To_v1alpha1_MaxPodsOptions is an autogenerated conversion function.
+func Convert_api_MaxPodsOptions_To_v1alpha1_MaxPodsOptions(in *api.MaxPodsOptions, out *v1alpha1.MaxPodsOptions, s conversion.Scope) error {
+	return autoConvert_api_MaxPodsOptions_To_v1alpha1_MaxPodsOptions(in, out, s)
+}
+
 func autoConvert_v1alpha1_NodeConfig_To_api_NodeConfig(in *v1alpha1.NodeConfig, out *api.NodeConfig, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha1_NodeConfigSpec_To_api_NodeConfigSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -334,3 +889,367 @@ func autoConvert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in *api.NodeConfi
 func Convert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in *api.NodeConfigSpec, out *v1alpha1.NodeConfigSpec, s conversion.Scope) error {
 	return autoConvert_api_NodeConfigSpec_To_v1alpha1_NodeConfigSpec(in, out, s)
 }
+
+func autoConvert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(in *v1alpha1.NodeIPOptions, out *api.NodeIPOptions, s conversion.Scope) error {
+	out.Interface = in.Interface
+	out.MAC = in.MAC
+	out.DeviceIndex = (*int32)(unsafe.Pointer(in.DeviceIndex))
+	out.CIDRs = *(*[]string)(unsafe.Pointer(&in.CIDRs))
+	out.DualStack = in.DualStack
+	return nil
+}
+
+// Convert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions is an autogenerated conversion function.
+func Convert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(in *v1alpha1.NodeIPOptions, out *api.NodeIPOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeIPOptions_To_api_NodeIPOptions(in, out, s)
+}
+
+func autoConvert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(in *api.NodeIPOptions, out *v1alpha1.NodeIPOptions, s conversion.Scope) error {
+	out.Interface = in.Interface
+	out.MAC = in.MAC
+	out.DeviceIndex = (*int32)(unsafe.Pointer(in.DeviceIndex))
+	out.CIDRs = *(*[]string)(unsafe.Pointer(&in.CIDRs))
+	out.DualStack = in.DualStack
+	return nil
+}
+
+// Convert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions is an autogenerated conversion function.
+func Convert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(in *api.NodeIPOptions, out *v1alpha1.NodeIPOptions, s conversion.Scope) error {
+	return autoConvert_api_NodeIPOptions_To_v1alpha1_NodeIPOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_NodeNameOptions_To_api_NodeNameOptions(in *v1alpha1.NodeNameOptions, out *api.NodeNameOptions, s conversion.Scope) error {
+	out.Strategy = api.NodeNameStrategy(in.Strategy)
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_v1alpha1_NodeNameOptions_To_api_NodeNameOptions is an autogenerated conversion function.
+func Convert_v1alpha1_NodeNameOptions_To_api_NodeNameOptions(in *v1alpha1.NodeNameOptions, out *api.NodeNameOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeNameOptions_To_api_NodeNameOptions(in, out, s)
+}
+
+func autoConvert_api_NodeNameOptions_To_v1alpha1_NodeNameOptions(in *api.NodeNameOptions, out *v1alpha1.NodeNameOptions, s conversion.Scope) error {
+	out.Strategy = v1alpha1.NodeNameStrategy(in.Strategy)
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_api_NodeNameOptions_To_v1alpha1_NodeNameOptions is an autogenerated conversion function.
+func Convert_api_NodeNameOptions_To_v1alpha1_NodeNameOptions(in *api.NodeNameOptions, out *v1alpha1.NodeNameOptions, s conversion.Scope) error {
+	return autoConvert_api_NodeNameOptions_To_v1alpha1_NodeNameOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_PreloadImage_To_api_PreloadImage(in *v1alpha1.PreloadImage, out *api.PreloadImage, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Pinned = in.Pinned
+	return nil
+}
+
+// Convert_v1alpha1_PreloadImage_To_api_PreloadImage is an autogenerated conversion function.
+func Convert_v1alpha1_PreloadImage_To_api_PreloadImage(in *v1alpha1.PreloadImage, out *api.PreloadImage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PreloadImage_To_api_PreloadImage(in, out, s)
+}
+
+func autoConvert_api_PreloadImage_To_v1alpha1_PreloadImage(in *api.PreloadImage, out *v1alpha1.PreloadImage, s conversion.Scope) error {
+	out.Image = in.Image
+	out.Pinned = in.Pinned
+	return nil
+}
+
+// Convert_api_PreloadImage_To_v1alpha1_PreloadImage is an autogenerated conversion function.
+func Convert_api_PreloadImage_To_v1alpha1_PreloadImage(in *api.PreloadImage, out *v1alpha1.PreloadImage, s conversion.Scope) error {
+	return autoConvert_api_PreloadImage_To_v1alpha1_PreloadImage(in, out, s)
+}
+
+func autoConvert_v1alpha1_PreloadImageArchive_To_api_PreloadImageArchive(in *v1alpha1.PreloadImageArchive, out *api.PreloadImageArchive, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Pinned = in.Pinned
+	return nil
+}
+
+// Convert_v1alpha1_PreloadImageArchive_To_api_PreloadImageArchive is an autogenerated conversion function.
+func Convert_v1alpha1_PreloadImageArchive_To_api_PreloadImageArchive(in *v1alpha1.PreloadImageArchive, out *api.PreloadImageArchive, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PreloadImageArchive_To_api_PreloadImageArchive(in, out, s)
+}
+
+func autoConvert_api_PreloadImageArchive_To_v1alpha1_PreloadImageArchive(in *api.PreloadImageArchive, out *v1alpha1.PreloadImageArchive, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Pinned = in.Pinned
+	return nil
+}
+
+// Convert_api_PreloadImageArchive_To_v1alpha1_PreloadImageArchive is an autogenerated conversion function.
+func Convert_api_PreloadImageArchive_To_v1alpha1_PreloadImageArchive(in *api.PreloadImageArchive, out *v1alpha1.PreloadImageArchive, s conversion.Scope) error {
+	return autoConvert_api_PreloadImageArchive_To_v1alpha1_PreloadImageArchive(in, out, s)
+}
+
+func autoConvert_v1alpha1_PreloadImagesOptions_To_api_PreloadImagesOptions(in *v1alpha1.PreloadImagesOptions, out *api.PreloadImagesOptions, s conversion.Scope) error {
+	out.Images = *(*[]api.PreloadImage)(unsafe.Pointer(&in.Images))
+	out.Archives = *(*[]api.PreloadImageArchive)(unsafe.Pointer(&in.Archives))
+	out.Parallelism = in.Parallelism
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1alpha1_PreloadImagesOptions_To_api_PreloadImagesOptions is an autogenerated conversion function.
+func Convert_v1alpha1_PreloadImagesOptions_To_api_PreloadImagesOptions(in *v1alpha1.PreloadImagesOptions, out *api.PreloadImagesOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PreloadImagesOptions_To_api_PreloadImagesOptions(in, out, s)
+}
+
+func autoConvert_api_PreloadImagesOptions_To_v1alpha1_PreloadImagesOptions(in *api.PreloadImagesOptions, out *v1alpha1.PreloadImagesOptions, s conversion.Scope) error {
+	out.Images = *(*[]v1alpha1.PreloadImage)(unsafe.Pointer(&in.Images))
+	out.Archives = *(*[]v1alpha1.PreloadImageArchive)(unsafe.Pointer(&in.Archives))
+	out.Parallelism = in.Parallelism
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_api_PreloadImagesOptions_To_v1alpha1_PreloadImagesOptions is an autogenerated conversion function.
+func Convert_api_PreloadImagesOptions_To_v1alpha1_PreloadImagesOptions(in *api.PreloadImagesOptions, out *v1alpha1.PreloadImagesOptions, s conversion.Scope) error {
+	return autoConvert_api_PreloadImagesOptions_To_v1alpha1_PreloadImagesOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProxySnapshotterOptions_To_api_ProxySnapshotterOptions(in *v1alpha1.ProxySnapshotterOptions, out *api.ProxySnapshotterOptions, s conversion.Scope) error {
+	out.Address = in.Address
+	out.Daemon = in.Daemon
+	return nil
+}
+
+// Convert_v1alpha1_ProxySnapshotterOptions_To_api_ProxySnapshotterOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ProxySnapshotterOptions_To_api_ProxySnapshotterOptions(in *v1alpha1.ProxySnapshotterOptions, out *api.ProxySnapshotterOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProxySnapshotterOptions_To_api_ProxySnapshotterOptions(in, out, s)
+}
+
+func autoConvert_api_ProxySnapshotterOptions_To_v1alpha1_ProxySnapshotterOptions(in *api.ProxySnapshotterOptions, out *v1alpha1.ProxySnapshotterOptions, s conversion.Scope) error {
+	out.Address = in.Address
+	out.Daemon = in.Daemon
+	return nil
+}
+
+// Convert_api_ProxySnapshotterOptions_To_v1alpha1_ProxySnapshotterOptions is an autogenerated conversion function.
+func Convert_api_ProxySnapshotterOptions_To_v1alpha1_ProxySnapshotterOptions(in *api.ProxySnapshotterOptions, out *v1alpha1.ProxySnapshotterOptions, s conversion.Scope) error {
+	return autoConvert_api_ProxySnapshotterOptions_To_v1alpha1_ProxySnapshotterOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegistryMirror_To_api_RegistryMirror(in *v1alpha1.RegistryMirror, out *api.RegistryMirror, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Capabilities = *(*[]api.RegistryCapability)(unsafe.Pointer(&in.Capabilities))
+	out.OverridePath = in.OverridePath
+	if err := Convert_v1alpha1_RegistryTLSOptions_To_api_RegistryTLSOptions(&in.TLS, &out.TLS, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_RegistryMirror_To_api_RegistryMirror is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryMirror_To_api_RegistryMirror(in *v1alpha1.RegistryMirror, out *api.RegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryMirror_To_api_RegistryMirror(in, out, s)
+}
+
+func autoConvert_api_RegistryMirror_To_v1alpha1_RegistryMirror(in *api.RegistryMirror, out *v1alpha1.RegistryMirror, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Capabilities = *(*[]v1alpha1.RegistryCapability)(unsafe.Pointer(&in.Capabilities))
+	out.OverridePath = in.OverridePath
+	if err := Convert_api_RegistryTLSOptions_To_v1alpha1_RegistryTLSOptions(&in.TLS, &out.TLS, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_RegistryMirror_To_v1alpha1_RegistryMirror is an autogenerated conversion function.
+func Convert_api_RegistryMirror_To_v1alpha1_RegistryMirror(in *api.RegistryMirror, out *v1alpha1.RegistryMirror, s conversion.Scope) error {
+	return autoConvert_api_RegistryMirror_To_v1alpha1_RegistryMirror(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegistryOptions_To_api_RegistryOptions(in *v1alpha1.RegistryOptions, out *api.RegistryOptions, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Server = in.Server
+	if err := Convert_v1alpha1_RegistryTLSOptions_To_api_RegistryTLSOptions(&in.ServerTLS, &out.ServerTLS, s); err != nil {
+		return err
+	}
+	out.Mirrors = *(*[]api.RegistryMirror)(unsafe.Pointer(&in.Mirrors))
+	return nil
+}
+
+// Convert_v1alpha1_RegistryOptions_To_api_RegistryOptions is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryOptions_To_api_RegistryOptions(in *v1alpha1.RegistryOptions, out *api.RegistryOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryOptions_To_api_RegistryOptions(in, out, s)
+}
+
+func autoConvert_api_RegistryOptions_To_v1alpha1_RegistryOptions(in *api.RegistryOptions, out *v1alpha1.RegistryOptions, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Server = in.Server
+	if err := Convert_api_RegistryTLSOptions_To_v1alpha1_RegistryTLSOptions(&in.ServerTLS, &out.ServerTLS, s); err != nil {
+		return err
+	}
+	out.Mirrors = *(*[]v1alpha1.RegistryMirror)(unsafe.Pointer(&in.Mirrors))
+	return nil
+}
+
+// Convert_api_RegistryOptions_To_v1alpha1_RegistryOptions is an autogenerated conversion function.
+func Convert_api_RegistryOptions_To_v1alpha1_RegistryOptions(in *api.RegistryOptions, out *v1alpha1.RegistryOptions, s conversion.Scope) error {
+	return autoConvert_api_RegistryOptions_To_v1alpha1_RegistryOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegistryTLSOptions_To_api_RegistryTLSOptions(in *v1alpha1.RegistryTLSOptions, out *api.RegistryTLSOptions, s conversion.Scope) error {
+	out.CertificateAuthority = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthority))
+	out.ClientCertificate = in.ClientCertificate
+	out.ClientKey = in.ClientKey
+	out.InsecureSkipVerify = in.InsecureSkipVerify
+	return nil
+}
+
+// Convert_v1alpha1_RegistryTLSOptions_To_api_RegistryTLSOptions is an autogenerated conversion function.
+func Convert_v1alpha1_RegistryTLSOptions_To_api_RegistryTLSOptions(in *v1alpha1.RegistryTLSOptions, out *api.RegistryTLSOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegistryTLSOptions_To_api_RegistryTLSOptions(in, out, s)
+}
+
+func autoConvert_api_RegistryTLSOptions_To_v1alpha1_RegistryTLSOptions(in *api.RegistryTLSOptions, out *v1alpha1.RegistryTLSOptions, s conversion.Scope) error {
+	out.CertificateAuthority = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthority))
+	out.ClientCertificate = in.ClientCertificate
+	out.ClientKey = in.ClientKey
+	out.InsecureSkipVerify = in.InsecureSkipVerify
+	return nil
+}
+
+// Convert_api_RegistryTLSOptions_To_v1alpha1_RegistryTLSOptions is an autogenerated conversion function.
+func Convert_api_RegistryTLSOptions_To_v1alpha1_RegistryTLSOptions(in *api.RegistryTLSOptions, out *v1alpha1.RegistryTLSOptions, s conversion.Scope) error {
+	return autoConvert_api_RegistryTLSOptions_To_v1alpha1_RegistryTLSOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ReservedResourcesOptions_To_api_ReservedResourcesOptions(in *v1alpha1.ReservedResourcesOptions, out *api.ReservedResourcesOptions, s conversion.Scope) error {
+	out.Policy = api.ReservationPolicy(in.Policy)
+	out.KubeReserved = *(*map[string]string)(unsafe.Pointer(&in.KubeReserved))
+	out.SystemReserved = *(*map[string]string)(unsafe.Pointer(&in.SystemReserved))
+	out.KubeReservedCgroup = (*string)(unsafe.Pointer(in.KubeReservedCgroup))
+	out.SystemReservedCgroup = (*string)(unsafe.Pointer(in.SystemReservedCgroup))
+	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	out.EnforceNodeAllocatable = *(*[]string)(unsafe.Pointer(&in.EnforceNodeAllocatable))
+	return nil
+}
+
+// Convert_v1alpha1_ReservedResourcesOptions_To_api_ReservedResourcesOptions is an autogenerated conversion function.
+func Convert_v1alpha1_ReservedResourcesOptions_To_api_ReservedResourcesOptions(in *v1alpha1.ReservedResourcesOptions, out *api.ReservedResourcesOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ReservedResourcesOptions_To_api_ReservedResourcesOptions(in, out, s)
+}
+
+func autoConvert_api_ReservedResourcesOptions_To_v1alpha1_ReservedResourcesOptions(in *api.ReservedResourcesOptions, out *v1alpha1.ReservedResourcesOptions, s conversion.Scope) error {
+	out.Policy = v1alpha1.ReservationPolicy(in.Policy)
+	out.KubeReserved = *(*map[string]string)(unsafe.Pointer(&in.KubeReserved))
+	out.SystemReserved = *(*map[string]string)(unsafe.Pointer(&in.SystemReserved))
+	out.KubeReservedCgroup = (*string)(unsafe.Pointer(in.KubeReservedCgroup))
+	out.SystemReservedCgroup = (*string)(unsafe.Pointer(in.SystemReservedCgroup))
+	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	out.EnforceNodeAllocatable = *(*[]string)(unsafe.Pointer(&in.EnforceNodeAllocatable))
+	return nil
+}
+
+// Convert_api_ReservedResourcesOptions_To_v1alpha1_ReservedResourcesOptions is an autogenerated conversion function.
+func Convert_api_ReservedResourcesOptions_To_v1alpha1_ReservedResourcesOptions(in *api.ReservedResourcesOptions, out *v1alpha1.ReservedResourcesOptions, s conversion.Scope) error {
+	return autoConvert_api_ReservedResourcesOptions_To_v1alpha1_ReservedResourcesOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_SandboxImagePullOptions_To_api_SandboxImagePullOptions(in *v1alpha1.SandboxImagePullOptions, out *api.SandboxImagePullOptions, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Attempts = in.Attempts
+	out.Backoff = (*v1.Duration)(unsafe.Pointer(in.Backoff))
+	return nil
+}
+
+// Convert_v1alpha1_SandboxImagePullOptions_To_api_SandboxImagePullOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SandboxImagePullOptions_To_api_SandboxImagePullOptions(in *v1alpha1.SandboxImagePullOptions, out *api.SandboxImagePullOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SandboxImagePullOptions_To_api_SandboxImagePullOptions(in, out, s)
+}
+
+func autoConvert_api_SandboxImagePullOptions_To_v1alpha1_SandboxImagePullOptions(in *api.SandboxImagePullOptions, out *v1alpha1.SandboxImagePullOptions, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Attempts = in.Attempts
+	out.Backoff = (*v1.Duration)(unsafe.Pointer(in.Backoff))
+	return nil
+}
+
+// Convert_api_SandboxImagePullOptions_To_v1alpha1_SandboxImagePullOptions is an autogenerated conversion function.
+func Convert_api_SandboxImagePullOptions_To_v1alpha1_SandboxImagePullOptions(in *api.SandboxImagePullOptions, out *v1alpha1.SandboxImagePullOptions, s conversion.Scope) error {
+	return autoConvert_api_SandboxImagePullOptions_To_v1alpha1_SandboxImagePullOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_ShutdownGracePeriodByPodPriority_To_api_ShutdownGracePeriodByPodPriority(in *v1alpha1.ShutdownGracePeriodByPodPriority, out *api.ShutdownGracePeriodByPodPriority, s conversion.Scope) error {
+	out.Priority = in.Priority
+	out.GracePeriodSeconds = in.GracePeriodSeconds
+	return nil
+}
+
+// Convert_v1alpha1_ShutdownGracePeriodByPodPriority_To_api_ShutdownGracePeriodByPodPriority is an autogenerated conversion function.
+func Convert_v1alpha1_ShutdownGracePeriodByPodPriority_To_api_ShutdownGracePeriodByPodPriority(in *v1alpha1.ShutdownGracePeriodByPodPriority, out *api.ShutdownGracePeriodByPodPriority, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ShutdownGracePeriodByPodPriority_To_api_ShutdownGracePeriodByPodPriority(in, out, s)
+}
+
+func autoConvert_api_ShutdownGracePeriodByPodPriority_To_v1alpha1_ShutdownGracePeriodByPodPriority(in *api.ShutdownGracePeriodByPodPriority, out *v1alpha1.ShutdownGracePeriodByPodPriority, s conversion.Scope) error {
+	out.Priority = in.Priority
+	out.GracePeriodSeconds = in.GracePeriodSeconds
+	return nil
+}
+
+// Convert_api_ShutdownGracePeriodByPodPriority_To_v1alpha1_ShutdownGracePeriodByPodPriority is an autogenerated conversion function.
+func Convert_api_ShutdownGracePeriodByPodPriority_To_v1alpha1_ShutdownGracePeriodByPodPriority(in *api.ShutdownGracePeriodByPodPriority, out *v1alpha1.ShutdownGracePeriodByPodPriority, s conversion.Scope) error {
+	return autoConvert_api_ShutdownGracePeriodByPodPriority_To_v1alpha1_ShutdownGracePeriodByPodPriority(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotterOptions_To_api_SnapshotterOptions(in *v1alpha1.SnapshotterOptions, out *api.SnapshotterOptions, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := Convert_v1alpha1_ProxySnapshotterOptions_To_api_ProxySnapshotterOptions(&in.Proxy, &out.Proxy, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotterOptions_To_api_SnapshotterOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotterOptions_To_api_SnapshotterOptions(in *v1alpha1.SnapshotterOptions, out *api.SnapshotterOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotterOptions_To_api_SnapshotterOptions(in, out, s)
+}
+
+func autoConvert_api_SnapshotterOptions_To_v1alpha1_SnapshotterOptions(in *api.SnapshotterOptions, out *v1alpha1.SnapshotterOptions, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := Convert_api_ProxySnapshotterOptions_To_v1alpha1_ProxySnapshotterOptions(&in.Proxy, &out.Proxy, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_api_SnapshotterOptions_To_v1alpha1_SnapshotterOptions is an autogenerated conversion function.
+func Convert_api_SnapshotterOptions_To_v1alpha1_SnapshotterOptions(in *api.SnapshotterOptions, out *v1alpha1.SnapshotterOptions, s conversion.Scope) error {
+	return autoConvert_api_SnapshotterOptions_To_v1alpha1_SnapshotterOptions(in, out, s)
+}
+
+func autoConvert_v1alpha1_SystemdOptions_To_api_SystemdOptions(in *v1alpha1.SystemdOptions, out *api.SystemdOptions, s conversion.Scope) error {
+	out.After = *(*[]string)(unsafe.Pointer(&in.After))
+	out.Environment = *(*map[string]string)(unsafe.Pointer(&in.Environment))
+	out.LimitNOFILE = in.LimitNOFILE
+	out.MemoryHigh = in.MemoryHigh
+	out.CPUWeight = in.CPUWeight
+	out.Slice = in.Slice
+	out.Restart = in.Restart
+	return nil
+}
+
+// Convert_v1alpha1_SystemdOptions_To_api_SystemdOptions is an autogenerated conversion function.
+func Convert_v1alpha1_SystemdOptions_To_api_SystemdOptions(in *v1alpha1.SystemdOptions, out *api.SystemdOptions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SystemdOptions_To_api_SystemdOptions(in, out, s)
+}
+
+func autoConvert_api_SystemdOptions_To_v1alpha1_SystemdOptions(in *api.SystemdOptions, out *v1alpha1.SystemdOptions, s conversion.Scope) error {
+	out.After = *(*[]string)(unsafe.Pointer(&in.After))
+	out.Environment = *(*map[string]string)(unsafe.Pointer(&in.Environment))
+	out.LimitNOFILE = in.LimitNOFILE
+	out.MemoryHigh = in.MemoryHigh
+	out.CPUWeight = in.CPUWeight
+	out.Slice = in.Slice
+	out.Restart = in.Restart
+	return nil
+}
+
+// Convert_api_SystemdOptions_To_v1alpha1_SystemdOptions is an autogenerated conversion function.
+func Convert_api_SystemdOptions_To_v1alpha1_SystemdOptions(in *api.SystemdOptions, out *v1alpha1.SystemdOptions, s conversion.Scope) error {
+	return autoConvert_api_SystemdOptions_To_v1alpha1_SystemdOptions(in, out, s)
+}
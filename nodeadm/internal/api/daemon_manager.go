@@ -0,0 +1,9 @@
+package api
+
+// GetDaemonManager returns the daemon manager, defaulting to systemd
+func GetDaemonManager(options InstanceOptions) DaemonManager {
+	if options.DaemonManager == "" {
+		return DaemonManagerSystemd
+	}
+	return options.DaemonManager
+}
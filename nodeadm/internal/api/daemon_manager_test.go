@@ -0,0 +1,66 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDaemonManager(t *testing.T) {
+	tests := []struct {
+		spec        NodeConfigSpec
+		expectedErr string
+	}{
+		{spec: NodeConfigSpec{}},
+		{spec: NodeConfigSpec{
+			Instance: InstanceOptions{DaemonManager: DaemonManagerSystemd},
+			Kubelet:  KubeletOptions{Systemd: SystemdOptions{Slice: "system.slice"}},
+		}},
+		{spec: NodeConfigSpec{Instance: InstanceOptions{DaemonManager: DaemonManagerProcess}}},
+		{
+			spec: NodeConfigSpec{
+				Instance:   InstanceOptions{DaemonManager: DaemonManagerProcess},
+				Containerd: ContainerdOptions{Systemd: SystemdOptions{LimitNOFILE: "1048576"}},
+			},
+			expectedErr: "Containerd systemd options are not supported by the process daemon manager",
+		},
+		{
+			spec: NodeConfigSpec{
+				Instance: InstanceOptions{DaemonManager: DaemonManagerProcess},
+				Kubelet:  KubeletOptions{Systemd: SystemdOptions{Environment: map[string]string{"HTTPS_PROXY": "http://proxy.example.com:3128"}}},
+			},
+			expectedErr: "Kubelet systemd options are not supported by the process daemon manager",
+		},
+		{spec: NodeConfigSpec{
+			Instance:   InstanceOptions{DaemonManager: DaemonManagerProcess},
+			Containerd: ContainerdOptions{Snapshotter: SnapshotterOptions{Name: "soci"}},
+		}},
+		{spec: NodeConfigSpec{
+			Instance:   InstanceOptions{DaemonManager: DaemonManagerProcess},
+			Containerd: ContainerdOptions{Snapshotter: SnapshotterOptions{Name: "nydus", Proxy: ProxySnapshotterOptions{Address: "/run/nydus.sock"}}},
+		}},
+		{spec: NodeConfigSpec{
+			Containerd: ContainerdOptions{Snapshotter: SnapshotterOptions{Name: "nydus", Proxy: ProxySnapshotterOptions{Address: "/run/nydus.sock", Daemon: "nydus-snapshotter"}}},
+		}},
+		{
+			spec: NodeConfigSpec{
+				Instance:   InstanceOptions{DaemonManager: DaemonManagerProcess},
+				Containerd: ContainerdOptions{Snapshotter: SnapshotterOptions{Name: "nydus", Proxy: ProxySnapshotterOptions{Address: "/run/nydus.sock", Daemon: "nydus-snapshotter"}}},
+			},
+			expectedErr: "Snapshotter daemon nydus-snapshotter is not supported by the process daemon manager",
+		},
+		{
+			spec:        NodeConfigSpec{Instance: InstanceOptions{DaemonManager: "launchd"}},
+			expectedErr: "Unknown daemon manager: launchd",
+		},
+	}
+
+	for _, test := range tests {
+		err := validateDaemonManager(&NodeConfig{Spec: test.spec})
+		if test.expectedErr == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, test.expectedErr)
+		}
+	}
+}
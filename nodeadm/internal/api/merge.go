@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"reflect"
+	"slices"
 
 	"dario.cat/mergo"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
@@ -18,8 +19,11 @@ func (dst *NodeConfig) Merge(src *NodeConfig) error {
 const (
 	kubeletFlagsName  = "Flags"
 	kubeletConfigName = "Config"
+	kubeletDropInName = "ConfigDropIns"
 
-	containerdConfigName = "Config"
+	containerdConfigName     = "Config"
+	containerdRegistriesName = "Registries"
+	containerdRuntimesName   = "Runtimes"
 )
 
 type nodeConfigTransformer struct{}
@@ -27,10 +31,24 @@ type nodeConfigTransformer struct{}
 func (t nodeConfigTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
 	if typ == reflect.TypeOf(ContainerdOptions{}) {
 		return func(dst, src reflect.Value) error {
-			return t.transformContainerdConfig(
+			if err := t.transformContainerdConfig(
 				dst.FieldByName(containerdConfigName),
 				src.FieldByName(containerdConfigName),
+			); err != nil {
+				return err
+			}
+
+			t.transformRegistries(
+				dst.FieldByName(containerdRegistriesName),
+				src.FieldByName(containerdRegistriesName),
 			)
+
+			t.transformRuntimes(
+				dst.FieldByName(containerdRuntimesName),
+				src.FieldByName(containerdRuntimesName),
+			)
+
+			return t.mergeRemainingFields(dst, src, containerdConfigName, containerdRegistriesName, containerdRuntimesName)
 		}
 	} else if typ == reflect.TypeOf(KubeletOptions{}) {
 		return func(dst, src reflect.Value) error {
@@ -46,7 +64,37 @@ func (t nodeConfigTransformer) Transformer(typ reflect.Type) func(dst, src refle
 				return err
 			}
 
-			return nil
+			t.transformKubeletConfigDropIns(
+				dst.FieldByName(kubeletDropInName),
+				src.FieldByName(kubeletDropInName),
+			)
+
+			return t.mergeRemainingFields(dst, src, kubeletFlagsName, kubeletConfigName, kubeletDropInName)
+		}
+	}
+	return nil
+}
+
+// mergeRemainingFields merges every field of the struct that is not handled by
+// a custom transformation. Returning a transformer for a struct type prevents
+// mergo from descending into it, so without this any other field would be
+// silently dropped when merging.
+func (t nodeConfigTransformer) mergeRemainingFields(dst, src reflect.Value, handledFields ...string) error {
+	for i := 0; i < dst.NumField(); i++ {
+		if slices.Contains(handledFields, dst.Type().Field(i).Name) {
+			continue
+		}
+		dstField, srcField := dst.Field(i), src.Field(i)
+		if !dstField.CanSet() || srcField.IsZero() {
+			continue
+		}
+		switch dstField.Kind() {
+		case reflect.Struct, reflect.Map:
+			if err := mergo.Merge(dstField.Addr().Interface(), srcField.Interface(), mergo.WithOverride, mergo.WithTransformers(t)); err != nil {
+				return err
+			}
+		default:
+			dstField.Set(srcField)
 		}
 	}
 	return nil
@@ -66,6 +114,54 @@ func (t nodeConfigTransformer) transformKubeletFlags(dst, src reflect.Value) {
 	}
 }
 
+func (t nodeConfigTransformer) transformKubeletConfigDropIns(dst, src reflect.Value) {
+	if dst.CanSet() {
+		// drop-ins are identified by name, so a drop-in replaces the one with
+		// the same name and the others are appended
+		dropIns := slices.Clone(dst.Interface().([]KubeletConfigDropIn))
+		for _, dropIn := range src.Interface().([]KubeletConfigDropIn) {
+			if i := slices.IndexFunc(dropIns, func(d KubeletConfigDropIn) bool { return d.Name == dropIn.Name }); i >= 0 {
+				dropIns[i] = dropIn
+			} else {
+				dropIns = append(dropIns, dropIn)
+			}
+		}
+		dst.Set(reflect.ValueOf(dropIns))
+	}
+}
+
+func (t nodeConfigTransformer) transformRegistries(dst, src reflect.Value) {
+	if dst.CanSet() {
+		// registries are identified by name, so a registry replaces the one
+		// with the same name and the others are appended
+		registries := slices.Clone(dst.Interface().([]RegistryOptions))
+		for _, registry := range src.Interface().([]RegistryOptions) {
+			if i := slices.IndexFunc(registries, func(r RegistryOptions) bool { return r.Name == registry.Name }); i >= 0 {
+				registries[i] = registry
+			} else {
+				registries = append(registries, registry)
+			}
+		}
+		dst.Set(reflect.ValueOf(registries))
+	}
+}
+
+func (t nodeConfigTransformer) transformRuntimes(dst, src reflect.Value) {
+	if dst.CanSet() {
+		// runtimes are identified by name, so a runtime replaces the one with
+		// the same name and the others are appended
+		runtimes := slices.Clone(dst.Interface().([]ContainerRuntime))
+		for _, runtime := range src.Interface().([]ContainerRuntime) {
+			if i := slices.IndexFunc(runtimes, func(r ContainerRuntime) bool { return r.Name == runtime.Name }); i >= 0 {
+				runtimes[i] = runtime
+			} else {
+				runtimes = append(runtimes, runtime)
+			}
+		}
+		dst.Set(reflect.ValueOf(runtimes))
+	}
+}
+
 func (t nodeConfigTransformer) transformKubeletConfig(dst, src reflect.Value) error {
 	if dst.CanSet() {
 		if dst.Len() <= 0 {
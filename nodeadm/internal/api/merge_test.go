@@ -3,8 +3,11 @@ package api
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/aws/smithy-go/ptr"
 	"github.com/pelletier/go-toml/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func toInlineDocumentMust(m map[string]interface{}) InlineDocument {
@@ -80,6 +83,115 @@ func TestMerge(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "merge readiness options alongside custom transforms",
+			baseSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Flags: []string{"--node-labels=nodegroup=example"},
+					Readiness: KubeletReadinessOptions{
+						Timeout: &metav1.Duration{Duration: time.Minute},
+					},
+				},
+				Containerd: ContainerdOptions{
+					Readiness: ContainerdReadinessOptions{
+						Conditions: []RuntimeCondition{RuntimeReady},
+					},
+				},
+			},
+			patchSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Readiness: KubeletReadinessOptions{
+						WaitForNodeRegistration: ptr.Bool(true),
+					},
+				},
+				Containerd: ContainerdOptions{
+					Readiness: ContainerdReadinessOptions{
+						Timeout:    &metav1.Duration{Duration: 2 * time.Minute},
+						Conditions: []RuntimeCondition{RuntimeReady, NetworkReady},
+					},
+				},
+			},
+			expectedSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					Flags: []string{"--node-labels=nodegroup=example"},
+					Readiness: KubeletReadinessOptions{
+						Timeout:                 &metav1.Duration{Duration: time.Minute},
+						WaitForNodeRegistration: ptr.Bool(true),
+					},
+				},
+				Containerd: ContainerdOptions{
+					Readiness: ContainerdReadinessOptions{
+						Timeout:    &metav1.Duration{Duration: 2 * time.Minute},
+						Conditions: []RuntimeCondition{RuntimeReady, NetworkReady},
+					},
+				},
+			},
+		},
+		{
+			name: "merge kubelet config drop-ins by name",
+			baseSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					ConfigDropIns: []KubeletConfigDropIn{
+						{Name: "eviction", Priority: 10, Config: toInlineDocumentMust(map[string]interface{}{"maxPods": 20})},
+						{Name: "logging", Priority: 20, Config: toInlineDocumentMust(map[string]interface{}{"logging": map[string]interface{}{"verbosity": 4}})},
+					},
+				},
+			},
+			patchSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					ConfigDropIns: []KubeletConfigDropIn{
+						{Name: "logging", Priority: 30, Config: toInlineDocumentMust(map[string]interface{}{"logging": map[string]interface{}{"verbosity": 6}})},
+						{Name: "gpu", Priority: 50, Config: toInlineDocumentMust(map[string]interface{}{"maxPods": 30})},
+					},
+				},
+			},
+			expectedSpec: NodeConfigSpec{
+				Kubelet: KubeletOptions{
+					ConfigDropIns: []KubeletConfigDropIn{
+						{Name: "eviction", Priority: 10, Config: toInlineDocumentMust(map[string]interface{}{"maxPods": 20})},
+						{Name: "logging", Priority: 30, Config: toInlineDocumentMust(map[string]interface{}{"logging": map[string]interface{}{"verbosity": 6}})},
+						{Name: "gpu", Priority: 50, Config: toInlineDocumentMust(map[string]interface{}{"maxPods": 30})},
+					},
+				},
+			},
+		},
+		{
+			name: "merge containerd runtimes and registries by name",
+			baseSpec: NodeConfigSpec{
+				Containerd: ContainerdOptions{
+					Runtimes: []ContainerRuntime{
+						{Name: "runsc", Type: "io.containerd.runsc.v1"},
+						{Name: "kata", Type: "io.containerd.kata.v2"},
+					},
+					Registries: []RegistryOptions{
+						{Name: "docker.io", Mirrors: []RegistryMirror{{Host: "https://cache.example.com"}}},
+					},
+				},
+			},
+			patchSpec: NodeConfigSpec{
+				Containerd: ContainerdOptions{
+					Runtimes: []ContainerRuntime{
+						{Name: "kata", Type: "io.containerd.kata.v2", Path: "/opt/kata/bin/containerd-shim-kata-v2"},
+					},
+					Registries: []RegistryOptions{
+						{Name: "docker.io", Mirrors: []RegistryMirror{{Host: "https://mirror.example.com"}}},
+						{Name: "quay.io", Mirrors: []RegistryMirror{{Host: "https://mirror.example.com"}}},
+					},
+				},
+			},
+			expectedSpec: NodeConfigSpec{
+				Containerd: ContainerdOptions{
+					Runtimes: []ContainerRuntime{
+						{Name: "runsc", Type: "io.containerd.runsc.v1"},
+						{Name: "kata", Type: "io.containerd.kata.v2", Path: "/opt/kata/bin/containerd-shim-kata-v2"},
+					},
+					Registries: []RegistryOptions{
+						{Name: "docker.io", Mirrors: []RegistryMirror{{Host: "https://mirror.example.com"}}},
+						{Name: "quay.io", Mirrors: []RegistryMirror{{Host: "https://mirror.example.com"}}},
+					},
+				},
+			},
+		},
 		{
 			name: "customer overrides orchestrator defaults",
 			baseSpec: NodeConfigSpec{
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var (
+	getOSHostname   = os.Hostname
+	getIMDSHostname = func(ctx context.Context, imdsClient *imds.Client) (string, error) {
+		res, err := imdsClient.GetMetadata(ctx, &imds.GetMetadataInput{Path: "hostname"})
+		if err != nil {
+			return "", err
+		}
+		hostname, err := io.ReadAll(res.Content)
+		if err != nil {
+			return "", err
+		}
+		// VPCs with multiple domain names in their DHCP options list a hostname
+		// for each of them
+		if fields := strings.Fields(string(hostname)); len(fields) > 0 {
+			return fields[0], nil
+		}
+		return "", nil
+	}
+)
+
+// GetNodeNameStrategy returns the node name strategy, defaulting based on the
+// InstanceIdNodeName feature gate
+func GetNodeNameStrategy(options NodeNameOptions, featureGates map[Feature]bool) NodeNameStrategy {
+	if options.Strategy != "" {
+		return options.Strategy
+	}
+	if IsFeatureEnabled(InstanceIdNodeName, featureGates) {
+		return NodeNameStrategyInstanceID
+	}
+	return NodeNameStrategyPrivateDNSName
+}
+
+// requiresPrivateDNSName returns whether the node name depends on the private
+// DNS name, which can only be retrieved from the EC2 API
+func requiresPrivateDNSName(options NodeNameOptions, featureGates map[Feature]bool) bool {
+	switch GetNodeNameStrategy(options, featureGates) {
+	case NodeNameStrategyPrivateDNSName:
+		return true
+	case NodeNameStrategyTemplate:
+		return strings.Contains(options.Template, "PrivateDNSName")
+	default:
+		return false
+	}
+}
+
+// ResolveNodeName returns the name of the Node object for this instance
+// according to the node name strategy. kubelet lowercases the name, so the
+// name is lowercased before it is validated.
+func ResolveNodeName(ctx context.Context, options NodeNameOptions, featureGates map[Feature]bool, instance *InstanceDetails, imdsClient *imds.Client) (string, error) {
+	strategy := GetNodeNameStrategy(options, featureGates)
+	var nodeName string
+	var err error
+	switch strategy {
+	case NodeNameStrategyPrivateDNSName:
+		nodeName = instance.PrivateDNSName
+	case NodeNameStrategyInstanceID:
+		nodeName = instance.ID
+	case NodeNameStrategyResourceName:
+		nodeName, err = getIMDSHostname(ctx, imdsClient)
+	case NodeNameStrategyHostname:
+		nodeName, err = getOSHostname()
+	case NodeNameStrategyTemplate:
+		nodeName, err = renderNodeNameTemplate(options.Template, instance)
+	default:
+		return "", fmt.Errorf("unknown node name strategy: %s", strategy)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get node name with the %s strategy: %w", strategy, err)
+	}
+	nodeName = strings.ToLower(strings.TrimSpace(nodeName))
+	if errs := validation.IsDNS1123Subdomain(nodeName); len(errs) > 0 {
+		return "", fmt.Errorf("invalid node name %q from the %s strategy: %s", nodeName, strategy, strings.Join(errs, ", "))
+	}
+	return nodeName, nil
+}
+
+func renderNodeNameTemplate(text string, instance *InstanceDetails) (string, error) {
+	tmpl, err := template.New("nodeName").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, instance); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveNodeName(t *testing.T) {
+	oldGetOSHostname, oldGetIMDSHostname := getOSHostname, getIMDSHostname
+	t.Cleanup(func() {
+		getOSHostname, getIMDSHostname = oldGetOSHostname, oldGetIMDSHostname
+	})
+	getOSHostname = func() (string, error) {
+		return "My-Node", nil
+	}
+	getIMDSHostname = func(ctx context.Context, imdsClient *imds.Client) (string, error) {
+		return "i-1234567890abcdef0.us-west-2.compute.internal", nil
+	}
+	instance := InstanceDetails{
+		ID:               "i-1234567890abcdef0",
+		Region:           "us-west-2",
+		AvailabilityZone: "us-west-2a",
+		PrivateDNSName:   "ip-10-0-0-1.us-west-2.compute.internal",
+	}
+
+	var tests = []struct {
+		name             string
+		options          NodeNameOptions
+		featureGates     map[Feature]bool
+		expectedNodeName string
+		expectedError    string
+		requiresEC2      bool
+	}{
+		{name: "default", expectedNodeName: "ip-10-0-0-1.us-west-2.compute.internal", requiresEC2: true},
+		{name: "feature gate", featureGates: map[Feature]bool{InstanceIdNodeName: true}, expectedNodeName: "i-1234567890abcdef0"},
+		{name: "strategy overrides feature gate", options: NodeNameOptions{Strategy: NodeNameStrategyPrivateDNSName}, featureGates: map[Feature]bool{InstanceIdNodeName: true}, expectedNodeName: "ip-10-0-0-1.us-west-2.compute.internal", requiresEC2: true},
+		{name: "resource name", options: NodeNameOptions{Strategy: NodeNameStrategyResourceName}, expectedNodeName: "i-1234567890abcdef0.us-west-2.compute.internal"},
+		{name: "hostname is lowercased", options: NodeNameOptions{Strategy: NodeNameStrategyHostname}, expectedNodeName: "my-node"},
+		{name: "template", options: NodeNameOptions{Strategy: NodeNameStrategyTemplate, Template: "{{ .ID }}.{{ .AvailabilityZone }}"}, expectedNodeName: "i-1234567890abcdef0.us-west-2a"},
+		{name: "template with private dns name", options: NodeNameOptions{Strategy: NodeNameStrategyTemplate, Template: "{{ .PrivateDNSName }}"}, expectedNodeName: "ip-10-0-0-1.us-west-2.compute.internal", requiresEC2: true},
+		{name: "template with unknown field", options: NodeNameOptions{Strategy: NodeNameStrategyTemplate, Template: "{{ .Zone }}"}, expectedError: `failed to get node name with the Template strategy: template: nodeName:1:3: executing "nodeName" at <.Zone>: can't evaluate field Zone in type *api.InstanceDetails`},
+		{name: "invalid name", options: NodeNameOptions{Strategy: NodeNameStrategyTemplate, Template: "{{ .ID }}_{{ .Region }}"}, expectedError: `invalid node name "i-1234567890abcdef0_us-west-2" from the Template strategy: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.requiresEC2, requiresPrivateDNSName(test.options, test.featureGates))
+			nodeName, err := ResolveNodeName(context.TODO(), test.options, test.featureGates, &instance, nil)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedNodeName, nodeName)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
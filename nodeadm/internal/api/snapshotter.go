@@ -0,0 +1,61 @@
+package api
+
+import "slices"
+
+// DefaultSnapshotterName is the snapshotter containerd unpacks images with
+// when none is selected
+const DefaultSnapshotterName = "overlayfs"
+
+// builtinSnapshotters are compiled into containerd, so they never run as a
+// proxy plugin
+var builtinSnapshotters = []string{DefaultSnapshotterName, "native", "btrfs", "zfs", "devmapper", "blockfile"}
+
+// defaultProxySnapshotters are the proxy snapshotters nodeadm knows how to run
+// without any further options
+var defaultProxySnapshotters = map[string]ProxySnapshotterOptions{
+	"soci": {
+		Address: "/run/soci-snapshotter-grpc/soci-snapshotter-grpc.sock",
+		Daemon:  "soci-snapshotter",
+	},
+	"stargz": {
+		Address: "/run/containerd-stargz-grpc/containerd-stargz-grpc.sock",
+		Daemon:  "stargz-snapshotter",
+	},
+}
+
+// GetSnapshotterName returns the name of the selected snapshotter
+func GetSnapshotterName(options SnapshotterOptions) string {
+	if options.Name == "" {
+		return DefaultSnapshotterName
+	}
+	return options.Name
+}
+
+// GetProxySnapshotter returns the options of the selected proxy snapshotter,
+// with the defaults of a known snapshotter applied, or nil if the snapshotter
+// is built into containerd
+func GetProxySnapshotter(options SnapshotterOptions) *ProxySnapshotterOptions {
+	name := GetSnapshotterName(options)
+	if slices.Contains(builtinSnapshotters, name) {
+		return nil
+	}
+	proxy := defaultProxySnapshotters[name]
+	if options.Proxy.Address != "" {
+		proxy.Address = options.Proxy.Address
+	}
+	if options.Proxy.Daemon != "" {
+		proxy.Daemon = options.Proxy.Daemon
+	}
+	return &proxy
+}
+
+// isDefaultProxySnapshotterDaemon returns whether the daemon runs one of the
+// proxy snapshotters that nodeadm knows about
+func isDefaultProxySnapshotterDaemon(daemon string) bool {
+	for _, proxy := range defaultProxySnapshotters {
+		if proxy.Daemon == daemon {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProxySnapshotter(t *testing.T) {
+	tests := []struct {
+		options       SnapshotterOptions
+		expectedProxy *ProxySnapshotterOptions
+	}{
+		{
+			options: SnapshotterOptions{},
+		},
+		{
+			options: SnapshotterOptions{Name: "native"},
+		},
+		{
+			options: SnapshotterOptions{Name: "soci"},
+			expectedProxy: &ProxySnapshotterOptions{
+				Address: "/run/soci-snapshotter-grpc/soci-snapshotter-grpc.sock",
+				Daemon:  "soci-snapshotter",
+			},
+		},
+		{
+			options: SnapshotterOptions{Name: "stargz", Proxy: ProxySnapshotterOptions{Daemon: "stargz"}},
+			expectedProxy: &ProxySnapshotterOptions{
+				Address: "/run/containerd-stargz-grpc/containerd-stargz-grpc.sock",
+				Daemon:  "stargz",
+			},
+		},
+		{
+			options:       SnapshotterOptions{Name: "nydus", Proxy: ProxySnapshotterOptions{Address: "/run/nydus.sock"}},
+			expectedProxy: &ProxySnapshotterOptions{Address: "/run/nydus.sock"},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expectedProxy, GetProxySnapshotter(test.options), test.options.Name)
+	}
+}
+
+func TestValidateSnapshotter(t *testing.T) {
+	tests := []struct {
+		options     SnapshotterOptions
+		expectedErr string
+	}{
+		{options: SnapshotterOptions{}},
+		{options: SnapshotterOptions{Name: "overlayfs"}},
+		{options: SnapshotterOptions{Name: "soci"}},
+		{options: SnapshotterOptions{Name: "nydus", Proxy: ProxySnapshotterOptions{Address: "/run/nydus.sock", Daemon: "nydus-snapshotter"}}},
+		{
+			options:     SnapshotterOptions{Proxy: ProxySnapshotterOptions{Address: "/run/soci.sock"}},
+			expectedErr: "Proxy snapshotter options require a snapshotter name",
+		},
+		{
+			options:     SnapshotterOptions{Name: "Soci"},
+			expectedErr: `Invalid snapshotter name: "Soci"`,
+		},
+		{
+			options:     SnapshotterOptions{Name: "overlayfs", Proxy: ProxySnapshotterOptions{Daemon: "overlayfs"}},
+			expectedErr: "Snapshotter overlayfs is built into containerd and cannot be a proxy",
+		},
+		{
+			options:     SnapshotterOptions{Name: "nydus"},
+			expectedErr: "Snapshotter nydus requires a proxy address",
+		},
+		{
+			options:     SnapshotterOptions{Name: "soci", Proxy: ProxySnapshotterOptions{Address: "soci.sock"}},
+			expectedErr: `Snapshotter soci proxy address must be absolute: "soci.sock"`,
+		},
+		{
+			options:     SnapshotterOptions{Name: "soci", Proxy: ProxySnapshotterOptions{Daemon: "soci snapshotter"}},
+			expectedErr: `Invalid daemon for snapshotter soci: "soci snapshotter"`,
+		},
+	}
+
+	for _, test := range tests {
+		err := validateSnapshotter(test.options)
+		if test.expectedErr == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, test.expectedErr)
+		}
+	}
+}
@@ -15,7 +15,7 @@ import (
 // Fetch information about the ec2 instance using IMDS data.
 // This information is stored into the internal config to avoid redundant calls
 // to IMDS when looking for instance metadata
-func GetInstanceDetails(ctx context.Context, featureGates map[Feature]bool, imdsClient *imds.Client, ec2Client *ec2.Client) (*InstanceDetails, error) {
+func GetInstanceDetails(ctx context.Context, nodeName NodeNameOptions, featureGates map[Feature]bool, imdsClient *imds.Client, ec2Client *ec2.Client) (*InstanceDetails, error) {
 	instanceIdenitityDocument, err := imdsClient.GetInstanceIdentityDocument(ctx, &imds.GetInstanceIdentityDocumentInput{})
 	if err != nil {
 		return nil, err
@@ -30,8 +30,10 @@ func GetInstanceDetails(ctx context.Context, featureGates map[Feature]bool, imds
 		return nil, err
 	}
 
+	// the private DNS name is only available from the EC2 API, which may wait
+	// for minutes, so it is skipped unless the node name depends on it
 	privateDNSName := ""
-	if !IsFeatureEnabled(InstanceIdNodeName, featureGates) {
+	if requiresPrivateDNSName(nodeName, featureGates) {
 		privateDNSName, err = getPrivateDNSName(ec2Client, instanceIdenitityDocument.InstanceID)
 		if err != nil {
 			return nil, err
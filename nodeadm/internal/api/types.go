@@ -36,8 +36,12 @@ type NodeConfigSpec struct {
 }
 
 type NodeConfigStatus struct {
-	Instance InstanceDetails `json:"instance,omitempty"`
-	Defaults DefaultOptions  `json:"default,omitempty"`
+	Instance          InstanceDetails    `json:"instance,omitempty"`
+	Defaults          DefaultOptions     `json:"default,omitempty"`
+	ReservedResources *ReservedResources `json:"reservedResources,omitempty"`
+	// NodeName is the name of the Node object resolved from the
+	// NodeNameOptions for this instance
+	NodeName string `json:"nodeName,omitempty"`
 }
 
 type InstanceDetails struct {
@@ -53,24 +57,177 @@ type DefaultOptions struct {
 	SandboxImage string `json:"sandboxImage,omitempty"`
 }
 
+// ReservedResources are the node allocatable settings resolved from the
+// ReservedResourcesOptions for this instance
+type ReservedResources struct {
+	MaxPods                int32             `json:"maxPods,omitempty"`
+	KubeReserved           map[string]string `json:"kubeReserved,omitempty"`
+	SystemReserved         map[string]string `json:"systemReserved,omitempty"`
+	KubeReservedCgroup     *string           `json:"kubeReservedCgroup,omitempty"`
+	SystemReservedCgroup   *string           `json:"systemReservedCgroup,omitempty"`
+	EvictionHard           map[string]string `json:"evictionHard,omitempty"`
+	EnforceNodeAllocatable []string          `json:"enforceNodeAllocatable,omitempty"`
+}
+
 type ClusterDetails struct {
-	Name                 string `json:"name,omitempty"`
-	APIServerEndpoint    string `json:"apiServerEndpoint,omitempty"`
-	CertificateAuthority []byte `json:"certificateAuthority,omitempty"`
-	CIDR                 string `json:"cidr,omitempty"`
-	EnableOutpost        *bool  `json:"enableOutpost,omitempty"`
-	ID                   string `json:"id,omitempty"`
+	Name                 string             `json:"name,omitempty"`
+	APIServerEndpoint    string             `json:"apiServerEndpoint,omitempty"`
+	CertificateAuthority []byte             `json:"certificateAuthority,omitempty"`
+	CIDR                 string             `json:"cidr,omitempty"`
+	EnableOutpost        *bool              `json:"enableOutpost,omitempty"`
+	ID                   string             `json:"id,omitempty"`
+	HostsRefreshInterval *metav1.Duration   `json:"hostsRefreshInterval,omitempty"`
+	Auth                 ClusterAuthOptions `json:"auth,omitempty"`
 }
 
+type ClusterAuthOptions struct {
+	Mode              ClusterAuthMode `json:"mode,omitempty"`
+	RoleARN           string          `json:"roleARN,omitempty"`
+	BootstrapToken    string          `json:"bootstrapToken,omitempty"`
+	ClientCertificate string          `json:"clientCertificate,omitempty"`
+	ClientKey         string          `json:"clientKey,omitempty"`
+}
+
+type ClusterAuthMode string
+
+const (
+	ClusterAuthModeEKSGetToken         ClusterAuthMode = "EKSGetToken"
+	ClusterAuthModeAWSIAMAuthenticator ClusterAuthMode = "AWSIAMAuthenticator"
+	ClusterAuthModeBootstrapToken      ClusterAuthMode = "BootstrapToken"
+	ClusterAuthModeClientCertificate   ClusterAuthMode = "ClientCertificate"
+)
+
 type KubeletOptions struct {
 	// Config is a kubelet config that can be provided by the user to override
 	// default generated configurations
 	// https://kubernetes.io/docs/reference/config-api/kubelet-config.v1/
 	Config InlineDocument `json:"config,omitempty"`
+	// ConfigDropIns are kubelet configs that are written to separate files in
+	// the drop-in directory, ordered by priority and name, and applied after
+	// Config
+	ConfigDropIns []KubeletConfigDropIn `json:"configDropIns,omitempty"`
 	// Flags is a list of command-line kubelet arguments. These arguments are
-	// amended to the generated defaults, and therefore will act as overrides
+	// merged with the generated defaults, and therefore will act as overrides.
+	// Flags with a KubeletConfiguration field are moved into the config on
+	// kubelet versions that deprecate them.
 	// https://kubernetes.io/docs/reference/command-line-tools-reference/kubelet/
 	Flags []string `json:"flags,omitempty"`
+	// Readiness controls how long to wait for kubelet to become healthy and
+	// whether to also wait for the node to register with the cluster
+	Readiness KubeletReadinessOptions `json:"readiness,omitempty"`
+	// Systemd are unit settings that are written to a drop-in for the kubelet
+	// service
+	Systemd SystemdOptions `json:"systemd,omitempty"`
+	// MaxPods selects how the maximum number of pods is calculated
+	MaxPods MaxPodsOptions `json:"maxPods,omitempty"`
+	// ReservedResources selects how the node allocatable reservations are
+	// calculated, see ReservedResources in the status for the result
+	ReservedResources ReservedResourcesOptions `json:"reservedResources,omitempty"`
+	// ImageCredentialProviders configure the kubelet image credential
+	// provider config, defaulting to the ECR credential provider
+	ImageCredentialProviders ImageCredentialProviderOptions `json:"imageCredentialProviders,omitempty"`
+	// GracefulShutdown sets the kubelet shutdown grace periods, and the
+	// systemd-logind inhibit delay that they require
+	GracefulShutdown GracefulShutdownOptions `json:"gracefulShutdown,omitempty"`
+	// NodeName selects how the node name is chosen, see NodeName in the
+	// status for the result
+	NodeName NodeNameOptions `json:"nodeName,omitempty"`
+	// NodeIP selects the --node-ip from the addresses on the host, instead of
+	// the primary address from IMDS
+	NodeIP NodeIPOptions `json:"nodeIP,omitempty"`
+}
+
+type GracefulShutdownOptions struct {
+	GracePeriod             *metav1.Duration                   `json:"gracePeriod,omitempty"`
+	CriticalPodsGracePeriod *metav1.Duration                   `json:"criticalPodsGracePeriod,omitempty"`
+	ByPodPriority           []ShutdownGracePeriodByPodPriority `json:"byPodPriority,omitempty"`
+}
+
+type ShutdownGracePeriodByPodPriority struct {
+	Priority           int32 `json:"priority"`
+	GracePeriodSeconds int64 `json:"gracePeriodSeconds"`
+}
+
+type NodeNameOptions struct {
+	Strategy NodeNameStrategy `json:"strategy,omitempty"`
+	Template string           `json:"template,omitempty"`
+}
+
+type NodeNameStrategy string
+
+const (
+	NodeNameStrategyPrivateDNSName NodeNameStrategy = "PrivateDNSName"
+	NodeNameStrategyInstanceID     NodeNameStrategy = "InstanceID"
+	NodeNameStrategyResourceName   NodeNameStrategy = "ResourceName"
+	NodeNameStrategyHostname       NodeNameStrategy = "Hostname"
+	NodeNameStrategyTemplate       NodeNameStrategy = "Template"
+)
+
+type KubeletConfigDropIn struct {
+	Name     string         `json:"name"`
+	Priority int32          `json:"priority"`
+	Config   InlineDocument `json:"config"`
+}
+
+type NodeIPOptions struct {
+	Interface   string   `json:"interface,omitempty"`
+	MAC         string   `json:"mac,omitempty"`
+	DeviceIndex *int32   `json:"deviceIndex,omitempty"`
+	CIDRs       []string `json:"cidrs,omitempty"`
+	DualStack   bool     `json:"dualStack,omitempty"`
+}
+
+type MaxPodsOptions struct {
+	Policy              MaxPodsPolicy `json:"policy,omitempty"`
+	Value               *int32        `json:"value,omitempty"`
+	CNIVersion          string        `json:"cniVersion,omitempty"`
+	CNICustomNetworking bool          `json:"cniCustomNetworking,omitempty"`
+	CNIPrefixDelegation bool          `json:"cniPrefixDelegation,omitempty"`
+	CNIMaxENI           *int32        `json:"cniMaxENI,omitempty"`
+}
+
+type MaxPodsPolicy string
+
+const (
+	MaxPodsPolicyEKS        MaxPodsPolicy = "EKS"
+	MaxPodsPolicyCalculated MaxPodsPolicy = "Calculated"
+	MaxPodsPolicyFixed      MaxPodsPolicy = "Fixed"
+)
+
+type ReservedResourcesOptions struct {
+	Policy                 ReservationPolicy `json:"policy,omitempty"`
+	KubeReserved           map[string]string `json:"kubeReserved,omitempty"`
+	SystemReserved         map[string]string `json:"systemReserved,omitempty"`
+	KubeReservedCgroup     *string           `json:"kubeReservedCgroup,omitempty"`
+	SystemReservedCgroup   *string           `json:"systemReservedCgroup,omitempty"`
+	EvictionHard           map[string]string `json:"evictionHard,omitempty"`
+	EnforceNodeAllocatable []string          `json:"enforceNodeAllocatable,omitempty"`
+}
+
+type ReservationPolicy string
+
+const (
+	ReservationPolicyEKS        ReservationPolicy = "EKS"
+	ReservationPolicyFixed      ReservationPolicy = "Fixed"
+	ReservationPolicyPercentage ReservationPolicy = "Percentage"
+)
+
+type ImageCredentialProviderOptions struct {
+	BinDir    string                    `json:"binDir,omitempty"`
+	Providers []ImageCredentialProvider `json:"providers,omitempty"`
+}
+
+type ImageCredentialProvider struct {
+	Name                 string            `json:"name"`
+	MatchImages          []string          `json:"matchImages,omitempty"`
+	DefaultCacheDuration *metav1.Duration  `json:"defaultCacheDuration,omitempty"`
+	Args                 []string          `json:"args,omitempty"`
+	Env                  map[string]string `json:"env,omitempty"`
+}
+
+type KubeletReadinessOptions struct {
+	Timeout                 *metav1.Duration `json:"timeout,omitempty"`
+	WaitForNodeRegistration *bool            `json:"waitForNodeRegistration,omitempty"`
 }
 
 // InlineDocument is an alias to a dynamically typed map. This allows using
@@ -78,10 +235,118 @@ type KubeletOptions struct {
 type InlineDocument map[string]runtime.RawExtension
 
 type ContainerdOptions struct {
-	Config          string         `json:"config,omitempty"`
+	Config                 string                     `json:"config,omitempty"`
+	BaseRuntimeSpec        InlineDocument             `json:"baseRuntimeSpec,omitempty"`
+	BaseRuntimeSpecPresets []BaseRuntimeSpecPreset    `json:"baseRuntimeSpecPresets,omitempty"`
+	Snapshotter            SnapshotterOptions         `json:"snapshotter,omitempty"`
+	Runtimes               []ContainerRuntime         `json:"runtimes,omitempty"`
+	Registries             []RegistryOptions          `json:"registries,omitempty"`
+	Readiness              ContainerdReadinessOptions `json:"readiness,omitempty"`
+	SandboxImagePull       SandboxImagePullOptions    `json:"sandboxImagePull,omitempty"`
+	PreloadImages          PreloadImagesOptions       `json:"preloadImages,omitempty"`
+	Systemd                SystemdOptions             `json:"systemd,omitempty"`
+}
+
+type BaseRuntimeSpecPreset string
+
+const (
+	BaseRuntimeSpecPresetRaisedNoFile     BaseRuntimeSpecPreset = "RaisedNoFile"
+	BaseRuntimeSpecPresetUnlimitedMemlock BaseRuntimeSpecPreset = "UnlimitedMemlock"
+	BaseRuntimeSpecPresetDropNetRaw       BaseRuntimeSpecPreset = "DropNetRaw"
+)
+
+type SnapshotterOptions struct {
+	Name  string                  `json:"name,omitempty"`
+	Proxy ProxySnapshotterOptions `json:"proxy,omitempty"`
+}
+
+type ProxySnapshotterOptions struct {
+	Address string `json:"address,omitempty"`
+	Daemon  string `json:"daemon,omitempty"`
+}
+
+type ContainerRuntime struct {
+	Name            string         `json:"name"`
+	Type            string         `json:"type"`
+	Path            string         `json:"path,omitempty"`
+	Options         InlineDocument `json:"options,omitempty"`
 	BaseRuntimeSpec InlineDocument `json:"baseRuntimeSpec,omitempty"`
 }
 
+type RegistryOptions struct {
+	Name      string             `json:"name"`
+	Server    string             `json:"server,omitempty"`
+	ServerTLS RegistryTLSOptions `json:"serverTLS,omitempty"`
+	Mirrors   []RegistryMirror   `json:"mirrors,omitempty"`
+}
+
+type RegistryMirror struct {
+	Host         string               `json:"host"`
+	Capabilities []RegistryCapability `json:"capabilities,omitempty"`
+	OverridePath bool                 `json:"overridePath,omitempty"`
+	TLS          RegistryTLSOptions   `json:"tls,omitempty"`
+}
+
+type RegistryCapability string
+
+const (
+	RegistryCapabilityPull    RegistryCapability = "pull"
+	RegistryCapabilityResolve RegistryCapability = "resolve"
+	RegistryCapabilityPush    RegistryCapability = "push"
+)
+
+type RegistryTLSOptions struct {
+	CertificateAuthority []byte `json:"certificateAuthority,omitempty"`
+	ClientCertificate    string `json:"clientCertificate,omitempty"`
+	ClientKey            string `json:"clientKey,omitempty"`
+	InsecureSkipVerify   bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+type ContainerdReadinessOptions struct {
+	Timeout    *metav1.Duration   `json:"timeout,omitempty"`
+	Conditions []RuntimeCondition `json:"conditions,omitempty"`
+}
+
+type SandboxImagePullOptions struct {
+	Timeout  *metav1.Duration `json:"timeout,omitempty"`
+	Attempts int32            `json:"attempts,omitempty"`
+	Backoff  *metav1.Duration `json:"backoff,omitempty"`
+}
+
+type PreloadImagesOptions struct {
+	Images      []PreloadImage        `json:"images,omitempty"`
+	Archives    []PreloadImageArchive `json:"archives,omitempty"`
+	Parallelism int32                 `json:"parallelism,omitempty"`
+	Timeout     *metav1.Duration      `json:"timeout,omitempty"`
+}
+
+type PreloadImage struct {
+	Image  string `json:"image"`
+	Pinned bool   `json:"pinned,omitempty"`
+}
+
+type PreloadImageArchive struct {
+	Path   string `json:"path"`
+	Pinned bool   `json:"pinned,omitempty"`
+}
+
+type SystemdOptions struct {
+	After       []string          `json:"after,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+	LimitNOFILE string            `json:"limitNOFILE,omitempty"`
+	MemoryHigh  string            `json:"memoryHigh,omitempty"`
+	CPUWeight   string            `json:"cpuWeight,omitempty"`
+	Slice       string            `json:"slice,omitempty"`
+	Restart     string            `json:"restart,omitempty"`
+}
+
+type RuntimeCondition string
+
+const (
+	RuntimeReady RuntimeCondition = "RuntimeReady"
+	NetworkReady RuntimeCondition = "NetworkReady"
+)
+
 type IPFamily string
 
 const (
@@ -90,9 +355,17 @@ const (
 )
 
 type InstanceOptions struct {
-	LocalStorage LocalStorageOptions `json:"localStorage,omitempty"`
+	LocalStorage  LocalStorageOptions `json:"localStorage,omitempty"`
+	DaemonManager DaemonManager       `json:"daemonManager,omitempty"`
 }
 
+type DaemonManager string
+
+const (
+	DaemonManagerSystemd DaemonManager = "systemd"
+	DaemonManagerProcess DaemonManager = "process"
+)
+
 type LocalStorageOptions struct {
 	Strategy LocalStorageStrategy `json:"strategy,omitempty"`
 }
@@ -1,6 +1,21 @@
 package api
 
-import "fmt"
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/mod/semver"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
 
 func ValidateNodeConfig(cfg *NodeConfig) error {
 	if cfg.Spec.Cluster.Name == "" {
@@ -20,5 +35,535 @@ func ValidateNodeConfig(cfg *NodeConfig) error {
 			return fmt.Errorf("CIDR is missing in cluster configuration")
 		}
 	}
+	if interval := cfg.Spec.Cluster.HostsRefreshInterval; interval != nil && interval.Duration < time.Minute {
+		return fmt.Errorf("Hosts refresh interval must be at least 1m")
+	}
+	if err := validateClusterAuth(cfg.Spec.Cluster.Auth); err != nil {
+		return err
+	}
+	for _, condition := range cfg.Spec.Containerd.Readiness.Conditions {
+		if condition != RuntimeReady && condition != NetworkReady {
+			return fmt.Errorf("Unknown containerd readiness condition: %s", condition)
+		}
+	}
+	if timeout := cfg.Spec.Containerd.Readiness.Timeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("Containerd readiness timeout must be positive")
+	}
+	if timeout := cfg.Spec.Containerd.SandboxImagePull.Timeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("Sandbox image pull timeout must be positive")
+	}
+	if cfg.Spec.Containerd.SandboxImagePull.Attempts < 0 {
+		return fmt.Errorf("Sandbox image pull attempts must not be negative")
+	}
+	if backoff := cfg.Spec.Containerd.SandboxImagePull.Backoff; backoff != nil && backoff.Duration < 0 {
+		return fmt.Errorf("Sandbox image pull backoff must not be negative")
+	}
+	if err := validatePreloadImages(cfg.Spec.Containerd.PreloadImages); err != nil {
+		return err
+	}
+	for _, preset := range cfg.Spec.Containerd.BaseRuntimeSpecPresets {
+		if !slices.Contains(baseRuntimeSpecPresets, preset) {
+			return fmt.Errorf("Unknown base runtime spec preset: %s", preset)
+		}
+	}
+	if err := validateSnapshotter(cfg.Spec.Containerd.Snapshotter); err != nil {
+		return err
+	}
+	if err := validateContainerRuntimes(cfg.Spec.Containerd.Runtimes); err != nil {
+		return err
+	}
+	if err := validateRegistries(cfg.Spec.Containerd.Registries); err != nil {
+		return err
+	}
+	if timeout := cfg.Spec.Kubelet.Readiness.Timeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("Kubelet readiness timeout must be positive")
+	}
+	if err := validateDaemonManager(cfg); err != nil {
+		return err
+	}
+	for _, systemd := range []SystemdOptions{cfg.Spec.Containerd.Systemd, cfg.Spec.Kubelet.Systemd} {
+		if systemd.Restart != "" && !slices.Contains(systemdRestartPolicies, systemd.Restart) {
+			return fmt.Errorf("Unknown systemd restart policy: %s", systemd.Restart)
+		}
+		for key := range systemd.Environment {
+			if !isValidEnvironmentVariableName(key) {
+				return fmt.Errorf("Invalid systemd environment variable name: %q", key)
+			}
+		}
+	}
+	if err := validateMaxPods(cfg.Spec.Kubelet.MaxPods); err != nil {
+		return err
+	}
+	if err := validateReservedResources(cfg.Spec.Kubelet.ReservedResources); err != nil {
+		return err
+	}
+	if err := validateImageCredentialProviders(cfg.Spec.Kubelet.ImageCredentialProviders); err != nil {
+		return err
+	}
+	if err := validateNodeIP(cfg.Spec.Kubelet.NodeIP); err != nil {
+		return err
+	}
+	if err := validateKubeletConfigDropIns(cfg.Spec.Kubelet.ConfigDropIns); err != nil {
+		return err
+	}
+	if err := validateNodeName(cfg.Spec.Kubelet.NodeName); err != nil {
+		return err
+	}
+	if err := validateGracefulShutdown(cfg.Spec.Kubelet.GracefulShutdown); err != nil {
+		return err
+	}
+	return nil
+}
+
+var bootstrapTokenPattern = regexp.MustCompile(`^[a-z0-9]{6}\.[a-z0-9]{16}$`)
+
+func validateClusterAuth(options ClusterAuthOptions) error {
+	switch options.Mode {
+	case "", ClusterAuthModeEKSGetToken, ClusterAuthModeAWSIAMAuthenticator:
+		if options.RoleARN != "" && !strings.HasPrefix(options.RoleARN, "arn:") {
+			return fmt.Errorf("Invalid cluster auth role ARN: %s", options.RoleARN)
+		}
+	case ClusterAuthModeBootstrapToken:
+		if !bootstrapTokenPattern.MatchString(options.BootstrapToken) {
+			return fmt.Errorf("Bootstrap token is missing or invalid for the BootstrapToken cluster auth mode")
+		}
+	case ClusterAuthModeClientCertificate:
+		if !path.IsAbs(options.ClientCertificate) || !path.IsAbs(options.ClientKey) {
+			return fmt.Errorf("Client certificate and key paths must be absolute for the ClientCertificate cluster auth mode")
+		}
+	default:
+		return fmt.Errorf("Unknown cluster auth mode: %s", options.Mode)
+	}
+	if options.RoleARN != "" && (options.Mode == ClusterAuthModeBootstrapToken || options.Mode == ClusterAuthModeClientCertificate) {
+		return fmt.Errorf("Cluster auth role ARN is not supported by the %s mode", options.Mode)
+	}
+	return nil
+}
+
+// validateDaemonManager rejects the settings that the daemon manager cannot
+// apply, as the process daemon manager has no units for drop-ins
+func validateDaemonManager(cfg *NodeConfig) error {
+	switch GetDaemonManager(cfg.Spec.Instance) {
+	case DaemonManagerSystemd:
+	case DaemonManagerProcess:
+		if !reflect.ValueOf(cfg.Spec.Containerd.Systemd).IsZero() {
+			return fmt.Errorf("Containerd systemd options are not supported by the %s daemon manager", DaemonManagerProcess)
+		}
+		if !reflect.ValueOf(cfg.Spec.Kubelet.Systemd).IsZero() {
+			return fmt.Errorf("Kubelet systemd options are not supported by the %s daemon manager", DaemonManagerProcess)
+		}
+		// the process daemon manager only knows how to run the daemons of the
+		// default proxy snapshotters
+		if proxy := GetProxySnapshotter(cfg.Spec.Containerd.Snapshotter); proxy != nil && proxy.Daemon != "" && !isDefaultProxySnapshotterDaemon(proxy.Daemon) {
+			return fmt.Errorf("Snapshotter daemon %s is not supported by the %s daemon manager", proxy.Daemon, DaemonManagerProcess)
+		}
+	default:
+		return fmt.Errorf("Unknown daemon manager: %s", cfg.Spec.Instance.DaemonManager)
+	}
+	return nil
+}
+
+var systemdRestartPolicies = []string{"no", "always", "on-success", "on-failure", "on-abnormal", "on-abort", "on-watchdog"}
+
+func validateMaxPods(options MaxPodsOptions) error {
+	switch options.Policy {
+	case "", MaxPodsPolicyEKS:
+	case MaxPodsPolicyCalculated:
+		if !semver.IsValid(CanonicalVersion(options.CNIVersion)) {
+			return fmt.Errorf("Invalid or missing VPC CNI version for the Calculated max pods policy: %q", options.CNIVersion)
+		}
+		if options.CNIMaxENI != nil && *options.CNIMaxENI <= 0 {
+			return fmt.Errorf("Max pods CNI max ENI must be positive")
+		}
+	case MaxPodsPolicyFixed:
+		if options.Value == nil || *options.Value <= 0 {
+			return fmt.Errorf("Max pods value must be positive for the Fixed max pods policy")
+		}
+	default:
+		return fmt.Errorf("Unknown max pods policy: %s", options.Policy)
+	}
+	return nil
+}
+
+// CanonicalVersion adds the v prefix that semver requires to a version
+func CanonicalVersion(version string) string {
+	if version == "" || strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
+
+var (
+	reservableResources    = []string{"cpu", "memory", "ephemeral-storage", "pid"}
+	enforceNodeAllocatable = []string{"pods", "system-reserved", "kube-reserved", "none"}
+)
+
+func validateReservedResources(options ReservedResourcesOptions) error {
+	switch options.Policy {
+	case "", ReservationPolicyEKS, ReservationPolicyFixed, ReservationPolicyPercentage:
+	default:
+		return fmt.Errorf("Unknown reserved resources policy: %s", options.Policy)
+	}
+	for _, reserved := range []map[string]string{options.KubeReserved, options.SystemReserved} {
+		for name, value := range reserved {
+			if !slices.Contains(reservableResources, name) {
+				return fmt.Errorf("Unknown reserved resource: %s", name)
+			}
+			if options.Policy == ReservationPolicyPercentage {
+				if name == "pid" {
+					return fmt.Errorf("Reserved resource pid cannot be a percentage")
+				}
+				if _, err := ParsePercentage(value); err != nil {
+					return fmt.Errorf("Invalid reserved %s: %w", name, err)
+				}
+			} else if _, err := resource.ParseQuantity(value); err != nil {
+				return fmt.Errorf("Invalid reserved %s %q: %w", name, value, err)
+			}
+		}
+	}
+	for _, value := range options.EnforceNodeAllocatable {
+		if !slices.Contains(enforceNodeAllocatable, value) {
+			return fmt.Errorf("Unknown enforceNodeAllocatable value: %s", value)
+		}
+	}
+	return nil
+}
+
+// ParsePercentage parses a percentage such as 5% or 0.5% into a fraction
+func ParsePercentage(value string) (float64, error) {
+	number, ok := strings.CutSuffix(value, "%")
+	if !ok {
+		return 0, fmt.Errorf("%q is not a percentage", value)
+	}
+	percentage, err := strconv.ParseFloat(number, 64)
+	if err != nil || percentage < 0 || percentage > 100 {
+		return 0, fmt.Errorf("%q is not a percentage between 0%% and 100%%", value)
+	}
+	return percentage / 100, nil
+}
+
+func isValidEnvironmentVariableName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "= \t\n")
+}
+
+// ECRCredentialProviderName is the name of the Amazon ECR credential provider,
+// which has default image patterns
+const ECRCredentialProviderName = "ecr-credential-provider"
+
+func validateImageCredentialProviders(options ImageCredentialProviderOptions) error {
+	if options.BinDir != "" && !path.IsAbs(options.BinDir) {
+		return fmt.Errorf("Image credential provider binDir must be an absolute path: %s", options.BinDir)
+	}
+	names := make(map[string]bool)
+	for _, provider := range options.Providers {
+		if provider.Name == "" || strings.ContainsAny(provider.Name, "/ ") || provider.Name == "." || provider.Name == ".." {
+			return fmt.Errorf("Invalid image credential provider name: %q", provider.Name)
+		}
+		if names[provider.Name] {
+			return fmt.Errorf("Duplicate image credential provider: %s", provider.Name)
+		}
+		names[provider.Name] = true
+		if len(provider.MatchImages) == 0 && provider.Name != ECRCredentialProviderName {
+			return fmt.Errorf("Image credential provider %s has no matchImages", provider.Name)
+		}
+		for _, pattern := range provider.MatchImages {
+			if err := validateMatchImage(pattern); err != nil {
+				return fmt.Errorf("Invalid matchImages pattern %q for image credential provider %s: %w", pattern, provider.Name, err)
+			}
+		}
+		if duration := provider.DefaultCacheDuration; duration != nil && duration.Duration < 0 {
+			return fmt.Errorf("Image credential provider %s defaultCacheDuration must not be negative", provider.Name)
+		}
+		for key := range provider.Env {
+			if !isValidEnvironmentVariableName(key) {
+				return fmt.Errorf("Invalid environment variable name for image credential provider %s: %q", provider.Name, key)
+			}
+		}
+	}
+	return nil
+}
+
+// validateMatchImage checks a pattern in the same way as kubelet, which only
+// allows globs in the domain
+func validateMatchImage(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("pattern is empty")
+	}
+	if strings.Contains(pattern, "://") {
+		return fmt.Errorf("pattern must not have a scheme")
+	}
+	host, urlPath, _ := strings.Cut(pattern, "/")
+	domain, port, hasPort := strings.Cut(host, ":")
+	if strings.Contains(urlPath, "*") || strings.Contains(port, "*") {
+		return fmt.Errorf("globs are only allowed in the domain")
+	}
+	if hasPort {
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return fmt.Errorf("invalid port %q", port)
+		}
+	}
+	for _, segment := range strings.Split(domain, ".") {
+		if segment == "" {
+			return fmt.Errorf("domain has an empty segment")
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateNodeIP(options NodeIPOptions) error {
+	selectors := 0
+	if options.Interface != "" {
+		selectors++
+	}
+	if options.MAC != "" {
+		if _, err := net.ParseMAC(options.MAC); err != nil {
+			return fmt.Errorf("Invalid node IP MAC address: %w", err)
+		}
+		selectors++
+	}
+	if options.DeviceIndex != nil {
+		if *options.DeviceIndex < 0 {
+			return fmt.Errorf("Node IP device index must not be negative")
+		}
+		selectors++
+	}
+	if selectors > 1 {
+		return fmt.Errorf("Only one of interface, mac and deviceIndex may be set to select the node IP")
+	}
+	for _, cidr := range options.CIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("Invalid node IP CIDR: %w", err)
+		}
+	}
+	return nil
+}
+
+var kubeletConfigDropInNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+func validateKubeletConfigDropIns(dropIns []KubeletConfigDropIn) error {
+	names := map[string]bool{}
+	for _, dropIn := range dropIns {
+		if !kubeletConfigDropInNamePattern.MatchString(dropIn.Name) {
+			return fmt.Errorf("Invalid kubelet config drop-in name: %q", dropIn.Name)
+		}
+		if names[dropIn.Name] {
+			return fmt.Errorf("Duplicate kubelet config drop-in name: %s", dropIn.Name)
+		}
+		names[dropIn.Name] = true
+		if dropIn.Priority < 1 || dropIn.Priority > 99 {
+			return fmt.Errorf("Kubelet config drop-in %s priority must be between 1 and 99", dropIn.Name)
+		}
+		if len(dropIn.Config) == 0 {
+			return fmt.Errorf("Kubelet config drop-in %s has no config", dropIn.Name)
+		}
+	}
+	return nil
+}
+
+func validateNodeName(options NodeNameOptions) error {
+	switch options.Strategy {
+	case "", NodeNameStrategyPrivateDNSName, NodeNameStrategyInstanceID, NodeNameStrategyResourceName, NodeNameStrategyHostname:
+		if options.Template != "" {
+			return fmt.Errorf("Node name template is only supported by the Template strategy")
+		}
+	case NodeNameStrategyTemplate:
+		if options.Template == "" {
+			return fmt.Errorf("Node name template is missing for the Template strategy")
+		}
+		if _, err := template.New("nodeName").Parse(options.Template); err != nil {
+			return fmt.Errorf("Invalid node name template: %w", err)
+		}
+	default:
+		return fmt.Errorf("Unknown node name strategy: %s", options.Strategy)
+	}
+	return nil
+}
+
+func validateGracefulShutdown(options GracefulShutdownOptions) error {
+	if len(options.ByPodPriority) > 0 {
+		if options.GracePeriod != nil || options.CriticalPodsGracePeriod != nil {
+			return fmt.Errorf("Graceful shutdown periods by pod priority cannot be combined with gracePeriod or criticalPodsGracePeriod")
+		}
+		priorities := map[int32]bool{}
+		for _, period := range options.ByPodPriority {
+			if priorities[period.Priority] {
+				return fmt.Errorf("Duplicate graceful shutdown period for pod priority %d", period.Priority)
+			}
+			priorities[period.Priority] = true
+			if period.GracePeriodSeconds < 0 {
+				return fmt.Errorf("Graceful shutdown period for pod priority %d must not be negative", period.Priority)
+			}
+		}
+		return nil
+	}
+	if options.CriticalPodsGracePeriod != nil {
+		if options.GracePeriod == nil {
+			return fmt.Errorf("Graceful shutdown criticalPodsGracePeriod requires gracePeriod")
+		}
+		if options.CriticalPodsGracePeriod.Duration < 0 {
+			return fmt.Errorf("Graceful shutdown criticalPodsGracePeriod must not be negative")
+		}
+		if options.CriticalPodsGracePeriod.Duration > options.GracePeriod.Duration {
+			return fmt.Errorf("Graceful shutdown criticalPodsGracePeriod must not exceed gracePeriod")
+		}
+	}
+	if options.GracePeriod != nil && options.GracePeriod.Duration < 0 {
+		return fmt.Errorf("Graceful shutdown gracePeriod must not be negative")
+	}
+	return nil
+}
+
+// DefaultRegistryName configures every registry without its own configuration
+const DefaultRegistryName = "_default"
+
+var registryNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9.]*[A-Za-z0-9])?(:[0-9]{1,5})?$`)
+
+func validateRegistries(registries []RegistryOptions) error {
+	names := map[string]bool{}
+	for _, registry := range registries {
+		if registry.Name != DefaultRegistryName && !registryNamePattern.MatchString(registry.Name) {
+			return fmt.Errorf("Invalid registry name: %q", registry.Name)
+		}
+		if names[registry.Name] {
+			return fmt.Errorf("Duplicate registry: %s", registry.Name)
+		}
+		names[registry.Name] = true
+		if registry.Server != "" {
+			if err := validateRegistryHost(registry.Server); err != nil {
+				return fmt.Errorf("Invalid server for registry %s: %w", registry.Name, err)
+			}
+		} else if registry.Name == DefaultRegistryName && !reflect.ValueOf(registry.ServerTLS).IsZero() {
+			return fmt.Errorf("Registry %s requires a server for its server TLS settings", registry.Name)
+		}
+		if err := validateRegistryTLS(registry.ServerTLS); err != nil {
+			return fmt.Errorf("Invalid server TLS settings for registry %s: %w", registry.Name, err)
+		}
+		for _, mirror := range registry.Mirrors {
+			if err := validateRegistryHost(mirror.Host); err != nil {
+				return fmt.Errorf("Invalid mirror for registry %s: %w", registry.Name, err)
+			}
+			for _, capability := range mirror.Capabilities {
+				if capability != RegistryCapabilityPull && capability != RegistryCapabilityResolve && capability != RegistryCapabilityPush {
+					return fmt.Errorf("Unknown capability %s of mirror %s for registry %s", capability, mirror.Host, registry.Name)
+				}
+			}
+			if err := validateRegistryTLS(mirror.TLS); err != nil {
+				return fmt.Errorf("Invalid TLS settings of mirror %s for registry %s: %w", mirror.Host, registry.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateRegistryHost(host string) error {
+	u, err := url.Parse(host)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q must be an http or https URL", host)
+	}
+	return nil
+}
+
+func validateRegistryTLS(options RegistryTLSOptions) error {
+	if (options.ClientCertificate == "") != (options.ClientKey == "") {
+		return fmt.Errorf("client certificate and key must be set together")
+	}
+	if options.ClientCertificate != "" && (!path.IsAbs(options.ClientCertificate) || !path.IsAbs(options.ClientKey)) {
+		return fmt.Errorf("client certificate and key paths must be absolute")
+	}
+	return nil
+}
+
+func validatePreloadImages(options PreloadImagesOptions) error {
+	for _, image := range options.Images {
+		if image.Image == "" {
+			return fmt.Errorf("Preload image must not be empty")
+		}
+	}
+	for _, archive := range options.Archives {
+		if !path.IsAbs(archive.Path) {
+			return fmt.Errorf("Preload image archive path must be absolute: %q", archive.Path)
+		}
+	}
+	if options.Parallelism < 0 {
+		return fmt.Errorf("Preload images parallelism must not be negative")
+	}
+	if timeout := options.Timeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("Preload images timeout must be positive")
+	}
+	return nil
+}
+
+// DefaultContainerRuntimeName is the runtime that nodeadm always configures
+const DefaultContainerRuntimeName = "runc"
+
+// runtimeNamePattern matches a RuntimeClass handler, which is a DNS label
+var runtimeNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+func validateContainerRuntimes(runtimes []ContainerRuntime) error {
+	names := map[string]bool{}
+	for _, runtime := range runtimes {
+		if !runtimeNamePattern.MatchString(runtime.Name) || len(runtime.Name) > 63 {
+			return fmt.Errorf("Invalid container runtime name: %q", runtime.Name)
+		}
+		if runtime.Name == DefaultContainerRuntimeName {
+			return fmt.Errorf("Container runtime name %s is reserved for the default runtime", runtime.Name)
+		}
+		if names[runtime.Name] {
+			return fmt.Errorf("Duplicate container runtime: %s", runtime.Name)
+		}
+		names[runtime.Name] = true
+		if runtime.Type == "" {
+			return fmt.Errorf("Container runtime %s has no type", runtime.Name)
+		}
+		if runtime.Path != "" && !path.IsAbs(runtime.Path) {
+			return fmt.Errorf("Container runtime %s path must be absolute: %q", runtime.Name, runtime.Path)
+		}
+	}
+	return nil
+}
+
+var baseRuntimeSpecPresets = []BaseRuntimeSpecPreset{
+	BaseRuntimeSpecPresetRaisedNoFile,
+	BaseRuntimeSpecPresetUnlimitedMemlock,
+	BaseRuntimeSpecPresetDropNetRaw,
+}
+
+var (
+	snapshotterNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9._]*[a-z0-9])?$`)
+	systemdUnitNamePattern = regexp.MustCompile(`^[A-Za-z0-9:_.@-]+$`)
+)
+
+func validateSnapshotter(options SnapshotterOptions) error {
+	if options.Name == "" {
+		if !reflect.ValueOf(options.Proxy).IsZero() {
+			return fmt.Errorf("Proxy snapshotter options require a snapshotter name")
+		}
+		return nil
+	}
+	if !snapshotterNamePattern.MatchString(options.Name) {
+		return fmt.Errorf("Invalid snapshotter name: %q", options.Name)
+	}
+	proxy := GetProxySnapshotter(options)
+	if proxy == nil {
+		if !reflect.ValueOf(options.Proxy).IsZero() {
+			return fmt.Errorf("Snapshotter %s is built into containerd and cannot be a proxy", options.Name)
+		}
+		return nil
+	}
+	if proxy.Address == "" {
+		return fmt.Errorf("Snapshotter %s requires a proxy address", options.Name)
+	}
+	if !path.IsAbs(proxy.Address) {
+		return fmt.Errorf("Snapshotter %s proxy address must be absolute: %q", options.Name, proxy.Address)
+	}
+	if proxy.Daemon != "" && !systemdUnitNamePattern.MatchString(proxy.Daemon) {
+		return fmt.Errorf("Invalid daemon for snapshotter %s: %q", options.Name, proxy.Daemon)
+	}
 	return nil
 }
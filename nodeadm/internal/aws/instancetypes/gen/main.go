@@ -0,0 +1,64 @@
+// Command gen refreshes the embedded instance type dataset from the
+// DescribeInstanceTypes API of every enabled region.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/instancetypes"
+)
+
+func main() {
+	output := flag.String("output", "instance-types.txt", "path of the dataset to write")
+	region := flag.String("region", "us-east-1", "region used to list the enabled regions")
+	flag.Parse()
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(*region))
+	if err != nil {
+		log.Fatal(err)
+	}
+	regions, err := ec2.NewFromConfig(cfg).DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	found := make(map[string]instancetypes.InstanceType)
+	for _, r := range regions.Regions {
+		log.Printf("Describing instance types in %s", aws.ToString(r.RegionName))
+		client := ec2.NewFromConfig(cfg, func(o *ec2.Options) {
+			o.Region = aws.ToString(r.RegionName)
+		})
+		paginator := ec2.NewDescribeInstanceTypesPaginator(client, &ec2.DescribeInstanceTypesInput{})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, info := range page.InstanceTypes {
+				instanceType, err := instancetypes.FromInstanceTypeInfo(info)
+				if err != nil {
+					log.Printf("Skipping instance type: %v", err)
+					continue
+				}
+				found[instanceType.Name] = instanceType
+			}
+		}
+	}
+
+	var all []instancetypes.InstanceType
+	for _, instanceType := range found {
+		all = append(all, instanceType)
+	}
+	if err := os.WriteFile(*output, []byte(instancetypes.Format(all)), 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Wrote %d instance types to %s", len(all), *output)
+}
@@ -0,0 +1,148 @@
+package instancetypes
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+//go:generate go run ./gen -output instance-types.txt
+
+//go:embed instance-types.txt
+var instanceTypesData string
+
+// InstanceType are the attributes of an EC2 instance type that determine the
+// node's defaults
+type InstanceType struct {
+	Name       string
+	VCpus      int32
+	MemoryMiB  int64
+	Hypervisor string
+	// MaxENIs is the number of network interfaces of the default network card
+	MaxENIs    int32
+	IPv4PerENI int32
+	IPv6PerENI int32
+	// NVMeCount and NVMeSizeGB describe the NVMe instance store volumes, each
+	// of which has the same size
+	NVMeCount  int32
+	NVMeSizeGB int64
+}
+
+var instanceTypes map[string]InstanceType
+
+func init() {
+	var err error
+	instanceTypes, err = parse(instanceTypesData)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded instance type data: %v", err))
+	}
+}
+
+// Get returns the attributes of an instance type from the embedded dataset.
+// The dataset may not list every instance type, so callers fall back to other
+// sources when it is missing.
+func Get(instanceType string) (InstanceType, bool) {
+	info, ok := instanceTypes[instanceType]
+	return info, ok
+}
+
+// FromInstanceTypeInfo converts the output of the DescribeInstanceTypes API
+func FromInstanceTypeInfo(info types.InstanceTypeInfo) (InstanceType, error) {
+	if info.VCpuInfo == nil || info.MemoryInfo == nil || info.NetworkInfo == nil {
+		return InstanceType{}, fmt.Errorf("incomplete instance type information for type: %s", info.InstanceType)
+	}
+	instanceType := InstanceType{
+		Name:       string(info.InstanceType),
+		VCpus:      deref(info.VCpuInfo.DefaultVCpus),
+		MemoryMiB:  deref(info.MemoryInfo.SizeInMiB),
+		Hypervisor: string(info.Hypervisor),
+		MaxENIs:    deref(info.NetworkInfo.MaximumNetworkInterfaces),
+		IPv4PerENI: deref(info.NetworkInfo.Ipv4AddressesPerInterface),
+		IPv6PerENI: deref(info.NetworkInfo.Ipv6AddressesPerInterface),
+	}
+	// like the VPC CNI, only count the interfaces of the default network card
+	// on instance types with several network cards
+	if defaultCard := info.NetworkInfo.DefaultNetworkCardIndex; defaultCard != nil {
+		for _, card := range info.NetworkInfo.NetworkCards {
+			if card.NetworkCardIndex != nil && *card.NetworkCardIndex == *defaultCard {
+				instanceType.MaxENIs = deref(card.MaximumNetworkInterfaces)
+			}
+		}
+	}
+	if storage := info.InstanceStorageInfo; storage != nil && storage.NvmeSupport != types.EphemeralNvmeSupportUnsupported {
+		for _, disk := range storage.Disks {
+			instanceType.NVMeCount += deref(disk.Count)
+			instanceType.NVMeSizeGB = deref(disk.SizeInGB)
+		}
+	}
+	return instanceType, nil
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
+
+// Format renders instance types in the format of the embedded dataset, sorted
+// by name
+func Format(instanceTypes []InstanceType) string {
+	sort.Slice(instanceTypes, func(i, j int) bool {
+		return instanceTypes[i].Name < instanceTypes[j].Name
+	})
+	var b strings.Builder
+	b.WriteString("# Instance type details that nodeadm uses before falling back to eni-max-pods.txt and the EC2 API.\n")
+	b.WriteString("# instance-type vcpus memory-mib hypervisor max-enis ipv4-per-eni ipv6-per-eni nvme-count nvme-size-gb\n")
+	for _, t := range instanceTypes {
+		hypervisor := t.Hypervisor
+		if hypervisor == "" {
+			hypervisor = "-"
+		}
+		fmt.Fprintf(&b, "%s %d %d %s %d %d %d %d %d\n", t.Name, t.VCpus, t.MemoryMiB, hypervisor, t.MaxENIs, t.IPv4PerENI, t.IPv6PerENI, t.NVMeCount, t.NVMeSizeGB)
+	}
+	return b.String()
+}
+
+func parse(data string) (map[string]InstanceType, error) {
+	result := make(map[string]InstanceType)
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 9 {
+			return nil, fmt.Errorf("line %d: expected 9 fields, got %d", i+1, len(fields))
+		}
+		var numbers [7]int64
+		for j, field := range append(fields[1:3:3], fields[4:]...) {
+			n, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			numbers[j] = n
+		}
+		hypervisor := fields[3]
+		if hypervisor == "-" {
+			hypervisor = ""
+		}
+		result[fields[0]] = InstanceType{
+			Name:       fields[0],
+			VCpus:      int32(numbers[0]),
+			MemoryMiB:  numbers[1],
+			Hypervisor: hypervisor,
+			MaxENIs:    int32(numbers[2]),
+			IPv4PerENI: int32(numbers[3]),
+			IPv6PerENI: int32(numbers[4]),
+			NVMeCount:  int32(numbers[5]),
+			NVMeSizeGB: numbers[6],
+		}
+	}
+	return result, nil
+}
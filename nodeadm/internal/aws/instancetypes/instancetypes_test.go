@@ -0,0 +1,56 @@
+package instancetypes
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRoundTrip(t *testing.T) {
+	var all []InstanceType
+	for _, instanceType := range instanceTypes {
+		all = append(all, instanceType)
+	}
+	assert.NotEmpty(t, all)
+	assert.Equal(t, instanceTypesData, Format(all))
+}
+
+func TestFromInstanceTypeInfo(t *testing.T) {
+	instanceType, err := FromInstanceTypeInfo(types.InstanceTypeInfo{
+		InstanceType: "p4d.24xlarge",
+		Hypervisor:   types.InstanceTypeHypervisorNitro,
+		VCpuInfo:     &types.VCpuInfo{DefaultVCpus: aws.Int32(96)},
+		MemoryInfo:   &types.MemoryInfo{SizeInMiB: aws.Int64(1179648)},
+		NetworkInfo: &types.NetworkInfo{
+			MaximumNetworkInterfaces:  aws.Int32(60),
+			Ipv4AddressesPerInterface: aws.Int32(50),
+			Ipv6AddressesPerInterface: aws.Int32(50),
+			DefaultNetworkCardIndex:   aws.Int32(0),
+			NetworkCards: []types.NetworkCardInfo{
+				{NetworkCardIndex: aws.Int32(0), MaximumNetworkInterfaces: aws.Int32(15)},
+				{NetworkCardIndex: aws.Int32(1), MaximumNetworkInterfaces: aws.Int32(15)},
+			},
+		},
+		InstanceStorageInfo: &types.InstanceStorageInfo{
+			NvmeSupport: types.EphemeralNvmeSupportRequired,
+			Disks:       []types.DiskInfo{{Count: aws.Int32(8), SizeInGB: aws.Int64(1000)}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, InstanceType{
+		Name:       "p4d.24xlarge",
+		VCpus:      96,
+		MemoryMiB:  1179648,
+		Hypervisor: "nitro",
+		MaxENIs:    15,
+		IPv4PerENI: 50,
+		IPv6PerENI: 50,
+		NVMeCount:  8,
+		NVMeSizeGB: 1000,
+	}, instanceType)
+
+	_, err = FromInstanceTypeInfo(types.InstanceTypeInfo{InstanceType: "m5.large"})
+	assert.EqualError(t, err, "incomplete instance type information for type: m5.large")
+}
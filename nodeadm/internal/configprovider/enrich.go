@@ -0,0 +1,49 @@
+package configprovider
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ecr"
+)
+
+// EnrichConfig performs various initializations of the NodeConfig and
+// in-place updates of its status when allowed by the user
+func EnrichConfig(log *zap.Logger, cfg *api.NodeConfig) error {
+	log.Info("Fetching instance details..")
+	imdsClient := imds.New(imds.Options{})
+	awsConfig, err := config.LoadDefaultConfig(context.TODO(), config.WithClientLogMode(aws.LogRetries), config.WithEC2IMDSRegion(func(o *config.UseEC2IMDSRegion) {
+		o.Client = imdsClient
+	}))
+	if err != nil {
+		return err
+	}
+	instanceDetails, err := api.GetInstanceDetails(context.TODO(), cfg.Spec.Kubelet.NodeName, cfg.Spec.FeatureGates, imdsClient, ec2.NewFromConfig(awsConfig))
+	if err != nil {
+		return err
+	}
+	cfg.Status.Instance = *instanceDetails
+	log.Info("Instance details populated", zap.Reflect("details", instanceDetails))
+	nodeName, err := api.ResolveNodeName(context.TODO(), cfg.Spec.Kubelet.NodeName, cfg.Spec.FeatureGates, instanceDetails, imdsClient)
+	if err != nil {
+		return err
+	}
+	cfg.Status.NodeName = nodeName
+	log.Info("Node name resolved", zap.String("nodeName", nodeName), zap.String("strategy", string(api.GetNodeNameStrategy(cfg.Spec.Kubelet.NodeName, cfg.Spec.FeatureGates))))
+	log.Info("Fetching default options...")
+	eksRegistry, err := ecr.GetEKSRegistry(instanceDetails.Region)
+	if err != nil {
+		return err
+	}
+	cfg.Status.Defaults = api.DefaultOptions{
+		SandboxImage: eksRegistry.GetSandboxImage(),
+	}
+	log.Info("Default options populated", zap.Reflect("defaults", cfg.Status.Defaults))
+	return nil
+}
@@ -1,14 +1,20 @@
 package containerd
 
 import (
+	"bytes"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"path"
+	"slices"
 	"strings"
 
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"go.uber.org/zap"
+
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
-	"go.uber.org/zap"
 )
 
 const containerdBaseRuntimeSpecFile = "/etc/containerd/base-runtime-spec.json"
@@ -18,21 +24,200 @@ var defaultBaseRuntimeSpecData string
 
 func writeBaseRuntimeSpec(cfg *api.NodeConfig) error {
 	zap.L().Info("Writing containerd base runtime spec...", zap.String("path", containerdBaseRuntimeSpecFile))
-	baseRuntimeSpecData := defaultBaseRuntimeSpecData
-	if len(cfg.Spec.Containerd.BaseRuntimeSpec) > 0 {
-		var defaultBaseRuntimeSpecMap api.InlineDocument
-		if err := json.Unmarshal([]byte(defaultBaseRuntimeSpecData), &defaultBaseRuntimeSpecMap); err != nil {
-			return fmt.Errorf("failed to unmarshal default base runtime spec: %v", err)
+	baseRuntimeSpecData, err := generateBaseRuntimeSpec(cfg)
+	if err != nil {
+		return err
+	}
+	if err := util.WriteFileWithDir(containerdBaseRuntimeSpecFile, baseRuntimeSpecData, containerdConfigPerm); err != nil {
+		return err
+	}
+	return writeRuntimeBaseRuntimeSpecs(cfg, baseRuntimeSpecData)
+}
+
+// generateBaseRuntimeSpec returns the default base runtime spec with the
+// presets applied and the user's base runtime spec merged into it
+func generateBaseRuntimeSpec(cfg *api.NodeConfig) ([]byte, error) {
+	baseRuntimeSpecData := []byte(defaultBaseRuntimeSpecData)
+	presets := cfg.Spec.Containerd.BaseRuntimeSpecPresets
+	if len(presets) > 0 || len(cfg.Spec.Containerd.BaseRuntimeSpec) > 0 {
+		if len(presets) > 0 {
+			var spec specs.Spec
+			if err := json.Unmarshal(baseRuntimeSpecData, &spec); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal default base runtime spec: %v", err)
+			}
+			for _, preset := range presets {
+				applyBaseRuntimeSpecPreset(&spec, preset)
+			}
+			presetData, err := json.Marshal(spec)
+			if err != nil {
+				return nil, err
+			}
+			baseRuntimeSpecData = presetData
+		}
+		var baseRuntimeSpecMap api.InlineDocument
+		if err := json.Unmarshal(baseRuntimeSpecData, &baseRuntimeSpecMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal default base runtime spec: %v", err)
 		}
-		mergedBaseRuntimeSpecMap, err := util.Merge(defaultBaseRuntimeSpecMap, cfg.Spec.Containerd.BaseRuntimeSpec, json.Marshal, json.Unmarshal)
+		mergedBaseRuntimeSpecMap, err := util.Merge(baseRuntimeSpecMap, cfg.Spec.Containerd.BaseRuntimeSpec, json.Marshal, unmarshalJSONNumbers)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		mergedBaseRuntimeSpecData, err := json.MarshalIndent(mergedBaseRuntimeSpecMap, "", strings.Repeat(" ", 4))
+		baseRuntimeSpecData, err = json.MarshalIndent(mergedBaseRuntimeSpecMap, "", strings.Repeat(" ", 4))
 		if err != nil {
-			return err
+			return nil, err
+		}
+	}
+	if err := validateBaseRuntimeSpec(baseRuntimeSpecData); err != nil {
+		return nil, fmt.Errorf("invalid base runtime spec: %w", err)
+	}
+	return baseRuntimeSpecData, nil
+}
+
+// unmarshalJSONNumbers keeps numbers exact when merging specs, because rlimits
+// such as RLIM_INFINITY do not fit into a float64
+func unmarshalJSONNumbers(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func applyBaseRuntimeSpecPreset(spec *specs.Spec, preset api.BaseRuntimeSpecPreset) {
+	if spec.Process == nil {
+		spec.Process = &specs.Process{}
+	}
+	switch preset {
+	case api.BaseRuntimeSpecPresetRaisedNoFile:
+		setRlimit(spec.Process, "RLIMIT_NOFILE", 1048576, 1048576)
+	case api.BaseRuntimeSpecPresetUnlimitedMemlock:
+		// RLIM_INFINITY
+		setRlimit(spec.Process, "RLIMIT_MEMLOCK", ^uint64(0), ^uint64(0))
+	case api.BaseRuntimeSpecPresetDropNetRaw:
+		dropCapability(spec.Process, "CAP_NET_RAW")
+	}
+}
+
+func setRlimit(process *specs.Process, rlimitType string, soft, hard uint64) {
+	for i := range process.Rlimits {
+		if process.Rlimits[i].Type == rlimitType {
+			process.Rlimits[i].Soft = soft
+			process.Rlimits[i].Hard = hard
+			return
+		}
+	}
+	process.Rlimits = append(process.Rlimits, specs.POSIXRlimit{Type: rlimitType, Soft: soft, Hard: hard})
+}
+
+func dropCapability(process *specs.Process, capability string) {
+	if process.Capabilities == nil {
+		return
+	}
+	for _, set := range capabilitySets(process.Capabilities) {
+		*set.capabilities = slices.DeleteFunc(*set.capabilities, func(c string) bool { return c == capability })
+	}
+}
+
+type capabilitySet struct {
+	name         string
+	capabilities *[]string
+}
+
+func capabilitySets(capabilities *specs.LinuxCapabilities) []capabilitySet {
+	return []capabilitySet{
+		{name: "bounding", capabilities: &capabilities.Bounding},
+		{name: "effective", capabilities: &capabilities.Effective},
+		{name: "inheritable", capabilities: &capabilities.Inheritable},
+		{name: "permitted", capabilities: &capabilities.Permitted},
+		{name: "ambient", capabilities: &capabilities.Ambient},
+	}
+}
+
+var (
+	linuxRlimits = []string{
+		"RLIMIT_AS", "RLIMIT_CORE", "RLIMIT_CPU", "RLIMIT_DATA", "RLIMIT_FSIZE", "RLIMIT_LOCKS",
+		"RLIMIT_MEMLOCK", "RLIMIT_MSGQUEUE", "RLIMIT_NICE", "RLIMIT_NOFILE", "RLIMIT_NPROC", "RLIMIT_RSS",
+		"RLIMIT_RTPRIO", "RLIMIT_RTTIME", "RLIMIT_SIGPENDING", "RLIMIT_STACK",
+	}
+
+	linuxCapabilities = []string{
+		"CAP_CHOWN", "CAP_DAC_OVERRIDE", "CAP_DAC_READ_SEARCH", "CAP_FOWNER", "CAP_FSETID", "CAP_KILL",
+		"CAP_SETGID", "CAP_SETUID", "CAP_SETPCAP", "CAP_LINUX_IMMUTABLE", "CAP_NET_BIND_SERVICE",
+		"CAP_NET_BROADCAST", "CAP_NET_ADMIN", "CAP_NET_RAW", "CAP_IPC_LOCK", "CAP_IPC_OWNER", "CAP_SYS_MODULE",
+		"CAP_SYS_RAWIO", "CAP_SYS_CHROOT", "CAP_SYS_PTRACE", "CAP_SYS_PACCT", "CAP_SYS_ADMIN", "CAP_SYS_BOOT",
+		"CAP_SYS_NICE", "CAP_SYS_RESOURCE", "CAP_SYS_TIME", "CAP_SYS_TTY_CONFIG", "CAP_MKNOD", "CAP_LEASE",
+		"CAP_AUDIT_WRITE", "CAP_AUDIT_CONTROL", "CAP_SETFCAP", "CAP_MAC_OVERRIDE", "CAP_MAC_ADMIN", "CAP_SYSLOG",
+		"CAP_WAKE_ALARM", "CAP_BLOCK_SUSPEND", "CAP_AUDIT_READ", "CAP_PERFMON", "CAP_BPF", "CAP_CHECKPOINT_RESTORE",
+	}
+
+	// mountFlags are the mount options that runc understands as flags, and
+	// the options without a value of the filesystems that containers mount.
+	// Any other option must be a key=value option of the filesystem.
+	mountFlags = []string{
+		"acl", "async", "atime", "bind", "defaults", "dev", "diratime", "dirsync", "exec", "iversion",
+		"lazytime", "loud", "mand", "noacl", "noatime", "nodev", "nodiratime", "noexec", "noiversion",
+		"nolazytime", "nomand", "norelatime", "nostrictatime", "nosuid", "nosymfollow", "rbind", "relatime",
+		"remount", "ro", "rw", "silent", "strictatime", "suid", "sync", "symfollow",
+		"private", "rprivate", "shared", "rshared", "slave", "rslave", "unbindable", "runbindable",
+		"rro", "rrw", "rnosuid", "rsuid", "rnodev", "rdev", "rnoexec", "rexec", "rnodiratime", "rdiratime",
+		"rrelatime", "rnorelatime", "rnoatime", "ratime", "rstrictatime", "rnostrictatime", "rnosymfollow",
+		"rsymfollow", "tmpcopyup", "idmap", "ridmap",
+		"newinstance", "noswap", "usrquota", "grpquota",
+	}
+
+	linuxNamespaces = []specs.LinuxNamespaceType{
+		specs.PIDNamespace, specs.NetworkNamespace, specs.MountNamespace, specs.IPCNamespace,
+		specs.UTSNamespace, specs.UserNamespace, specs.CgroupNamespace, specs.TimeNamespace,
+	}
+)
+
+// validateBaseRuntimeSpec decodes a base runtime spec into the types of the
+// OCI runtime spec, rejecting unknown fields, and validates the values that
+// runc would otherwise only reject when it creates a container
+func validateBaseRuntimeSpec(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var spec specs.Spec
+	if err := decoder.Decode(&spec); err != nil {
+		return err
+	}
+	var errs []error
+	if spec.Process != nil {
+		for _, rlimit := range spec.Process.Rlimits {
+			if !slices.Contains(linuxRlimits, rlimit.Type) {
+				errs = append(errs, fmt.Errorf("unknown rlimit %q", rlimit.Type))
+			} else if rlimit.Soft > rlimit.Hard {
+				errs = append(errs, fmt.Errorf("soft limit of rlimit %s exceeds its hard limit", rlimit.Type))
+			}
+		}
+		if spec.Process.Capabilities != nil {
+			for _, set := range capabilitySets(spec.Process.Capabilities) {
+				for _, capability := range *set.capabilities {
+					if !slices.Contains(linuxCapabilities, capability) {
+						errs = append(errs, fmt.Errorf("unknown capability %q in %s capabilities", capability, set.name))
+					}
+				}
+			}
+		}
+	}
+	for _, mount := range spec.Mounts {
+		for _, option := range mount.Options {
+			if !strings.Contains(option, "=") && !slices.Contains(mountFlags, option) {
+				errs = append(errs, fmt.Errorf("unknown option %q of mount %s", option, mount.Destination))
+			}
+		}
+	}
+	if spec.Linux != nil {
+		seen := map[specs.LinuxNamespaceType]bool{}
+		for _, namespace := range spec.Linux.Namespaces {
+			if !slices.Contains(linuxNamespaces, namespace.Type) {
+				errs = append(errs, fmt.Errorf("unknown namespace %q", namespace.Type))
+			} else if seen[namespace.Type] {
+				errs = append(errs, fmt.Errorf("duplicate namespace %s", namespace.Type))
+			}
+			seen[namespace.Type] = true
+			if namespace.Path != "" && !path.IsAbs(namespace.Path) {
+				errs = append(errs, fmt.Errorf("path of namespace %s must be absolute: %q", namespace.Type, namespace.Path))
+			}
 		}
-		baseRuntimeSpecData = string(mergedBaseRuntimeSpecData)
 	}
-	return util.WriteFileWithDir(containerdBaseRuntimeSpecFile, []byte(baseRuntimeSpecData), containerdConfigPerm)
+	return errors.Join(errs...)
 }
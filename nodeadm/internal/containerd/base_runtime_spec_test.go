@@ -0,0 +1,125 @@
+package containerd
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestGenerateBaseRuntimeSpec(t *testing.T) {
+	var tests = []struct {
+		name            string
+		options         api.ContainerdOptions
+		expectedRlimits []specs.POSIXRlimit
+		expectedNetRaw  bool
+		expectedErr     string
+	}{
+		{
+			name:            "default",
+			expectedRlimits: []specs.POSIXRlimit{{Type: "RLIMIT_NOFILE", Soft: 65536, Hard: 1048576}},
+			expectedNetRaw:  true,
+		},
+		{
+			name: "presets",
+			options: api.ContainerdOptions{
+				BaseRuntimeSpecPresets: []api.BaseRuntimeSpecPreset{
+					api.BaseRuntimeSpecPresetRaisedNoFile,
+					api.BaseRuntimeSpecPresetUnlimitedMemlock,
+					api.BaseRuntimeSpecPresetDropNetRaw,
+				},
+			},
+			expectedRlimits: []specs.POSIXRlimit{
+				{Type: "RLIMIT_NOFILE", Soft: 1048576, Hard: 1048576},
+				{Type: "RLIMIT_MEMLOCK", Soft: ^uint64(0), Hard: ^uint64(0)},
+			},
+		},
+		{
+			name: "user spec overrides presets",
+			options: api.ContainerdOptions{
+				BaseRuntimeSpecPresets: []api.BaseRuntimeSpecPreset{api.BaseRuntimeSpecPresetUnlimitedMemlock},
+				BaseRuntimeSpec: api.InlineDocument{
+					"process": runtime.RawExtension{Raw: []byte(`{"rlimits": [{"type": "RLIMIT_NOFILE", "soft": 1024, "hard": 1024}]}`)},
+				},
+			},
+			expectedRlimits: []specs.POSIXRlimit{{Type: "RLIMIT_NOFILE", Soft: 1024, Hard: 1024}},
+			expectedNetRaw:  true,
+		},
+		{
+			name: "unknown field",
+			options: api.ContainerdOptions{
+				BaseRuntimeSpec: api.InlineDocument{"foo": runtime.RawExtension{Raw: []byte(`"bar"`)}},
+			},
+			expectedErr: `invalid base runtime spec: json: unknown field "foo"`,
+		},
+		{
+			name: "unknown rlimit",
+			options: api.ContainerdOptions{
+				BaseRuntimeSpec: api.InlineDocument{
+					"process": runtime.RawExtension{Raw: []byte(`{"rlimits": [{"type": "RLIMIT_NFILE", "soft": 1024, "hard": 1024}]}`)},
+				},
+			},
+			expectedErr: `invalid base runtime spec: unknown rlimit "RLIMIT_NFILE"`,
+		},
+		{
+			name: "soft limit above hard limit",
+			options: api.ContainerdOptions{
+				BaseRuntimeSpec: api.InlineDocument{
+					"process": runtime.RawExtension{Raw: []byte(`{"rlimits": [{"type": "RLIMIT_NOFILE", "soft": 2048, "hard": 1024}]}`)},
+				},
+			},
+			expectedErr: "invalid base runtime spec: soft limit of rlimit RLIMIT_NOFILE exceeds its hard limit",
+		},
+		{
+			name: "unknown capability",
+			options: api.ContainerdOptions{
+				BaseRuntimeSpec: api.InlineDocument{
+					"process": runtime.RawExtension{Raw: []byte(`{"capabilities": {"bounding": ["CAP_CHOWN", "NET_ADMIN"]}}`)},
+				},
+			},
+			expectedErr: `invalid base runtime spec: unknown capability "NET_ADMIN" in bounding capabilities`,
+		},
+		{
+			name: "unknown mount option",
+			options: api.ContainerdOptions{
+				BaseRuntimeSpec: api.InlineDocument{
+					"mounts": runtime.RawExtension{Raw: []byte(`[{"destination": "/proc", "type": "proc", "source": "proc", "options": ["nosuid", "size=1m", "nosetuid"]}]`)},
+				},
+			},
+			expectedErr: `invalid base runtime spec: unknown option "nosetuid" of mount /proc`,
+		},
+		{
+			name: "invalid namespaces",
+			options: api.ContainerdOptions{
+				BaseRuntimeSpec: api.InlineDocument{
+					"linux": runtime.RawExtension{Raw: []byte(`{"namespaces": [{"type": "pid"}, {"type": "pid"}, {"type": "net"}, {"type": "ipc", "path": "ipc"}]}`)},
+				},
+			},
+			expectedErr: "invalid base runtime spec: duplicate namespace pid\n" +
+				"unknown namespace \"net\"\n" +
+				"path of namespace ipc must be absolute: \"ipc\"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{Spec: api.NodeConfigSpec{Containerd: test.options}}
+			data, err := generateBaseRuntimeSpec(&cfg)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			var spec specs.Spec
+			assert.NoError(t, json.Unmarshal(data, &spec))
+			assert.Equal(t, test.expectedRlimits, spec.Process.Rlimits)
+			assert.Equal(t, test.expectedNetRaw, slices.Contains(spec.Process.Capabilities.Bounding, "CAP_NET_RAW"))
+			assert.Equal(t, test.expectedNetRaw, slices.Contains(spec.Process.Capabilities.Permitted, "CAP_NET_RAW"))
+		})
+	}
+}
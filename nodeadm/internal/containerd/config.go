@@ -22,6 +22,11 @@ var (
 	//go:embed config.template.toml
 	containerdConfigTemplateData string
 	containerdConfigTemplate     = template.Must(template.New(containerdConfigFile).Parse(containerdConfigTemplateData))
+
+	// containerd 2.0 splits the CRI plugin into the images and runtime plugins
+	//go:embed config-v3.template.toml
+	containerdConfigV3TemplateData string
+	containerdConfigV3Template     = template.Must(template.New(containerdConfigFile).Parse(containerdConfigV3TemplateData))
 )
 
 type containerdTemplateVars struct {
@@ -33,7 +38,11 @@ func writeContainerdConfig(cfg *api.NodeConfig) error {
 		return err
 	}
 
-	containerdConfig, err := generateContainerdConfig(cfg)
+	containerdVersion, err := GetContainerdVersion()
+	if err != nil {
+		return err
+	}
+	containerdConfig, err := generateContainerdConfig(cfg, containerdVersion)
 	if err != nil {
 		return err
 	}
@@ -41,7 +50,11 @@ func writeContainerdConfig(cfg *api.NodeConfig) error {
 	// overwrite entire sections, we want to implement this merging ourselves.
 	// see: https://github.com/containerd/containerd/blob/a91b05d99ceac46329be06eb43f7ae10b89aad45/cmd/containerd/server/config/config.go#L407-L431
 	if len(cfg.Spec.Containerd.Config) > 0 {
-		containerdConfigMap, err := util.Merge(containerdConfig, []byte(cfg.Spec.Containerd.Config), toml.Marshal, toml.Unmarshal)
+		userConfig, err := translateUserConfig([]byte(cfg.Spec.Containerd.Config), containerdVersion)
+		if err != nil {
+			return err
+		}
+		containerdConfigMap, err := util.Merge(containerdConfig, userConfig, toml.Marshal, toml.Unmarshal)
 		if err != nil {
 			return err
 		}
@@ -50,18 +63,37 @@ func writeContainerdConfig(cfg *api.NodeConfig) error {
 			return err
 		}
 	}
+	containerdConfig, err = applySnapshotterConfig(containerdConfig, cfg, containerdVersion)
+	if err != nil {
+		return err
+	}
 
 	zap.L().Info("Writing containerd config to file..", zap.String("path", containerdConfigFile))
 	return util.WriteFileWithDir(containerdConfigFile, containerdConfig, containerdConfigPerm)
 }
 
-func generateContainerdConfig(cfg *api.NodeConfig) ([]byte, error) {
+func generateContainerdConfig(cfg *api.NodeConfig, containerdVersion string) ([]byte, error) {
 	configVars := containerdTemplateVars{
 		SandboxImage: cfg.Status.Defaults.SandboxImage,
 	}
+	configTemplate := containerdConfigTemplate
+	if usesConfigVersion3(containerdVersion) {
+		configTemplate = containerdConfigV3Template
+	}
 	var buf bytes.Buffer
-	if err := containerdConfigTemplate.Execute(&buf, configVars); err != nil {
+	if err := configTemplate.Execute(&buf, configVars); err != nil {
+		return nil, err
+	}
+	if len(cfg.Spec.Containerd.Runtimes) == 0 {
+		return buf.Bytes(), nil
+	}
+	runtimesConfig, err := generateRuntimesConfig(cfg, containerdVersion)
+	if err != nil {
+		return nil, err
+	}
+	containerdConfigMap, err := util.Merge(buf.Bytes(), runtimesConfig, toml.Marshal, toml.Unmarshal)
+	if err != nil {
 		return nil, err
 	}
-	return buf.Bytes(), nil
+	return toml.Marshal(containerdConfigMap)
 }
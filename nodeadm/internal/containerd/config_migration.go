@@ -0,0 +1,167 @@
+package containerd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	criPluginV2          = "io.containerd.grpc.v1.cri"
+	criImagesPluginV3    = "io.containerd.cri.v1.images"
+	criRuntimePluginV3   = "io.containerd.cri.v1.runtime"
+	criContainerdSection = "containerd"
+)
+
+// the keys of the version 2 CRI plugin that moved to the images plugin
+var criImagesKeys = []string{
+	"registry",
+	"image_decryption",
+	"max_concurrent_downloads",
+	"image_pull_progress_timeout",
+	"image_pull_with_sync_fs",
+	"stats_collect_period",
+}
+
+// the keys of the version 2 containerd section that moved to the images plugin
+var criContainerdImagesKeys = []string{
+	"snapshotter",
+	"disable_snapshot_annotations",
+	"discard_unpacked_layers",
+}
+
+// the keys of the version 2 CRI plugin that stay in it, as they configure the
+// CRI server itself
+var criServerKeys = []string{
+	"disable_tcp_service",
+	"stream_server_address",
+	"stream_server_port",
+	"stream_idle_timeout",
+	"enable_tls_streaming",
+	"x509_key_pair_streaming",
+}
+
+// settings that were removed in containerd 2.0, and cannot be translated
+var removedConfigKeys = []struct {
+	path        []string
+	alternative string
+}{
+	{path: []string{"plugins", criPluginV2, "systemd_cgroup"}, alternative: "use SystemdCgroup in the runc runtime options"},
+	{path: []string{"plugins", criPluginV2, "registry", "auths"}, alternative: "use a kubelet image credential provider"},
+	{path: []string{"plugins", criPluginV2, criContainerdSection, "default_runtime"}, alternative: "use default_runtime_name"},
+	{path: []string{"plugins", criPluginV2, criContainerdSection, "untrusted_workload_runtime"}, alternative: "use a runtime handler"},
+	{path: []string{"plugins", "io.containerd.runtime.v1.linux"}, alternative: "use the io.containerd.runc.v2 runtime"},
+}
+
+// translateUserConfig converts the containerd config in the NodeConfig into
+// the layout of the config that nodeadm generates for the containerd version.
+// A config without a version follows the layout of version 2, which nodeadm
+// generated for every containerd version before 2.0.
+func translateUserConfig(userConfig []byte, containerdVersion string) ([]byte, error) {
+	var config map[string]interface{}
+	if err := toml.Unmarshal(userConfig, &config); err != nil {
+		return nil, err
+	}
+	configVersion := int64(2)
+	if version, ok := config["version"].(int64); ok {
+		configVersion = version
+	}
+	if !usesConfigVersion3(containerdVersion) {
+		if configVersion >= 3 {
+			return nil, fmt.Errorf("containerd config version %d requires containerd 2.0 or later, found %s", configVersion, containerdVersion)
+		}
+		return userConfig, nil
+	}
+	if configVersion >= 3 {
+		return userConfig, nil
+	}
+	if err := migrateConfigToVersion3(config); err != nil {
+		return nil, err
+	}
+	return toml.Marshal(config)
+}
+
+// migrateConfigToVersion3 moves the settings of the version 2 CRI plugin into
+// the images and runtime plugins that replace it in version 3, the same way
+// that containerd 2.0 migrates a version 2 config when it loads it.
+func migrateConfigToVersion3(config map[string]interface{}) error {
+	for _, removed := range removedConfigKeys {
+		if hasConfigKey(config, removed.path) {
+			return fmt.Errorf("containerd config key %q was removed in containerd 2.0, %s", strings.Join(removed.path, "."), removed.alternative)
+		}
+	}
+	config["version"] = int64(3)
+	plugins := subTable(config, "plugins")
+	if plugins == nil {
+		return nil
+	}
+	cri := subTable(plugins, criPluginV2)
+	if cri == nil {
+		return nil
+	}
+
+	images := map[string]interface{}{}
+	runtime := map[string]interface{}{}
+	server := map[string]interface{}{}
+	if sandboxImage, ok := cri["sandbox_image"]; ok {
+		images["pinned_images"] = map[string]interface{}{"sandbox": sandboxImage}
+	}
+	for key, value := range cri {
+		switch {
+		case key == "sandbox_image":
+		case key == criContainerdSection:
+			containerd, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("containerd config key plugins.%q.%s must be a table", criPluginV2, key)
+			}
+			runtimeContainerd := map[string]interface{}{}
+			for containerdKey, containerdValue := range containerd {
+				if slices.Contains(criContainerdImagesKeys, containerdKey) {
+					images[containerdKey] = containerdValue
+				} else {
+					runtimeContainerd[containerdKey] = containerdValue
+				}
+			}
+			if len(runtimeContainerd) > 0 {
+				runtime[criContainerdSection] = runtimeContainerd
+			}
+		case slices.Contains(criImagesKeys, key):
+			images[key] = value
+		case slices.Contains(criServerKeys, key):
+			server[key] = value
+		default:
+			runtime[key] = value
+		}
+	}
+
+	delete(plugins, criPluginV2)
+	for name, table := range map[string]map[string]interface{}{
+		criImagesPluginV3:  images,
+		criRuntimePluginV3: runtime,
+		criPluginV2:        server,
+	} {
+		if len(table) > 0 {
+			plugins[name] = table
+		}
+	}
+	return nil
+}
+
+// hasConfigKey returns whether the key at the path of tables is set
+func hasConfigKey(config map[string]interface{}, path []string) bool {
+	table := config
+	for _, key := range path[:len(path)-1] {
+		if table = subTable(table, key); table == nil {
+			return false
+		}
+	}
+	_, ok := table[path[len(path)-1]]
+	return ok
+}
+
+func subTable(table map[string]interface{}, key string) map[string]interface{} {
+	value, _ := table[key].(map[string]interface{})
+	return value
+}
@@ -0,0 +1,161 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestParseContainerdVersion(t *testing.T) {
+	var tests = []struct {
+		rawVersion      string
+		expectedVersion string
+	}{
+		{rawVersion: "containerd github.com/containerd/containerd v1.7.11 64b8a811b07ba6288238eefc14d898ee0b5b99ba\n", expectedVersion: "v1.7.11"},
+		{rawVersion: "containerd github.com/containerd/containerd 1.7.20 8fc6bcff51318944179630522a095cc9dbf9f353\n", expectedVersion: "v1.7.20"},
+		{rawVersion: "containerd github.com/containerd/containerd/v2 v2.0.0 207ad711eabd375a01713109a8a197d197ff6542\n", expectedVersion: "v2.0.0"},
+		{rawVersion: "containerd github.com/containerd/containerd/v2 v2.1.0-rc.0 \n", expectedVersion: "v2.1.0"},
+		{rawVersion: "containerd github.com/containerd/containerd/v2 dev\n", expectedVersion: ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expectedVersion, parseContainerdVersion(test.rawVersion))
+	}
+}
+
+func TestGenerateContainerdConfig(t *testing.T) {
+	cfg := api.NodeConfig{
+		Status: api.NodeConfigStatus{
+			Defaults: api.DefaultOptions{SandboxImage: "registry.k8s.io/pause:3.10"},
+		},
+	}
+	for _, containerdVersion := range []string{"v1.7.20", "v2.0.0"} {
+		config, err := generateContainerdConfig(&cfg, containerdVersion)
+		assert.NoError(t, err)
+		sandboxImage, err := findSandboxImage(config)
+		assert.NoError(t, err, containerdVersion)
+		assert.Equal(t, "registry.k8s.io/pause:3.10", sandboxImage, containerdVersion)
+	}
+}
+
+func TestTranslateUserConfig(t *testing.T) {
+	var tests = []struct {
+		name              string
+		containerdVersion string
+		userConfig        string
+		expectedConfig    string
+		expectedErr       bool
+	}{
+		{
+			name:              "version 2 config on containerd 1.7",
+			containerdVersion: "v1.7.20",
+			userConfig: `
+[plugins."io.containerd.grpc.v1.cri"]
+sandbox_image = "registry.k8s.io/pause:3.10"
+`,
+			expectedConfig: `
+[plugins."io.containerd.grpc.v1.cri"]
+sandbox_image = "registry.k8s.io/pause:3.10"
+`,
+		},
+		{
+			name:              "version 3 config on containerd 1.7",
+			containerdVersion: "v1.7.20",
+			userConfig:        "version = 3\n",
+			expectedErr:       true,
+		},
+		{
+			name:              "version 3 config on containerd 2.0",
+			containerdVersion: "v2.0.0",
+			userConfig: `
+version = 3
+
+[plugins."io.containerd.cri.v1.images"]
+discard_unpacked_layers = false
+`,
+			expectedConfig: `
+version = 3
+
+[plugins."io.containerd.cri.v1.images"]
+discard_unpacked_layers = false
+`,
+		},
+		{
+			name:              "version 2 config on containerd 2.0",
+			containerdVersion: "v2.0.0",
+			userConfig: `
+version = 2
+
+[grpc]
+address = "/run/foo/foo.sock"
+
+[plugins."io.containerd.grpc.v1.cri"]
+sandbox_image = "registry.k8s.io/pause:3.10"
+enable_cdi = true
+stream_idle_timeout = "1h"
+
+[plugins."io.containerd.grpc.v1.cri".registry]
+config_path = "/etc/containerd/certs.d"
+
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = false
+snapshotter = "soci"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+runtime_type = "io.containerd.runc.v2"
+`,
+			expectedConfig: `
+version = 3
+
+[grpc]
+address = "/run/foo/foo.sock"
+
+[plugins."io.containerd.grpc.v1.cri"]
+stream_idle_timeout = "1h"
+
+[plugins."io.containerd.cri.v1.images"]
+discard_unpacked_layers = false
+snapshotter = "soci"
+
+[plugins."io.containerd.cri.v1.images".pinned_images]
+sandbox = "registry.k8s.io/pause:3.10"
+
+[plugins."io.containerd.cri.v1.images".registry]
+config_path = "/etc/containerd/certs.d"
+
+[plugins."io.containerd.cri.v1.runtime"]
+enable_cdi = true
+
+[plugins."io.containerd.cri.v1.runtime".containerd.runtimes.nvidia]
+runtime_type = "io.containerd.runc.v2"
+`,
+		},
+		{
+			name:              "removed config on containerd 2.0",
+			containerdVersion: "v2.0.0",
+			userConfig: `
+[plugins."io.containerd.grpc.v1.cri"]
+systemd_cgroup = true
+`,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := translateUserConfig([]byte(test.userConfig), test.containerdVersion)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var actual, expected map[string]interface{}
+			assert.NoError(t, toml.Unmarshal(config, &actual))
+			assert.NoError(t, toml.Unmarshal([]byte(test.expectedConfig), &expected))
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
@@ -3,11 +3,19 @@ package containerd
 import (
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/snapshotter"
 )
 
 const ContainerdDaemonName = "containerd"
 
 var _ daemon.Daemon = &containerd{}
+var _ daemon.DependentDaemon = &containerd{}
+
+// ProcessSpec runs containerd the same way as its systemd unit, for daemon
+// managers that supervise child processes.
+var ProcessSpec = daemon.ProcessSpec{
+	Command: []string{"/usr/bin/containerd"},
+}
 
 type containerd struct {
 	daemonManager daemon.DaemonManager
@@ -20,15 +28,40 @@ func NewContainerdDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
 }
 
 func (cd *containerd) Configure(c *api.NodeConfig) error {
-	return writeContainerdConfig(c)
+	if err := ensureRuntimeBinariesExist(c); err != nil {
+		return err
+	}
+	if err := writeContainerdConfig(c); err != nil {
+		return err
+	}
+	if err := writeRegistryHosts(c); err != nil {
+		return err
+	}
+	return daemon.ConfigureSystemdOptions(cd.daemonManager, ContainerdDaemonName, c.Spec.Containerd.Systemd)
 }
 
 func (cd *containerd) EnsureRunning() error {
 	return cd.daemonManager.StartDaemon(ContainerdDaemonName)
 }
 
+func (cd *containerd) WaitUntilReady(c *api.NodeConfig) error {
+	if err := waitForRuntimeConditions(c); err != nil {
+		return daemon.ErrorWithStatus(err, cd.daemonManager, ContainerdDaemonName)
+	}
+	return nil
+}
+
 func (cd *containerd) PostLaunch(c *api.NodeConfig) error {
-	return cacheSandboxImage(c)
+	if err := cacheSandboxImage(c); err != nil {
+		return err
+	}
+	return preloadImages(c)
+}
+
+// Dependencies orders containerd after the proxy snapshotter, which containerd
+// only connects to at startup
+func (cd *containerd) Dependencies() []string {
+	return []string{snapshotter.SnapshotterDaemonName}
 }
 
 func (cd *containerd) Name() string {
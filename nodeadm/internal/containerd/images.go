@@ -0,0 +1,287 @@
+package containerd
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	v1 "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/credentialprovider"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	// criNamespace is the containerd namespace of the images used by kubelet
+	criNamespace = "k8s.io"
+	// pinnedImageLabel keeps an image from being removed by the kubelet image
+	// garbage collection, as the CRI reports it as pinned
+	pinnedImageLabel = "io.cri-containerd.pinned=pinned"
+
+	defaultPreloadImagesParallelism = 4
+	defaultPreloadImagesTimeout     = 10 * time.Minute
+	preloadImagePullAttempts        = 3
+)
+
+var preloadImagePullBackoff = 2 * time.Second
+
+// newImageClient connects to the CRI image service of containerd
+var newImageClient = func(ctx context.Context) (v1.ImageServiceClient, func() error, error) {
+	conn, err := grpc.DialContext(ctx, ContainerRuntimeEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return v1.NewImageServiceClient(conn), conn.Close, nil
+}
+
+// pullImage pulls an image through the CRI with the credentials that kubelet
+// would use for it, so that it can be in any registry
+func pullImage(ctx context.Context, client v1.ImageServiceClient, cfg *api.NodeConfig, image string, attempts int, backoff time.Duration) (*v1.ImageSpec, error) {
+	var authConfig *v1.AuthConfig
+	credentials, err := credentialprovider.GetCredentials(ctx, cfg.Spec.Kubelet.ImageCredentialProviders, image)
+	if err != nil {
+		zap.L().Warn("Failed to get credentials for image, pulling anonymously..", zap.String("image", image), zap.Error(err))
+	} else if credentials == nil {
+		zap.L().Info("No image credential provider matches the image, pulling anonymously..", zap.String("image", image))
+	} else {
+		authConfig = &v1.AuthConfig{Username: credentials.Username, Password: credentials.Password}
+	}
+
+	imageSpec := &v1.ImageSpec{Image: image}
+	return imageSpec, util.RetryExponentialBackoffWithContext(ctx, attempts, backoff, func(ctx context.Context) error {
+		zap.L().Info("Pulling image..", zap.String("image", image))
+		response, err := client.PullImage(ctx, &v1.PullImageRequest{Image: imageSpec, Auth: authConfig})
+		if err != nil {
+			zap.L().Warn("Failed to pull image", zap.String("image", image), zap.Error(err))
+			return err
+		}
+		zap.L().Info("Finished pulling image", zap.String("image", image), zap.String("image-ref", response.ImageRef))
+		return nil
+	})
+}
+
+// preloadImages imports the image archives, and then pulls the images with
+// bounded parallelism
+func preloadImages(cfg *api.NodeConfig) error {
+	options := cfg.Spec.Containerd.PreloadImages
+	if len(options.Images) == 0 && len(options.Archives) == 0 {
+		return nil
+	}
+	timeout := defaultPreloadImagesTimeout
+	if options.Timeout != nil {
+		timeout = options.Timeout.Duration
+	}
+	parallelism := defaultPreloadImagesParallelism
+	if options.Parallelism > 0 {
+		parallelism = int(options.Parallelism)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for _, archive := range options.Archives {
+		if err := importImageArchive(ctx, archive); err != nil {
+			return err
+		}
+	}
+	if len(options.Images) == 0 {
+		return nil
+	}
+
+	client, closeClient, err := newImageClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		errs     []error
+		inFlight = make(chan struct{}, parallelism)
+	)
+	for _, image := range options.Images {
+		image := image
+		wg.Add(1)
+		inFlight <- struct{}{}
+		go func() {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+			err := preloadImage(ctx, client, cfg, image)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("failed to preload image %s: %w", image.Image, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func preloadImage(ctx context.Context, client v1.ImageServiceClient, cfg *api.NodeConfig, image api.PreloadImage) error {
+	imageSpec, err := pullImage(ctx, client, cfg, image.Image, preloadImagePullAttempts, preloadImagePullBackoff)
+	if err != nil || !image.Pinned {
+		return err
+	}
+	// the image is labeled under every name that containerd stores it by
+	status, err := client.ImageStatus(ctx, &v1.ImageStatusRequest{Image: imageSpec})
+	if err != nil {
+		return err
+	}
+	if status.Image == nil {
+		return fmt.Errorf("image was not found after it was pulled")
+	}
+	names := append(append([]string{status.Image.Id}, status.Image.RepoTags...), status.Image.RepoDigests...)
+	return pinImages(ctx, names)
+}
+
+func importImageArchive(ctx context.Context, archive api.PreloadImageArchive) error {
+	names, err := readArchiveImageNames(archive.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read image archive %s: %w", archive.Path, err)
+	}
+	zap.L().Info("Importing image archive..", zap.String("path", archive.Path))
+	if _, err := runCtr(ctx, "images", "import", archive.Path); err != nil {
+		return fmt.Errorf("failed to import image archive %s: %w", archive.Path, err)
+	}
+	output, err := runCtr(ctx, "images", "list", "--quiet")
+	if err != nil {
+		return fmt.Errorf("failed to list images: %w", err)
+	}
+	existing := strings.Fields(output)
+	var imported []string
+	for _, name := range names {
+		if slices.Contains(existing, name) {
+			imported = append(imported, name)
+		} else {
+			zap.L().Warn("Image from archive was not found after the import", zap.String("path", archive.Path), zap.String("image", name))
+		}
+	}
+	if len(imported) == 0 {
+		return fmt.Errorf("image archive %s did not yield any named image", archive.Path)
+	}
+	zap.L().Info("Imported image archive", zap.String("path", archive.Path), zap.Strings("images", imported))
+	if !archive.Pinned {
+		return nil
+	}
+	return pinImages(ctx, imported)
+}
+
+// readArchiveImageNames returns the names that containerd gives the images of
+// a Docker or OCI image archive, which may be compressed with gzip
+func readArchiveImageNames(archivePath string) ([]string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = bufio.NewReader(f)
+	if magic, err := r.(*bufio.Reader).Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		if r, err = gzip.NewReader(r); err != nil {
+			return nil, err
+		}
+	}
+	var names []string
+	addName := func(name string) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		switch path.Clean(header.Name) {
+		case "manifest.json":
+			var manifests []struct {
+				RepoTags []string
+			}
+			if err := json.NewDecoder(tr).Decode(&manifests); err != nil {
+				return nil, fmt.Errorf("invalid manifest.json: %w", err)
+			}
+			for _, manifest := range manifests {
+				for _, tag := range manifest.RepoTags {
+					addName(normalizeImageName(tag))
+				}
+			}
+		case "index.json":
+			var index struct {
+				Manifests []struct {
+					Annotations map[string]string `json:"annotations"`
+				} `json:"manifests"`
+			}
+			if err := json.NewDecoder(tr).Decode(&index); err != nil {
+				return nil, fmt.Errorf("invalid index.json: %w", err)
+			}
+			for _, manifest := range index.Manifests {
+				if name := manifest.Annotations[imageNameAnnotation]; name != "" {
+					addName(name)
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// imageNameAnnotation names the images of an OCI image archive for containerd
+const imageNameAnnotation = "io.containerd.image.name"
+
+// normalizeImageName expands a short image name of a Docker archive the way
+// containerd does on import, such as busybox to docker.io/library/busybox:latest
+func normalizeImageName(name string) string {
+	domain, remainder := "docker.io", name
+	if i := strings.IndexRune(name, '/'); i >= 0 && (strings.ContainsAny(name[:i], ".:") || name[:i] == "localhost") {
+		domain, remainder = name[:i], name[i+1:]
+	}
+	if domain == "index.docker.io" {
+		domain = "docker.io"
+	}
+	if domain == "docker.io" && !strings.ContainsRune(remainder, '/') {
+		remainder = "library/" + remainder
+	}
+	if !strings.ContainsRune(remainder, '@') && !strings.ContainsRune(path.Base(remainder), ':') {
+		remainder += ":latest"
+	}
+	return domain + "/" + remainder
+}
+
+func pinImages(ctx context.Context, names []string) error {
+	for _, name := range names {
+		zap.L().Info("Pinning image..", zap.String("image", name))
+		if _, err := runCtr(ctx, "images", "label", name, pinnedImageLabel); err != nil {
+			return fmt.Errorf("failed to pin image %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// runCtr runs ctr in the namespace of the CRI images, and returns its output
+var runCtr = func(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "ctr", append([]string{"--namespace", criNamespace}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
@@ -0,0 +1,174 @@
+package containerd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	v1 "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+type fakeImageClient struct {
+	v1.ImageServiceClient
+	mu       sync.Mutex
+	pulled   []string
+	inFlight int
+	maxSeen  int
+}
+
+func (c *fakeImageClient) PullImage(ctx context.Context, in *v1.PullImageRequest, opts ...grpc.CallOption) (*v1.PullImageResponse, error) {
+	c.mu.Lock()
+	c.inFlight++
+	c.maxSeen = max(c.maxSeen, c.inFlight)
+	c.mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+	if strings.Contains(in.Image.Image, "missing") {
+		return nil, fmt.Errorf("not found")
+	}
+	c.pulled = append(c.pulled, in.Image.Image)
+	return &v1.PullImageResponse{ImageRef: "sha256:" + in.Image.Image}, nil
+}
+
+func (c *fakeImageClient) ImageStatus(ctx context.Context, in *v1.ImageStatusRequest, opts ...grpc.CallOption) (*v1.ImageStatusResponse, error) {
+	return &v1.ImageStatusResponse{Image: &v1.Image{
+		Id:       "sha256:" + in.Image.Image,
+		RepoTags: []string{"docker.io/library/" + in.Image.Image},
+	}}, nil
+}
+
+// writeImageArchive writes a tar archive containing the given files
+func writeImageArchive(t *testing.T, name string, compress bool, files map[string]string) string {
+	archivePath := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var w io.Writer = f
+	if compress {
+		gz := gzip.NewWriter(f)
+		defer gz.Close()
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+	for fileName, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: fileName, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return archivePath
+}
+
+func TestPreloadImages(t *testing.T) {
+	oldNewImageClient, oldRunCtr, oldBackoff := newImageClient, runCtr, preloadImagePullBackoff
+	t.Cleanup(func() { newImageClient, runCtr, preloadImagePullBackoff = oldNewImageClient, oldRunCtr, oldBackoff })
+	client := &fakeImageClient{}
+	newImageClient = func(ctx context.Context) (v1.ImageServiceClient, func() error, error) {
+		return client, func() error { return nil }, nil
+	}
+	cniArchive := writeImageArchive(t, "cni.tar", false, map[string]string{
+		"manifest.json": `[{"Config":"config.json","RepoTags":["cni:v1"],"Layers":[]}]`,
+	})
+	toolsArchive := writeImageArchive(t, "tools.tar.gz", true, map[string]string{
+		"oci-layout": `{"imageLayoutVersion":"1.0.0"}`,
+		"index.json": `{"manifests":[{"annotations":{"io.containerd.image.name":"ghcr.io/example/tools:v2"}}]}`,
+	})
+	emptyArchive := writeImageArchive(t, "empty.tar", false, map[string]string{
+		"oci-layout": `{"imageLayoutVersion":"1.0.0"}`,
+		"index.json": `{"manifests":[]}`,
+	})
+	var ctrCalls []string
+	var ctrMu sync.Mutex
+	runCtr = func(ctx context.Context, args ...string) (string, error) {
+		ctrMu.Lock()
+		defer ctrMu.Unlock()
+		ctrCalls = append(ctrCalls, strings.Join(args, " "))
+		if args[1] == "list" {
+			return "docker.io/library/cni:v1\nghcr.io/example/tools:v2\n", nil
+		}
+		return "", nil
+	}
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				PreloadImages: api.PreloadImagesOptions{
+					Images: []api.PreloadImage{
+						{Image: "kube-proxy:v1", Pinned: true},
+						{Image: "agent:v1"},
+						{Image: "exporter:v1"},
+						{Image: "driver:v1"},
+					},
+					Archives: []api.PreloadImageArchive{
+						{Path: cniArchive, Pinned: true},
+						{Path: toolsArchive},
+					},
+					Parallelism: 2,
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, preloadImages(&cfg))
+	slices.Sort(client.pulled)
+	assert.Equal(t, []string{"agent:v1", "driver:v1", "exporter:v1", "kube-proxy:v1"}, client.pulled)
+	assert.Equal(t, 2, client.maxSeen)
+	assert.Equal(t, []string{
+		"images import " + cniArchive,
+		"images list --quiet",
+		"images label docker.io/library/cni:v1 " + pinnedImageLabel,
+		"images import " + toolsArchive,
+		"images list --quiet",
+		"images label sha256:kube-proxy:v1 " + pinnedImageLabel,
+		"images label docker.io/library/kube-proxy:v1 " + pinnedImageLabel,
+	}, ctrCalls)
+
+	cfg.Spec.Containerd.PreloadImages = api.PreloadImagesOptions{
+		Archives: []api.PreloadImageArchive{{Path: emptyArchive}},
+	}
+	assert.ErrorContains(t, preloadImages(&cfg), "did not yield any named image")
+
+	preloadImagePullBackoff = time.Millisecond
+	cfg.Spec.Containerd.PreloadImages = api.PreloadImagesOptions{
+		Images: []api.PreloadImage{{Image: "missing:v1"}},
+	}
+	assert.ErrorContains(t, preloadImages(&cfg), "failed to preload image missing:v1")
+}
+
+func TestNormalizeImageName(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected string
+	}{
+		{name: "busybox", expected: "docker.io/library/busybox:latest"},
+		{name: "library/busybox:1.36", expected: "docker.io/library/busybox:1.36"},
+		{name: "example/app:v1", expected: "docker.io/example/app:v1"},
+		{name: "index.docker.io/example/app:v1", expected: "docker.io/example/app:v1"},
+		{name: "localhost/app", expected: "localhost/app:latest"},
+		{name: "registry.local:5000/team/app:v1", expected: "registry.local:5000/team/app:v1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, normalizeImageName(test.name))
+		})
+	}
+}
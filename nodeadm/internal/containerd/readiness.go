@@ -0,0 +1,61 @@
+package containerd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	internalapi "github.com/containerd/containerd/integration/cri-api/pkg/apis"
+	"github.com/containerd/containerd/integration/remote"
+)
+
+const defaultReadinessTimeout = time.Minute
+
+// the NetworkReady condition is only true once a CNI plugin has been installed,
+// which usually happens after kubelet has registered the node, so it is opt-in.
+var defaultRuntimeConditions = []api.RuntimeCondition{api.RuntimeReady}
+
+func waitForRuntimeConditions(cfg *api.NodeConfig) error {
+	readiness := cfg.Spec.Containerd.Readiness
+	timeout := defaultReadinessTimeout
+	if readiness.Timeout != nil {
+		timeout = readiness.Timeout.Duration
+	}
+	conditionTypes := readiness.Conditions
+	if len(conditionTypes) == 0 {
+		conditionTypes = defaultRuntimeConditions
+	}
+
+	client, err := remote.NewRuntimeService(ContainerRuntimeEndpoint, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	var conditions []daemon.ReadinessCondition
+	for _, conditionType := range conditionTypes {
+		conditions = append(conditions, daemon.ReadinessCondition{
+			Name:  string(conditionType),
+			Check: runtimeConditionCheck(client, string(conditionType)),
+		})
+	}
+	return daemon.WaitForReadiness(ContainerdDaemonName, timeout, conditions...)
+}
+
+func runtimeConditionCheck(client internalapi.RuntimeService, conditionType string) func() (bool, string, error) {
+	return func() (bool, string, error) {
+		runtimeStatus, err := client.Status()
+		if err != nil {
+			return false, "", err
+		}
+		for _, condition := range runtimeStatus.Conditions {
+			if condition.Type != conditionType {
+				continue
+			}
+			if condition.Status {
+				return true, "", nil
+			}
+			return false, fmt.Sprintf("%s: %s", condition.Reason, condition.Message), nil
+		}
+		return false, fmt.Sprintf("runtime did not report the %s condition", conditionType), nil
+	}
+}
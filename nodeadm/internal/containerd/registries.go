@@ -0,0 +1,194 @@
+package containerd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	hostsFileName   = "hosts.toml"
+	hostsFileHeader = "# Generated by nodeadm from the NodeConfig, do not edit.\n"
+	hostsFilePerm   = 0644
+	dockerHubServer = "https://registry-1.docker.io"
+)
+
+// registryHostsDir is the first directory of the registry config_path in the
+// containerd config
+var registryHostsDir = "/etc/containerd/certs.d"
+
+// registryCAFilePattern matches the CA bundles that nodeadm writes next to the
+// hosts.toml of a registry
+var registryCAFilePattern = regexp.MustCompile(`^nodeadm-.*\.crt$`)
+
+// writeRegistryHosts writes a hosts.toml for each registry, and removes the
+// ones that nodeadm wrote for registries that are no longer configured.
+// Registry directories that were not written by nodeadm are left in place.
+func writeRegistryHosts(cfg *api.NodeConfig) error {
+	configured := map[string]bool{}
+	for _, registry := range cfg.Spec.Containerd.Registries {
+		configured[registry.Name] = true
+		files, err := renderRegistryHosts(registry)
+		if err != nil {
+			return err
+		}
+		registryDir := path.Join(registryHostsDir, registry.Name)
+		if err := pruneRegistryCAFiles(registryDir, files); err != nil {
+			return err
+		}
+		zap.L().Info("Writing containerd registry hosts..", zap.String("registry", registry.Name), zap.String("path", path.Join(registryDir, hostsFileName)))
+		for name, content := range files {
+			if err := util.WriteFileWithDir(path.Join(registryDir, name), content, hostsFilePerm); err != nil {
+				return err
+			}
+		}
+	}
+	entries, err := os.ReadDir(registryHostsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || configured[entry.Name()] {
+			continue
+		}
+		registryDir := path.Join(registryHostsDir, entry.Name())
+		hostsFile, err := os.ReadFile(path.Join(registryDir, hostsFileName))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !bytes.HasPrefix(hostsFile, []byte(hostsFileHeader)) {
+			continue
+		}
+		zap.L().Info("Removing stale containerd registry hosts..", zap.String("registry", entry.Name()))
+		if err := util.RemoveFile(path.Join(registryDir, hostsFileName)); err != nil {
+			return err
+		}
+		if err := pruneRegistryCAFiles(registryDir, nil); err != nil {
+			return err
+		}
+		// the directory is kept if anything else was added to it
+		if remaining, err := os.ReadDir(registryDir); err == nil && len(remaining) == 0 {
+			if err := util.RemoveEmptyDir(registryDir); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func pruneRegistryCAFiles(registryDir string, files map[string][]byte) error {
+	entries, err := os.ReadDir(registryDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if _, ok := files[entry.Name()]; ok || entry.IsDir() || !registryCAFilePattern.MatchString(entry.Name()) {
+			continue
+		}
+		if err := util.RemoveFile(path.Join(registryDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderRegistryHosts returns the hosts.toml of a registry and the CA bundles
+// that it refers to, by file name
+func renderRegistryHosts(registry api.RegistryOptions) (map[string][]byte, error) {
+	registryDir := path.Join(registryHostsDir, registry.Name)
+	files := map[string][]byte{}
+	var buf bytes.Buffer
+	buf.WriteString(hostsFileHeader)
+
+	server := registry.Server
+	if server == "" && registry.Name != api.DefaultRegistryName {
+		if registry.Name == "docker.io" {
+			server = dockerHubServer
+		} else {
+			server = "https://" + registry.Name
+		}
+	}
+	if server != "" {
+		fmt.Fprintf(&buf, "server = %s\n", tomlString(server))
+		writeRegistryTLS(&buf, "", registryDir, server, registry.ServerTLS, files)
+	}
+
+	for _, mirror := range registry.Mirrors {
+		fmt.Fprintf(&buf, "\n[host.%s]\n", tomlString(mirror.Host))
+		if len(mirror.Capabilities) > 0 {
+			var capabilities []string
+			for _, capability := range mirror.Capabilities {
+				capabilities = append(capabilities, tomlString(string(capability)))
+			}
+			fmt.Fprintf(&buf, "  capabilities = [%s]\n", strings.Join(capabilities, ", "))
+		}
+		if mirror.OverridePath {
+			buf.WriteString("  override_path = true\n")
+		}
+		writeRegistryTLS(&buf, "  ", registryDir, mirror.Host, mirror.TLS, files)
+	}
+	files[hostsFileName] = buf.Bytes()
+	return files, nil
+}
+
+func writeRegistryTLS(buf *bytes.Buffer, indent string, registryDir string, host string, options api.RegistryTLSOptions, files map[string][]byte) {
+	if len(options.CertificateAuthority) > 0 {
+		name := registryCAFileName(host)
+		files[name] = options.CertificateAuthority
+		fmt.Fprintf(buf, "%sca = %s\n", indent, tomlString(path.Join(registryDir, name)))
+	}
+	if options.ClientCertificate != "" {
+		fmt.Fprintf(buf, "%sclient = [[%s, %s]]\n", indent, tomlString(options.ClientCertificate), tomlString(options.ClientKey))
+	}
+	if options.InsecureSkipVerify {
+		fmt.Fprintf(buf, "%sskip_verify = true\n", indent)
+	}
+}
+
+// registryCAFileName returns the name of the CA bundle of a host, such as
+// nodeadm-cache.example.com_5000.crt for https://cache.example.com:5000
+func registryCAFileName(host string) string {
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+	sanitized := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || slices.Contains([]rune{'.', '-'}, r) {
+			return r
+		}
+		return '_'
+	}, host)
+	return "nodeadm-" + sanitized + ".crt"
+}
+
+// tomlString quotes a TOML basic string
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, "\\u%04x", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
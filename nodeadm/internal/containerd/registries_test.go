@@ -0,0 +1,84 @@
+package containerd
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestWriteRegistryHosts(t *testing.T) {
+	oldRegistryHostsDir := registryHostsDir
+	t.Cleanup(func() { registryHostsDir = oldRegistryHostsDir })
+	registryHostsDir = t.TempDir()
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				Registries: []api.RegistryOptions{
+					{
+						Name: "docker.io",
+						Mirrors: []api.RegistryMirror{
+							{
+								Host:         "https://cache.example.com:5000",
+								Capabilities: []api.RegistryCapability{api.RegistryCapabilityPull, api.RegistryCapabilityResolve},
+								TLS:          api.RegistryTLSOptions{CertificateAuthority: []byte("ca")},
+							},
+							{
+								Host:         "http://10.0.0.10/v2/docker",
+								OverridePath: true,
+							},
+						},
+					},
+					{
+						Name: "registry.dev.example.com:5000",
+						ServerTLS: api.RegistryTLSOptions{
+							ClientCertificate:  "/etc/pki/registry.crt",
+							ClientKey:          "/etc/pki/registry.key",
+							InsecureSkipVerify: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	// directories written by other tools, and by a previous run for a registry
+	// that is no longer configured
+	assert.NoError(t, os.MkdirAll(path.Join(registryHostsDir, "quay.io"), 0755))
+	assert.NoError(t, os.WriteFile(path.Join(registryHostsDir, "quay.io", hostsFileName), []byte("server = \"https://quay.io\"\n"), 0644))
+	assert.NoError(t, os.MkdirAll(path.Join(registryHostsDir, "ghcr.io"), 0755))
+	assert.NoError(t, os.WriteFile(path.Join(registryHostsDir, "ghcr.io", hostsFileName), []byte(hostsFileHeader), 0644))
+	assert.NoError(t, os.WriteFile(path.Join(registryHostsDir, "ghcr.io", "nodeadm-ghcr.io.crt"), []byte("ca"), 0644))
+
+	assert.NoError(t, writeRegistryHosts(&cfg))
+
+	expectFile := func(expected string, elem ...string) {
+		t.Helper()
+		content, err := os.ReadFile(path.Join(append([]string{registryHostsDir}, elem...)...))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(content))
+	}
+	expectFile(hostsFileHeader+`server = "https://registry-1.docker.io"
+
+[host."https://cache.example.com:5000"]
+  capabilities = ["pull", "resolve"]
+  ca = "`+path.Join(registryHostsDir, "docker.io", "nodeadm-cache.example.com_5000.crt")+`"
+
+[host."http://10.0.0.10/v2/docker"]
+  override_path = true
+`, "docker.io", hostsFileName)
+	expectFile("ca", "docker.io", "nodeadm-cache.example.com_5000.crt")
+	expectFile(hostsFileHeader+`server = "https://registry.dev.example.com:5000"
+client = [["/etc/pki/registry.crt", "/etc/pki/registry.key"]]
+skip_verify = true
+`, "registry.dev.example.com:5000", hostsFileName)
+	expectFile("server = \"https://quay.io\"\n", "quay.io", hostsFileName)
+	assert.NoDirExists(t, path.Join(registryHostsDir, "ghcr.io"))
+
+	// the CA bundle is removed along with its mirror
+	cfg.Spec.Containerd.Registries[0].Mirrors = cfg.Spec.Containerd.Registries[0].Mirrors[1:]
+	assert.NoError(t, writeRegistryHosts(&cfg))
+	assert.NoFileExists(t, path.Join(registryHostsDir, "docker.io", "nodeadm-cache.example.com_5000.crt"))
+}
@@ -0,0 +1,183 @@
+package containerd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+// runtimeBaseRuntimeSpecDir holds the base runtime specs of the additional
+// runtimes. nodeadm owns the directory, so any spec in it that does not belong
+// to a configured runtime is stale.
+var runtimeBaseRuntimeSpecDir = "/etc/containerd/base-runtime-specs"
+
+const runtimeBaseRuntimeSpecPattern = "*.json"
+
+// lookPath finds the shim binary of a runtime
+var lookPath = exec.LookPath
+
+func runtimeBaseRuntimeSpecFile(name string) string {
+	return path.Join(runtimeBaseRuntimeSpecDir, strings.Replace(runtimeBaseRuntimeSpecPattern, "*", name, 1))
+}
+
+// shimBinaryName returns the binary that containerd runs for a runtime type,
+// such as containerd-shim-runsc-v1 for io.containerd.runsc.v1
+func shimBinaryName(runtimeType string) string {
+	parts := strings.Split(runtimeType, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return fmt.Sprintf("containerd-shim-%s-%s", parts[len(parts)-2], parts[len(parts)-1])
+}
+
+func ensureRuntimeBinariesExist(cfg *api.NodeConfig) error {
+	for _, runtime := range cfg.Spec.Containerd.Runtimes {
+		if runtime.Path != "" {
+			if _, err := os.Stat(runtime.Path); err != nil {
+				return fmt.Errorf("shim binary of container runtime %s was not found: %w", runtime.Name, err)
+			}
+			continue
+		}
+		binary := shimBinaryName(runtime.Type)
+		if binary == "" {
+			return fmt.Errorf("shim binary of container runtime %s cannot be derived from its type %q, set its path instead", runtime.Name, runtime.Type)
+		}
+		if _, err := lookPath(binary); err != nil {
+			return fmt.Errorf("shim binary of container runtime %s was not found: %w", runtime.Name, err)
+		}
+	}
+	return nil
+}
+
+// generateRuntimesConfig returns the containerd config of the additional
+// runtimes, in the CRI plugin of the containerd version
+func generateRuntimesConfig(cfg *api.NodeConfig, containerdVersion string) ([]byte, error) {
+	runtimes := map[string]interface{}{}
+	for _, runtime := range cfg.Spec.Containerd.Runtimes {
+		runtimeConfig := map[string]interface{}{
+			"runtime_type":      runtime.Type,
+			"base_runtime_spec": containerdBaseRuntimeSpecFile,
+		}
+		if runtime.Path != "" {
+			runtimeConfig["runtime_path"] = runtime.Path
+		}
+		if len(runtime.BaseRuntimeSpec) > 0 {
+			runtimeConfig["base_runtime_spec"] = runtimeBaseRuntimeSpecFile(runtime.Name)
+		}
+		if len(runtime.Options) > 0 {
+			options, err := inlineDocumentToTOML(runtime.Options)
+			if err != nil {
+				return nil, fmt.Errorf("invalid options of container runtime %s: %w", runtime.Name, err)
+			}
+			runtimeConfig["options"] = options
+		}
+		runtimes[runtime.Name] = runtimeConfig
+	}
+	pluginName := criPluginV2
+	if usesConfigVersion3(containerdVersion) {
+		pluginName = criRuntimePluginV3
+	}
+	return toml.Marshal(map[string]interface{}{
+		"plugins": map[string]interface{}{
+			pluginName: map[string]interface{}{
+				criContainerdSection: map[string]interface{}{
+					"runtimes": runtimes,
+				},
+			},
+		},
+	})
+}
+
+// inlineDocumentToTOML converts an inline document into TOML values, keeping
+// whole numbers as integers rather than the floats that JSON decodes them to
+func inlineDocumentToTOML(document api.InlineDocument) (map[string]interface{}, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value map[string]interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return convertJSONNumbers(value).(map[string]interface{}), nil
+}
+
+func convertJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = convertJSONNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertJSONNumbers(item)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
+
+// writeRuntimeBaseRuntimeSpecs writes the base runtime spec of each runtime
+// that has one, merged into the base runtime spec of runc, and removes those of
+// runtimes that are no longer configured
+func writeRuntimeBaseRuntimeSpecs(cfg *api.NodeConfig, baseRuntimeSpecData []byte) error {
+	configured := map[string]bool{}
+	for _, runtime := range cfg.Spec.Containerd.Runtimes {
+		if len(runtime.BaseRuntimeSpec) == 0 {
+			continue
+		}
+		specPath := runtimeBaseRuntimeSpecFile(runtime.Name)
+		configured[specPath] = true
+		runtimeSpecData, err := json.Marshal(runtime.BaseRuntimeSpec)
+		if err != nil {
+			return err
+		}
+		mergedSpec, err := util.Merge(baseRuntimeSpecData, runtimeSpecData, json.Marshal, unmarshalJSONNumbers)
+		if err != nil {
+			return err
+		}
+		mergedSpecData, err := json.MarshalIndent(mergedSpec, "", strings.Repeat(" ", 4))
+		if err != nil {
+			return err
+		}
+		if err := validateBaseRuntimeSpec(mergedSpecData); err != nil {
+			return fmt.Errorf("invalid base runtime spec of container runtime %s: %w", runtime.Name, err)
+		}
+		zap.L().Info("Writing container runtime base runtime spec...", zap.String("runtime", runtime.Name), zap.String("path", specPath))
+		if err := util.WriteFileWithDir(specPath, mergedSpecData, containerdConfigPerm); err != nil {
+			return err
+		}
+	}
+	existing, err := filepath.Glob(path.Join(runtimeBaseRuntimeSpecDir, runtimeBaseRuntimeSpecPattern))
+	if err != nil {
+		return err
+	}
+	for _, specPath := range existing {
+		if configured[specPath] {
+			continue
+		}
+		zap.L().Info("Removing stale container runtime base runtime spec..", zap.String("path", specPath))
+		if err := util.RemoveFile(specPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package containerd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestShimBinaryName(t *testing.T) {
+	assert.Equal(t, "containerd-shim-runsc-v1", shimBinaryName("io.containerd.runsc.v1"))
+	assert.Equal(t, "containerd-shim-kata-v2", shimBinaryName("io.containerd.kata.v2"))
+	assert.Equal(t, "", shimBinaryName("runsc"))
+}
+
+func TestEnsureRuntimeBinariesExist(t *testing.T) {
+	oldLookPath := lookPath
+	t.Cleanup(func() { lookPath = oldLookPath })
+	lookPath = func(file string) (string, error) {
+		if file == "containerd-shim-runsc-v1" {
+			return "/usr/local/bin/" + file, nil
+		}
+		return "", fmt.Errorf("executable file not found in $PATH")
+	}
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				Runtimes: []api.ContainerRuntime{{Name: "runsc", Type: "io.containerd.runsc.v1"}},
+			},
+		},
+	}
+	assert.NoError(t, ensureRuntimeBinariesExist(&cfg))
+
+	cfg.Spec.Containerd.Runtimes = append(cfg.Spec.Containerd.Runtimes, api.ContainerRuntime{Name: "kata", Type: "io.containerd.kata.v2"})
+	assert.ErrorContains(t, ensureRuntimeBinariesExist(&cfg), "shim binary of container runtime kata was not found")
+
+	cfg.Spec.Containerd.Runtimes[1].Path = path.Join(t.TempDir(), "containerd-shim-kata-v2")
+	assert.ErrorContains(t, ensureRuntimeBinariesExist(&cfg), "shim binary of container runtime kata was not found")
+	assert.NoError(t, os.WriteFile(cfg.Spec.Containerd.Runtimes[1].Path, nil, 0755))
+	assert.NoError(t, ensureRuntimeBinariesExist(&cfg))
+}
+
+func TestGenerateRuntimesConfig(t *testing.T) {
+	oldRuntimeBaseRuntimeSpecDir := runtimeBaseRuntimeSpecDir
+	t.Cleanup(func() { runtimeBaseRuntimeSpecDir = oldRuntimeBaseRuntimeSpecDir })
+	runtimeBaseRuntimeSpecDir = "/etc/containerd/base-runtime-specs"
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				Runtimes: []api.ContainerRuntime{
+					{
+						Name:    "runsc",
+						Type:    "io.containerd.runsc.v1",
+						Options: api.InlineDocument{"TypeUrl": runtime.RawExtension{Raw: []byte(`"io.containerd.runsc.v1.options"`)}, "ConfigPath": runtime.RawExtension{Raw: []byte(`"/etc/containerd/runsc.toml"`)}},
+					},
+					{
+						Name:            "kata",
+						Type:            "io.containerd.kata.v2",
+						Path:            "/opt/kata/bin/containerd-shim-kata-v2",
+						Options:         api.InlineDocument{"Debug": runtime.RawExtension{Raw: []byte(`true`)}, "Memory": runtime.RawExtension{Raw: []byte(`2048`)}},
+						BaseRuntimeSpec: api.InlineDocument{"process": runtime.RawExtension{Raw: []byte(`{"noNewPrivileges": true}`)}},
+					},
+				},
+			},
+		},
+	}
+	for containerdVersion, pluginName := range map[string]string{"v1.7.20": criPluginV2, "v2.0.0": criRuntimePluginV3} {
+		config, err := generateContainerdConfig(&cfg, containerdVersion)
+		assert.NoError(t, err)
+		var configMap map[string]interface{}
+		assert.NoError(t, toml.Unmarshal(config, &configMap))
+		runtimes := subTable(subTable(subTable(subTable(configMap, "plugins"), pluginName), criContainerdSection), "runtimes")
+		assert.Equal(t, map[string]interface{}{
+			"runtime_type":      "io.containerd.runsc.v1",
+			"base_runtime_spec": "/etc/containerd/base-runtime-spec.json",
+			"options": map[string]interface{}{
+				"TypeUrl":    "io.containerd.runsc.v1.options",
+				"ConfigPath": "/etc/containerd/runsc.toml",
+			},
+		}, runtimes["runsc"], containerdVersion)
+		assert.Equal(t, map[string]interface{}{
+			"runtime_type":      "io.containerd.kata.v2",
+			"runtime_path":      "/opt/kata/bin/containerd-shim-kata-v2",
+			"base_runtime_spec": "/etc/containerd/base-runtime-specs/kata.json",
+			"options": map[string]interface{}{
+				"Debug":  true,
+				"Memory": int64(2048),
+			},
+		}, runtimes["kata"], containerdVersion)
+		// the default runtime is kept
+		assert.Contains(t, runtimes, "runc", containerdVersion)
+	}
+}
+
+func TestWriteRuntimeBaseRuntimeSpecs(t *testing.T) {
+	containerdDir := t.TempDir()
+	oldRuntimeBaseRuntimeSpecDir := runtimeBaseRuntimeSpecDir
+	t.Cleanup(func() { runtimeBaseRuntimeSpecDir = oldRuntimeBaseRuntimeSpecDir })
+	runtimeBaseRuntimeSpecDir = path.Join(containerdDir, "base-runtime-specs")
+	assert.NoError(t, os.MkdirAll(runtimeBaseRuntimeSpecDir, 0755))
+	stale := path.Join(runtimeBaseRuntimeSpecDir, "removed.json")
+	assert.NoError(t, os.WriteFile(stale, []byte("{}"), 0644))
+	// specs outside of the directory are not nodeadm's to remove
+	unmanaged := path.Join(containerdDir, "base-runtime-spec-custom.json")
+	assert.NoError(t, os.WriteFile(unmanaged, []byte("{}"), 0644))
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{
+				Runtimes: []api.ContainerRuntime{
+					{Name: "runsc", Type: "io.containerd.runsc.v1"},
+					{
+						Name:            "kata",
+						Type:            "io.containerd.kata.v2",
+						BaseRuntimeSpec: api.InlineDocument{"process": runtime.RawExtension{Raw: []byte(`{"noNewPrivileges": true}`)}},
+					},
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, writeRuntimeBaseRuntimeSpecs(&cfg, []byte(`{"ociVersion": "1.1.0", "process": {"noNewPrivileges": false, "cwd": "/"}}`)))
+	data, err := os.ReadFile(path.Join(runtimeBaseRuntimeSpecDir, "kata.json"))
+	assert.NoError(t, err)
+	var spec map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &spec))
+	assert.Equal(t, map[string]interface{}{
+		"ociVersion": "1.1.0",
+		"process":    map[string]interface{}{"noNewPrivileges": true, "cwd": "/"},
+	}, spec)
+	assert.NoFileExists(t, path.Join(runtimeBaseRuntimeSpecDir, "runsc.json"))
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, unmanaged)
+}
@@ -1,56 +1,76 @@
 package containerd
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
-	"regexp"
 	"time"
 
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/ecr"
-	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
-	"github.com/containerd/containerd/integration/remote"
+	"github.com/pelletier/go-toml/v2"
 	"go.uber.org/zap"
-	v1 "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const (
+	defaultSandboxImagePullTimeout  = 5 * time.Minute
+	defaultSandboxImagePullAttempts = 3
+	defaultSandboxImagePullBackoff  = 2 * time.Second
 )
 
-var containerdSandboxImageRegex = regexp.MustCompile(`sandbox_image = "(.*)"`)
+// findSandboxImage returns the sandbox image in a containerd config, which is
+// pinned in the images plugin from config version 3, and set in the CRI plugin
+// before
+func findSandboxImage(config []byte) (string, error) {
+	var configMap map[string]interface{}
+	if err := toml.Unmarshal(config, &configMap); err != nil {
+		return "", err
+	}
+	plugins := subTable(configMap, "plugins")
+	if sandboxImage, ok := subTable(subTable(plugins, criImagesPluginV3), "pinned_images")["sandbox"].(string); ok && sandboxImage != "" {
+		return sandboxImage, nil
+	}
+	if sandboxImage, ok := subTable(plugins, criPluginV2)["sandbox_image"].(string); ok && sandboxImage != "" {
+		return sandboxImage, nil
+	}
+	return "", fmt.Errorf("sandbox image could not be found in containerd config")
+}
 
 func cacheSandboxImage(cfg *api.NodeConfig) error {
+	options := cfg.Spec.Containerd.SandboxImagePull
+	timeout := defaultSandboxImagePullTimeout
+	if options.Timeout != nil {
+		timeout = options.Timeout.Duration
+	}
+	attempts := defaultSandboxImagePullAttempts
+	if options.Attempts > 0 {
+		attempts = int(options.Attempts)
+	}
+	backoff := defaultSandboxImagePullBackoff
+	if options.Backoff != nil {
+		backoff = options.Backoff.Duration
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
 	zap.L().Info("Looking up current sandbox image in containerd config..")
 	// capture the output of a `containerd config dump`, which is the final
 	// containerd configuration used after all of the applied transformations
-	dump, err := exec.Command("containerd", "config", "dump").Output()
+	dump, err := exec.CommandContext(ctx, "containerd", "config", "dump").Output()
 	if err != nil {
 		return err
 	}
-	matches := containerdSandboxImageRegex.FindSubmatch(dump)
-	if matches == nil {
-		return fmt.Errorf("sandbox image could not be found in containerd config")
-	}
-	sandboxImage := string(matches[1])
-	zap.L().Info("Found sandbox image", zap.String("image", sandboxImage))
-
-	zap.L().Info("Fetching ECR authorization token..")
-	ecrUserToken, err := ecr.GetAuthorizationToken(cfg.Status.Instance.Region)
+	sandboxImage, err := findSandboxImage(dump)
 	if err != nil {
 		return err
 	}
+	zap.L().Info("Found sandbox image", zap.String("image", sandboxImage))
 
-	client, err := remote.NewImageService(ContainerRuntimeEndpoint, 5*time.Second)
+	client, closeClient, err := newImageClient(ctx)
 	if err != nil {
 		return err
 	}
-	imageSpec := &v1.ImageSpec{Image: sandboxImage}
-	authConfig := &v1.AuthConfig{Auth: ecrUserToken}
-
-	return util.RetryExponentialBackoff(3, 2*time.Second, func() error {
-		zap.L().Info("Pulling sandbox image..", zap.String("image", sandboxImage))
-		imageRef, err := client.PullImage(imageSpec, authConfig, nil)
-		if err != nil {
-			return err
-		}
-		zap.L().Info("Finished pulling sandbox image", zap.String("image-ref", imageRef))
-		return nil
-	})
+	defer closeClient()
+	_, err = pullImage(ctx, client, cfg, sandboxImage, attempts, backoff)
+	return err
 }
@@ -47,11 +47,33 @@ const containerdConfigDumpFragment = `
     unset_seccomp_profile = ""
 `
 
-func TestSandboxImageRegex(t *testing.T) {
-	matches := containerdSandboxImageRegex.FindStringSubmatch(containerdConfigDumpFragment)
-	if matches == nil {
-		t.Errorf("sandbox image could not be found in containerd config")
-	}
-	sandboxImage := matches[1]
-	assert.Equal(t, sandboxImage, "registry.k8s.io/pause:3.8")
+const containerdV3ConfigDumpFragment = `
+version = 3
+
+[plugins]
+  [plugins.'io.containerd.cri.v1.images']
+    discard_unpacked_layers = true
+    image_pull_progress_timeout = '5m0s'
+    max_concurrent_downloads = 3
+    snapshotter = 'overlayfs'
+
+    [plugins.'io.containerd.cri.v1.images'.pinned_images]
+      sandbox = 'registry.k8s.io/pause:3.10'
+
+  [plugins.'io.containerd.grpc.v1.cri']
+    disable_tcp_service = true
+    stream_idle_timeout = '4h0m0s'
+`
+
+func TestFindSandboxImage(t *testing.T) {
+	sandboxImage, err := findSandboxImage([]byte(containerdConfigDumpFragment))
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.k8s.io/pause:3.8", sandboxImage)
+
+	sandboxImage, err = findSandboxImage([]byte(containerdV3ConfigDumpFragment))
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.k8s.io/pause:3.10", sandboxImage)
+
+	_, err = findSandboxImage([]byte("version = 3\n"))
+	assert.Error(t, err)
 }
@@ -0,0 +1,73 @@
+package containerd
+
+import (
+	"github.com/pelletier/go-toml/v2"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+// applySnapshotterConfig selects the snapshotter in the CRI plugin of the
+// containerd version. It is applied after the user's config, so that the
+// layers a proxy snapshotter resolves lazily are never discarded.
+func applySnapshotterConfig(config []byte, cfg *api.NodeConfig, containerdVersion string) ([]byte, error) {
+	options := cfg.Spec.Containerd.Snapshotter
+	if options.Name == "" {
+		return config, nil
+	}
+	snapshotterConfig := map[string]interface{}{
+		"snapshotter": options.Name,
+	}
+	pluginConfig := map[string]interface{}{}
+	if usesConfigVersion3(containerdVersion) {
+		pluginConfig[criImagesPluginV3] = snapshotterConfig
+	} else {
+		pluginConfig[criPluginV2] = map[string]interface{}{
+			criContainerdSection: snapshotterConfig,
+		}
+	}
+	fragment := map[string]interface{}{
+		"plugins": pluginConfig,
+	}
+	if proxy := api.GetProxySnapshotter(options); proxy != nil {
+		// the unpacked layers are how a proxy snapshotter finds the remote
+		// content of a layer, and it relies on the snapshot annotations to
+		// resolve them
+		if userDiscardsUnpackedLayers(cfg.Spec.Containerd.Config) {
+			zap.L().Warn("Keeping unpacked layers for the proxy snapshotter, overriding discard_unpacked_layers in the containerd config", zap.String("snapshotter", options.Name))
+		}
+		snapshotterConfig["discard_unpacked_layers"] = false
+		snapshotterConfig["disable_snapshot_annotations"] = false
+		fragment["proxy_plugins"] = map[string]interface{}{
+			options.Name: map[string]interface{}{
+				"type":    "snapshot",
+				"address": proxy.Address,
+			},
+		}
+	}
+	fragmentData, err := toml.Marshal(fragment)
+	if err != nil {
+		return nil, err
+	}
+	configMap, err := util.Merge(config, fragmentData, toml.Marshal, toml.Unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	return toml.Marshal(configMap)
+}
+
+// userDiscardsUnpackedLayers returns whether the containerd config in the
+// NodeConfig enables discard_unpacked_layers, in either config layout
+func userDiscardsUnpackedLayers(userConfig string) bool {
+	var config map[string]interface{}
+	if err := toml.Unmarshal([]byte(userConfig), &config); err != nil {
+		return false
+	}
+	plugins := subTable(config, "plugins")
+	if discard, ok := subTable(subTable(plugins, criPluginV2), criContainerdSection)["discard_unpacked_layers"].(bool); ok && discard {
+		return true
+	}
+	discard, ok := subTable(plugins, criImagesPluginV3)["discard_unpacked_layers"].(bool)
+	return ok && discard
+}
@@ -0,0 +1,144 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestApplySnapshotterConfig(t *testing.T) {
+	var tests = []struct {
+		name              string
+		containerdVersion string
+		options           api.SnapshotterOptions
+		config            string
+		expectedConfig    string
+	}{
+		{
+			name:              "default snapshotter",
+			containerdVersion: "v1.7.20",
+			config: `
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = true
+`,
+			expectedConfig: `
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = true
+`,
+		},
+		{
+			name:              "built-in snapshotter",
+			containerdVersion: "v1.7.20",
+			options:           api.SnapshotterOptions{Name: "native"},
+			config: `
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = true
+`,
+			expectedConfig: `
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = true
+snapshotter = "native"
+`,
+		},
+		{
+			name:              "proxy snapshotter on containerd 1.7",
+			containerdVersion: "v1.7.20",
+			options:           api.SnapshotterOptions{Name: "soci"},
+			config: `
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = true
+`,
+			expectedConfig: `
+[plugins."io.containerd.grpc.v1.cri".containerd]
+disable_snapshot_annotations = false
+discard_unpacked_layers = false
+snapshotter = "soci"
+
+[proxy_plugins.soci]
+address = "/run/soci-snapshotter-grpc/soci-snapshotter-grpc.sock"
+type = "snapshot"
+`,
+		},
+		{
+			name:              "proxy snapshotter on containerd 2.0",
+			containerdVersion: "v2.0.0",
+			options: api.SnapshotterOptions{
+				Name:  "stargz",
+				Proxy: api.ProxySnapshotterOptions{Address: "/run/stargz.sock"},
+			},
+			config: `
+[plugins."io.containerd.cri.v1.images"]
+discard_unpacked_layers = true
+`,
+			expectedConfig: `
+[plugins."io.containerd.cri.v1.images"]
+disable_snapshot_annotations = false
+discard_unpacked_layers = false
+snapshotter = "stargz"
+
+[proxy_plugins.stargz]
+address = "/run/stargz.sock"
+type = "snapshot"
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Containerd: api.ContainerdOptions{Snapshotter: test.options},
+				},
+			}
+			config, err := applySnapshotterConfig([]byte(test.config), &cfg, test.containerdVersion)
+			assert.NoError(t, err)
+			var actual, expected map[string]interface{}
+			assert.NoError(t, toml.Unmarshal(config, &actual))
+			assert.NoError(t, toml.Unmarshal([]byte(test.expectedConfig), &expected))
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestUserDiscardsUnpackedLayers(t *testing.T) {
+	var tests = []struct {
+		name     string
+		config   string
+		expected bool
+	}{
+		{name: "no config"},
+		{
+			name: "version 2",
+			config: `
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = true
+`,
+			expected: true,
+		},
+		{
+			name: "version 3",
+			config: `
+version = 3
+[plugins."io.containerd.cri.v1.images"]
+discard_unpacked_layers = true
+`,
+			expected: true,
+		},
+		{
+			name: "disabled",
+			config: `
+[plugins."io.containerd.grpc.v1.cri".containerd]
+discard_unpacked_layers = false
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, userDiscardsUnpackedLayers(test.config))
+		})
+	}
+}
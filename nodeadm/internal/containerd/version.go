@@ -0,0 +1,40 @@
+package containerd
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+func GetContainerdVersion() (string, error) {
+	output, err := exec.Command("containerd", "--version").Output()
+	if err != nil {
+		return "", err
+	}
+	version := parseContainerdVersion(string(output))
+	if version == "" {
+		return "", fmt.Errorf("containerd version could not be found in %q", strings.TrimSpace(string(output)))
+	}
+	return version, nil
+}
+
+// the module path of containerd 2.x ends in /v2, which must not be mistaken for
+// the version, and distribution builds may omit the v prefix
+var containerdVersionRegex = regexp.MustCompile(`\sv?([0-9]+\.[0-9]+\.[0-9]+)`)
+
+func parseContainerdVersion(rawVersion string) string {
+	matches := containerdVersionRegex.FindStringSubmatch(rawVersion)
+	if matches == nil {
+		return ""
+	}
+	return "v" + matches[1]
+}
+
+// usesConfigVersion3 returns whether containerd reads the version 3 config,
+// which splits the CRI plugin into separate images and runtime plugins
+func usesConfigVersion3(containerdVersion string) bool {
+	return semver.Compare(containerdVersion, "v2.0.0") >= 0
+}
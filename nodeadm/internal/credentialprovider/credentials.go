@@ -0,0 +1,160 @@
+package credentialprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// Credentials authenticate a pull from a registry
+type Credentials struct {
+	Username string
+	Password string
+}
+
+type credentialProviderRequest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Image      string `json:"image"`
+}
+
+type credentialProviderResponse struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Auth       map[string]authConfig `json:"auth"`
+}
+
+type authConfig struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// GetCredentials runs the credential providers in the config written for
+// kubelet whose matchImages match the image, with the same protocol as kubelet,
+// and returns the first credentials found. nil is returned when no provider
+// matches the image, or kubelet has not been configured, for an anonymous pull.
+func GetCredentials(ctx context.Context, options api.ImageCredentialProviderOptions, image string) (*Credentials, error) {
+	data, err := os.ReadFile(ConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse image credential provider config %s: %w", ConfigPath, err)
+	}
+	binDir, _ := ResolveProviders(options)
+	var errs []error
+	for _, provider := range config.Providers {
+		if !matchesAnyImage(provider.MatchImages, image) {
+			continue
+		}
+		zap.L().Info("Running image credential provider..", zap.String("provider", provider.Name), zap.String("image", image))
+		credentials, err := runProvider(ctx, path.Join(binDir, provider.Name), provider, image)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("image credential provider %s failed: %w", provider.Name, err))
+			continue
+		}
+		if credentials != nil {
+			return credentials, nil
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+func runProvider(ctx context.Context, binPath string, provider Provider, image string) (*Credentials, error) {
+	request, err := json.Marshal(credentialProviderRequest{
+		APIVersion: provider.APIVersion,
+		Kind:       "CredentialProviderRequest",
+		Image:      image,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, binPath, provider.Args...)
+	cmd.Env = os.Environ()
+	for _, env := range provider.Env {
+		cmd.Env = append(cmd.Env, env.Name+"="+env.Value)
+	}
+	cmd.Stdin = bytes.NewReader(request)
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	var response credentialProviderResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if response.Kind != "CredentialProviderResponse" || response.APIVersion != provider.APIVersion {
+		return nil, fmt.Errorf("unexpected response %s, %s", response.APIVersion, response.Kind)
+	}
+	// the keys of the response are matchImages patterns as well, of which the
+	// longest that matches the image is the most specific
+	var matched string
+	for pattern := range response.Auth {
+		if matchImage(pattern, image) && len(pattern) > len(matched) {
+			matched = pattern
+		}
+	}
+	if matched == "" {
+		return nil, nil
+	}
+	auth := response.Auth[matched]
+	return &Credentials{Username: auth.Username, Password: auth.Password}, nil
+}
+
+func matchesAnyImage(patterns []string, image string) bool {
+	for _, pattern := range patterns {
+		if matchImage(pattern, image) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchImage matches an image against a matchImages pattern the way kubelet
+// does. Each part of the pattern's host may be a glob, which must match the
+// same number of parts of the image's host. The ports must be equal, and the
+// pattern's path must be a prefix of the image's path.
+func matchImage(pattern string, image string) bool {
+	patternHost, patternPath, _ := strings.Cut(pattern, "/")
+	imageHost, imagePath, _ := strings.Cut(image, "/")
+	patternHostname, patternPort := splitPort(patternHost)
+	imageHostname, imagePort := splitPort(imageHost)
+	if patternPort != imagePort {
+		return false
+	}
+	patternParts := strings.Split(patternHostname, ".")
+	imageParts := strings.Split(imageHostname, ".")
+	if len(patternParts) != len(imageParts) {
+		return false
+	}
+	for i := range patternParts {
+		if matched, err := path.Match(patternParts[i], imageParts[i]); err != nil || !matched {
+			return false
+		}
+	}
+	return strings.HasPrefix(imagePath, patternPath)
+}
+
+func splitPort(host string) (string, string) {
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		return host[:i], host[i+1:]
+	}
+	return host, ""
+}
@@ -0,0 +1,98 @@
+package credentialprovider
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestMatchImage(t *testing.T) {
+	var tests = []struct {
+		pattern  string
+		image    string
+		expected bool
+	}{
+		{pattern: "*.dkr.ecr.*.amazonaws.com", image: "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause:3.5", expected: true},
+		{pattern: "*.dkr.ecr.*.amazonaws.com", image: "602401143452.dkr.ecr.us-west-2.amazonaws.com.cn/eks/pause:3.5", expected: false},
+		{pattern: "*.example.com", image: "example.com/pause:3.9", expected: false},
+		{pattern: "registry.example.com:5000/team", image: "registry.example.com:5000/team/pause:3.9", expected: true},
+		{pattern: "registry.example.com:5000/team", image: "registry.example.com/team/pause:3.9", expected: false},
+		{pattern: "registry.example.com/team", image: "registry.example.com/other/pause:3.9", expected: false},
+		{pattern: "registry.*", image: "registry.k8s.io/pause:3.9", expected: false},
+		{pattern: "registry.*.io", image: "registry.k8s.io/pause:3.9", expected: true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, matchImage(test.pattern, test.image), "%s %s", test.pattern, test.image)
+	}
+}
+
+func TestGetCredentials(t *testing.T) {
+	binDir := t.TempDir()
+	oldConfigPath := ConfigPath
+	t.Cleanup(func() { ConfigPath = oldConfigPath })
+	ConfigPath = path.Join(t.TempDir(), "config.json")
+	image := "registry.example.com:5000/team/pause:3.9"
+	options := api.ImageCredentialProviderOptions{BinDir: binDir}
+
+	// kubelet has not been configured
+	credentials, err := GetCredentials(context.Background(), options, image)
+	assert.NoError(t, err)
+	assert.Nil(t, credentials)
+
+	assert.NoError(t, os.WriteFile(ConfigPath, []byte(`{
+		"apiVersion": "kubelet.config.k8s.io/v1",
+		"kind": "CredentialProviderConfig",
+		"providers": [
+			{
+				"name": "ecr-credential-provider",
+				"matchImages": ["*.dkr.ecr.*.amazonaws.com"],
+				"defaultCacheDuration": "12h",
+				"apiVersion": "credentialprovider.kubelet.k8s.io/v1"
+			},
+			{
+				"name": "registry-helper",
+				"matchImages": ["registry.example.com:5000"],
+				"defaultCacheDuration": "12h",
+				"apiVersion": "credentialprovider.kubelet.k8s.io/v1",
+				"args": ["team"],
+				"env": [{"name": "PASSWORD", "value": "secret"}]
+			}
+		]
+	}`), 0644))
+	// the helper checks the request on stdin, and answers for the registry and
+	// for the team, which is the more specific match
+	assert.NoError(t, os.WriteFile(path.Join(binDir, "registry-helper"), []byte(`#!/usr/bin/env bash
+grep -q '"image":"registry.example.com:5000/team/pause:3.9"' || exit 1
+cat <<EOT
+{
+	"apiVersion": "credentialprovider.kubelet.k8s.io/v1",
+	"kind": "CredentialProviderResponse",
+	"cacheKeyType": "Registry",
+	"auth": {
+		"registry.example.com:5000": {"username": "registry", "password": "$PASSWORD"},
+		"registry.example.com:5000/$1": {"username": "$1", "password": "$PASSWORD"}
+	}
+}
+EOT
+`), 0755))
+
+	credentials, err = GetCredentials(context.Background(), options, image)
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Username: "team", Password: "secret"}, credentials)
+
+	// no provider matches
+	credentials, err = GetCredentials(context.Background(), options, "registry.k8s.io/pause:3.9")
+	assert.NoError(t, err)
+	assert.Nil(t, credentials)
+
+	// the provider fails
+	credentials, err = GetCredentials(context.Background(), options, "registry.example.com:5000/other/pause:3.9")
+	assert.Error(t, err)
+	assert.Nil(t, credentials)
+}
@@ -0,0 +1,73 @@
+package credentialprovider
+
+import (
+	"os"
+	"path"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const (
+	// #nosec G101 //constant path, not credential
+	root = "/etc/eks/image-credential-provider"
+	// #nosec G101 //constant path, not credential
+	BinPathEnvironmentName = "ECR_CREDENTIAL_PROVIDER_BIN_PATH"
+)
+
+var (
+	// ConfigPath is the CredentialProviderConfig that nodeadm writes for kubelet
+	ConfigPath = path.Join(root, "config.json")
+
+	// ECRMatchImages are the Amazon ECR registry hosts in every partition
+	ECRMatchImages = []string{
+		"*.dkr.ecr.*.amazonaws.com",
+		"*.dkr.ecr.*.amazonaws.com.cn",
+		"*.dkr.ecr-fips.*.amazonaws.com",
+		"*.dkr.ecr.*.c2s.ic.gov",
+		"*.dkr.ecr.*.sc2s.sgov.gov",
+	}
+)
+
+// Config is the CredentialProviderConfig read by kubelet, which has the same
+// fields in every API version
+type Config struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Providers  []Provider `json:"providers"`
+}
+
+type Provider struct {
+	Name                 string   `json:"name"`
+	MatchImages          []string `json:"matchImages"`
+	DefaultCacheDuration string   `json:"defaultCacheDuration"`
+	APIVersion           string   `json:"apiVersion"`
+	Args                 []string `json:"args,omitempty"`
+	Env                  []EnvVar `json:"env,omitempty"`
+}
+
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ResolveProviders returns the provider bin directory and the providers,
+// defaulting to the ECR credential provider
+func ResolveProviders(options api.ImageCredentialProviderOptions) (string, []api.ImageCredentialProvider) {
+	// fallback default for image credential provider binary if not overridden
+	ecrCredentialProviderBinPath := path.Join(root, api.ECRCredentialProviderName)
+	if binPath, set := os.LookupEnv(BinPathEnvironmentName); set {
+		zap.L().Info("picked up image credential provider binary path from environment", zap.String("bin-path", binPath))
+		ecrCredentialProviderBinPath = binPath
+	}
+	binDir := path.Dir(ecrCredentialProviderBinPath)
+	if options.BinDir != "" {
+		binDir = options.BinDir
+	}
+	providers := options.Providers
+	if len(providers) == 0 {
+		providers = []api.ImageCredentialProvider{{Name: path.Base(ecrCredentialProviderBinPath), MatchImages: ECRMatchImages}}
+	}
+	return binDir, providers
+}
@@ -0,0 +1,85 @@
+// Package daemontest provides a fake daemon manager for tests.
+package daemontest
+
+import (
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+var _ daemon.DaemonManager = &FakeDaemonManager{}
+
+// FakeDaemonManager records the operations on daemons instead of performing
+// them. Every method succeeds, so tests only set up what they inspect.
+type FakeDaemonManager struct {
+	// Statuses are returned by GetDaemonStatus. Daemons without a status are
+	// in the unknown state.
+	Statuses map[string]daemon.DaemonStatus
+	// SystemDaemons is returned by ManagesSystemDaemons.
+	SystemDaemons bool
+
+	Started   []string
+	Stopped   []string
+	Restarted []string
+	Enabled   []string
+	Disabled  []string
+	// DropIns are the contents of the written drop-ins, keyed by
+	// "<daemon>/<drop-in>".
+	DropIns map[string]string
+	// Reloads counts the calls to Reload.
+	Reloads int
+}
+
+func (m *FakeDaemonManager) StartDaemon(name string) error {
+	m.Started = append(m.Started, name)
+	return nil
+}
+
+func (m *FakeDaemonManager) StopDaemon(name string) error {
+	m.Stopped = append(m.Stopped, name)
+	return nil
+}
+
+func (m *FakeDaemonManager) RestartDaemon(name string) error {
+	m.Restarted = append(m.Restarted, name)
+	return nil
+}
+
+func (m *FakeDaemonManager) GetDaemonStatus(name string) (daemon.DaemonStatus, error) {
+	if status, ok := m.Statuses[name]; ok {
+		return status, nil
+	}
+	return daemon.DaemonStatus{State: daemon.DaemonStateUnknown}, nil
+}
+
+func (m *FakeDaemonManager) EnableDaemon(name string) error {
+	m.Enabled = append(m.Enabled, name)
+	return nil
+}
+
+func (m *FakeDaemonManager) DisableDaemon(name string) error {
+	m.Disabled = append(m.Disabled, name)
+	return nil
+}
+
+func (m *FakeDaemonManager) WriteDropIn(name string, dropInName string, content []byte) error {
+	if m.DropIns == nil {
+		m.DropIns = map[string]string{}
+	}
+	m.DropIns[name+"/"+dropInName] = string(content)
+	return nil
+}
+
+func (m *FakeDaemonManager) RemoveDropIn(name string, dropInName string) error {
+	delete(m.DropIns, name+"/"+dropInName)
+	return nil
+}
+
+func (m *FakeDaemonManager) Reload() error {
+	m.Reloads++
+	return nil
+}
+
+func (m *FakeDaemonManager) ManagesSystemDaemons() bool {
+	return m.SystemDaemons
+}
+
+func (m *FakeDaemonManager) Close() {}
@@ -0,0 +1,84 @@
+package daemon
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// unitConfigRoot holds the unit files and drop-ins of the host
+const unitConfigRoot = "/etc/systemd/system"
+
+// nodeConfigDropInName is the drop-in holding the unit settings from the
+// NodeConfig. It sorts after the drop-ins shipped with the AMI so that its
+// settings take precedence.
+const nodeConfigDropInName = "90-nodeadm"
+
+// ConfigureSystemdOptions writes the drop-in for the daemon's unit settings, or
+// removes it if no settings are specified.
+func ConfigureSystemdOptions(daemonManager DaemonManager, name string, options api.SystemdOptions) error {
+	content := renderSystemdDropIn(options)
+	if content == nil {
+		return daemonManager.RemoveDropIn(name, nodeConfigDropInName)
+	}
+	return daemonManager.WriteDropIn(name, nodeConfigDropInName, content)
+}
+
+func renderSystemdDropIn(options api.SystemdOptions) []byte {
+	var unit, service []string
+	if len(options.After) > 0 {
+		unit = append(unit, "After="+strings.Join(options.After, " "))
+	}
+	var envKeys []string
+	for key := range options.Environment {
+		envKeys = append(envKeys, key)
+	}
+	sort.Strings(envKeys)
+	for _, key := range envKeys {
+		service = append(service, fmt.Sprintf("Environment=\"%s\"", escapeUnitValue(key+"="+options.Environment[key])))
+	}
+	for _, setting := range []struct{ key, value string }{
+		{"LimitNOFILE", options.LimitNOFILE},
+		{"MemoryHigh", options.MemoryHigh},
+		{"CPUWeight", options.CPUWeight},
+		{"Slice", options.Slice},
+		{"Restart", options.Restart},
+	} {
+		if setting.value != "" {
+			service = append(service, setting.key+"="+setting.value)
+		}
+	}
+	if len(unit) == 0 && len(service) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	buf.WriteString("# Generated by nodeadm from the NodeConfig, do not edit.\n")
+	if len(unit) > 0 {
+		buf.WriteString("\n[Unit]\n" + strings.Join(unit, "\n") + "\n")
+	}
+	if len(service) > 0 {
+		buf.WriteString("\n[Service]\n" + strings.Join(service, "\n") + "\n")
+	}
+	return buf.Bytes()
+}
+
+// escapeUnitValue escapes a value for use within a double-quoted unit file
+// setting, including systemd's % specifiers.
+func escapeUnitValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "%", "%%").Replace(value)
+}
+
+// ReloadRestoredDropIns reloads the unit configuration if any of the files
+// that a rolled back FileTransaction restored is a unit drop-in, so that the
+// units do not keep the settings of the failed operation.
+func ReloadRestoredDropIns(daemonManager DaemonManager, files []string) error {
+	for _, file := range files {
+		if strings.HasPrefix(file, unitConfigRoot+"/") {
+			return daemonManager.Reload()
+		}
+	}
+	return nil
+}
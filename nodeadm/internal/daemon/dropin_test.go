@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSystemdDropIn(t *testing.T) {
+	var tests = []struct {
+		name            string
+		options         api.SystemdOptions
+		expectedContent string
+	}{
+		{
+			name:            "no options",
+			options:         api.SystemdOptions{},
+			expectedContent: "",
+		},
+		{
+			name: "service options",
+			options: api.SystemdOptions{
+				Environment: map[string]string{
+					"HTTPS_PROXY": "http://proxy:3128",
+					"ARGS":        `--v=2 "quoted" 100%`,
+				},
+				LimitNOFILE: "1048576",
+				Slice:       "runtime.slice",
+			},
+			expectedContent: `# Generated by nodeadm from the NodeConfig, do not edit.
+
+[Service]
+Environment="ARGS=--v=2 \"quoted\" 100%%"
+Environment="HTTPS_PROXY=http://proxy:3128"
+LimitNOFILE=1048576
+Slice=runtime.slice
+`,
+		},
+		{
+			name: "all options",
+			options: api.SystemdOptions{
+				After:       []string{"network-online.target", "custom.service"},
+				LimitNOFILE: "infinity",
+				MemoryHigh:  "4G",
+				CPUWeight:   "500",
+				Slice:       "system.slice",
+				Restart:     "always",
+			},
+			expectedContent: `# Generated by nodeadm from the NodeConfig, do not edit.
+
+[Unit]
+After=network-online.target custom.service
+
+[Service]
+LimitNOFILE=infinity
+MemoryHigh=4G
+CPUWeight=500
+Slice=system.slice
+Restart=always
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedContent, string(renderSystemdDropIn(test.options)))
+		})
+	}
+}
+
+type reloadCountingDaemonManager struct {
+	DaemonManager
+	reloads int
+}
+
+func (m *reloadCountingDaemonManager) Reload() error {
+	m.reloads++
+	return nil
+}
+
+func TestReloadRestoredDropIns(t *testing.T) {
+	daemonManager := &reloadCountingDaemonManager{}
+	assert.NoError(t, ReloadRestoredDropIns(daemonManager, []string{"/etc/kubernetes/kubelet/config.json"}))
+	assert.Equal(t, 0, daemonManager.reloads)
+
+	assert.NoError(t, ReloadRestoredDropIns(daemonManager, []string{
+		"/etc/kubernetes/kubelet/config.json",
+		"/etc/systemd/system/kubelet.service.d/90-nodeadm.conf",
+		"/etc/systemd/system/containerd.service.d/90-nodeadm.conf",
+	}))
+	assert.Equal(t, 1, daemonManager.reloads)
+}
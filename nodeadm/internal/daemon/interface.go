@@ -11,6 +11,11 @@ type Daemon interface {
 	// If the daemon is already running, and has been re-configured, it will be restarted.
 	EnsureRunning() error
 
+	// WaitUntilReady blocks until the daemon that was started by EnsureRunning
+	// is ready to serve, or returns an error describing which readiness
+	// condition did not become true.
+	WaitUntilReady(*api.NodeConfig) error
+
 	// PostLaunch runs any additional step that needs to occur after the service
 	// daemon as been started
 	PostLaunch(*api.NodeConfig) error
@@ -18,3 +23,9 @@ type Daemon interface {
 	// Name returns the name of the daemon.
 	Name() string
 }
+
+// DependentDaemon is implemented by daemons that must be started after other
+// daemons are ready. Dependencies returns the names of those daemons.
+type DependentDaemon interface {
+	Dependencies() []string
+}
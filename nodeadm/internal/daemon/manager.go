@@ -1,13 +1,65 @@
 package daemon
 
-type DaemonStatus string
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type DaemonState string
 
 const (
-	DaemonStatusRunning DaemonStatus = "running"
-	DaemonStatusStopped DaemonStatus = "stopped"
-	DaemonStatusUnknown DaemonStatus = "unknown"
+	DaemonStateRunning  DaemonState = "running"
+	DaemonStateStarting DaemonState = "starting"
+	DaemonStateStopping DaemonState = "stopping"
+	DaemonStateStopped  DaemonState = "stopped"
+	DaemonStateFailed   DaemonState = "failed"
+	DaemonStateUnknown  DaemonState = "unknown"
 )
 
+// daemonStatusLogLines is the number of recent log lines included in a
+// DaemonStatus.
+const daemonStatusLogLines = 20
+
+type DaemonStatus struct {
+	// State summarizes the state of the daemon.
+	State DaemonState
+	// ActiveState and SubState are the daemon's state in the terms of the
+	// daemon manager, such as systemd's `activating` and `auto-restart`.
+	ActiveState string
+	SubState    string
+	// NRestarts is the number of times the daemon was automatically restarted.
+	NRestarts uint32
+	// ExecMainStatus is the exit code or signal of the daemon's last exit.
+	ExecMainStatus int32
+	// ActiveEnterTimestamp is when the daemon last entered the active state.
+	ActiveEnterTimestamp time.Time
+	// RecentLogs are the last lines logged by the daemon, if available.
+	RecentLogs []string
+}
+
+// String returns a description of the status suitable for error messages.
+func (s DaemonStatus) String() string {
+	description := fmt.Sprintf("%s (%s/%s), restarts: %d, last exit status: %d", s.State, s.ActiveState, s.SubState, s.NRestarts, s.ExecMainStatus)
+	if !s.ActiveEnterTimestamp.IsZero() {
+		description += fmt.Sprintf(", active since: %s", s.ActiveEnterTimestamp.Format(time.RFC3339))
+	}
+	if len(s.RecentLogs) > 0 {
+		description += "\nrecent logs:\n" + strings.Join(s.RecentLogs, "\n")
+	}
+	return description
+}
+
+// ErrorWithStatus annotates an error about a daemon with the daemon's status,
+// so that the error explains why the daemon is not working.
+func ErrorWithStatus(err error, daemonManager DaemonManager, name string) error {
+	status, statusErr := daemonManager.GetDaemonStatus(name)
+	if statusErr != nil {
+		return fmt.Errorf("%w (failed to get %s status: %v)", err, name, statusErr)
+	}
+	return fmt.Errorf("%w\n%s status: %s", err, name, status)
+}
+
 type DaemonManager interface {
 	// StartDaemon starts the daemon with the given name.
 	// If the daemon is already running, this is a no-op.
@@ -26,6 +78,18 @@ type DaemonManager interface {
 	// DisableDaemon disables the daemon with the given name.
 	// If the daemon is not enabled, this is a no-op.
 	DisableDaemon(name string) error
+	// WriteDropIn writes a drop-in with the given name for the daemon's unit,
+	// reloading the unit configuration if the drop-in changed.
+	WriteDropIn(name string, dropInName string, content []byte) error
+	// RemoveDropIn removes a drop-in with the given name from the daemon's
+	// unit, reloading the unit configuration if it existed.
+	RemoveDropIn(name string, dropInName string) error
+	// Reload reloads the unit configuration, such as after drop-ins were
+	// restored by rolling back a FileTransaction.
+	Reload() error
+	// ManagesSystemDaemons reports whether the daemon manager also controls
+	// the daemons of the host that nodeadm does not run, like systemd-logind.
+	ManagesSystemDaemons() bool
 	// Close cleans up any underlying resources used by the daemon manager.
 	Close()
 }
@@ -23,7 +23,7 @@ func (m *noopDaemonManager) RestartDaemon(name string) error {
 }
 
 func (m *noopDaemonManager) GetDaemonStatus(name string) (DaemonStatus, error) {
-	return DaemonStatusUnknown, nil
+	return DaemonStatus{State: DaemonStateUnknown}, nil
 }
 
 func (m *noopDaemonManager) EnableDaemon(name string) error {
@@ -34,4 +34,20 @@ func (m *noopDaemonManager) DisableDaemon(name string) error {
 	return nil
 }
 
+func (m *noopDaemonManager) WriteDropIn(name string, dropInName string, content []byte) error {
+	return nil
+}
+
+func (m *noopDaemonManager) RemoveDropIn(name string, dropInName string) error {
+	return nil
+}
+
+func (m *noopDaemonManager) Reload() error {
+	return nil
+}
+
+func (m *noopDaemonManager) ManagesSystemDaemons() bool {
+	return false
+}
+
 func (m *noopDaemonManager) Close() {}
@@ -0,0 +1,377 @@
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var _ DaemonManager = &processDaemonManager{}
+
+// ProcessSpec describes how to run a daemon as a child process. It mirrors the
+// ExecStartPre, ExecStart and EnvironmentFile settings of the daemon's systemd
+// unit.
+type ProcessSpec struct {
+	// PreStart are commands run to completion before the daemon is started.
+	PreStart [][]string
+	// Command is the daemon's executable followed by its arguments. Arguments
+	// may reference variables from the environment files, where `$VAR` is
+	// split on whitespace into multiple arguments and `${VAR}` is not.
+	Command []string
+	// EnvironmentFiles are files of KEY=VALUE lines added to the daemon's
+	// environment. Files that do not exist are ignored.
+	EnvironmentFiles []string
+}
+
+const processStopTimeout = 10 * time.Second
+
+var (
+	// processRestartBackoff is the initial delay before restarting a daemon
+	// that exited, doubled for each consecutive crash up to the maximum.
+	processRestartBackoff    = time.Second
+	processRestartBackoffMax = 30 * time.Second
+	// processStableDuration is how long a daemon must run for it to be
+	// considered healthy, resetting the restart backoff.
+	processStableDuration = 10 * time.Second
+)
+
+type process struct {
+	spec       ProcessSpec
+	cmd        *exec.Cmd
+	running    bool
+	stopped    bool
+	stop       chan struct{}
+	done       chan struct{}
+	restarts   uint32
+	exitStatus int32
+	startTime  time.Time
+	logs       *lineBuffer
+}
+
+type processDaemonManager struct {
+	mu        sync.Mutex
+	processes map[string]*process
+}
+
+// NewProcessDaemonManager returns a DaemonManager that runs daemons as child
+// processes of nodeadm and restarts them if they exit, for hosts and
+// containers without systemd. The child processes only live as long as
+// nodeadm, so the caller must keep running while the daemons are in use.
+func NewProcessDaemonManager(specs map[string]ProcessSpec) DaemonManager {
+	processes := make(map[string]*process)
+	for name, spec := range specs {
+		processes[name] = &process{spec: spec, stopped: true, logs: newLineBuffer(daemonStatusLogLines)}
+	}
+	return &processDaemonManager{processes: processes}
+}
+
+func (m *processDaemonManager) getProcess(name string) (*process, error) {
+	p, ok := m.processes[name]
+	if !ok {
+		return nil, fmt.Errorf("no process is defined for daemon %s", name)
+	}
+	return p, nil
+}
+
+func (m *processDaemonManager) StartDaemon(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, err := m.getProcess(name)
+	if err != nil {
+		return err
+	}
+	if !p.stopped {
+		return nil
+	}
+	for _, preStart := range p.spec.PreStart {
+		if out, err := exec.Command(preStart[0], preStart[1:]...).CombinedOutput(); err != nil {
+			return fmt.Errorf("pre-start command %q for daemon %s failed: %v: %s", strings.Join(preStart, " "), name, err, out)
+		}
+	}
+	if err := m.startProcess(name, p); err != nil {
+		return err
+	}
+	p.stopped = false
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go m.supervise(name, p, p.stop, p.done)
+	return nil
+}
+
+// startProcess launches the daemon's command. The caller must hold the lock.
+func (m *processDaemonManager) startProcess(name string, p *process) error {
+	env, err := readEnvironmentFiles(p.spec.EnvironmentFiles)
+	if err != nil {
+		return err
+	}
+	args := expandCommand(p.spec.Command, env)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = os.Environ()
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	cmd.Stdout = io.MultiWriter(os.Stdout, p.logs)
+	cmd.Stderr = io.MultiWriter(os.Stderr, p.logs)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	zap.L().Info("Started daemon process", zap.String("name", name), zap.Int("pid", cmd.Process.Pid))
+	p.cmd = cmd
+	p.running = true
+	p.startTime = time.Now()
+	return nil
+}
+
+// supervise waits for the daemon's process to exit and restarts it with an
+// exponential backoff until the daemon is stopped.
+func (m *processDaemonManager) supervise(name string, p *process, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	backoff := processRestartBackoff
+	for {
+		m.mu.Lock()
+		cmd := p.cmd
+		m.mu.Unlock()
+
+		startTime := time.Now()
+		err := cmd.Wait()
+
+		m.mu.Lock()
+		p.running = false
+		p.exitStatus = int32(cmd.ProcessState.ExitCode())
+		if p.stopped {
+			m.mu.Unlock()
+			return
+		}
+		m.mu.Unlock()
+
+		if time.Since(startTime) >= processStableDuration {
+			backoff = processRestartBackoff
+		}
+		zap.L().Warn("Daemon process exited, restarting..", zap.String("name", name), zap.Error(err), zap.Duration("backoff", backoff))
+		select {
+		case <-stop:
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, processRestartBackoffMax)
+
+		m.mu.Lock()
+		if p.stopped {
+			m.mu.Unlock()
+			return
+		}
+		if err := m.startProcess(name, p); err != nil {
+			zap.L().Error("Failed to restart daemon process", zap.String("name", name), zap.Error(err))
+			p.stopped = true
+			m.mu.Unlock()
+			return
+		}
+		p.restarts++
+		m.mu.Unlock()
+	}
+}
+
+func (m *processDaemonManager) StopDaemon(name string) error {
+	m.mu.Lock()
+	p, err := m.getProcess(name)
+	if err != nil {
+		m.mu.Unlock()
+		return err
+	}
+	if p.stopped {
+		m.mu.Unlock()
+		return nil
+	}
+	p.stopped = true
+	close(p.stop)
+	if p.running {
+		if err := p.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+			zap.L().Warn("Failed to signal daemon process", zap.String("name", name), zap.Error(err))
+		}
+	}
+	cmd, done := p.cmd, p.done
+	m.mu.Unlock()
+
+	select {
+	case <-done:
+	case <-time.After(processStopTimeout):
+		// the process can only still be running here, as the supervisor
+		// returns immediately when stopped during a restart backoff
+		zap.L().Warn("Daemon process did not stop in time, killing..", zap.String("name", name))
+		if err := cmd.Process.Kill(); err != nil {
+			return err
+		}
+		<-done
+	}
+	return nil
+}
+
+func (m *processDaemonManager) RestartDaemon(name string) error {
+	if err := m.StopDaemon(name); err != nil {
+		return err
+	}
+	return m.StartDaemon(name)
+}
+
+func (m *processDaemonManager) GetDaemonStatus(name string) (DaemonStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, err := m.getProcess(name)
+	if err != nil {
+		return DaemonStatus{State: DaemonStateUnknown}, err
+	}
+	// the states follow systemd's, to be consistent between daemon managers
+	status := DaemonStatus{
+		NRestarts:      p.restarts,
+		ExecMainStatus: p.exitStatus,
+		RecentLogs:     p.logs.Lines(),
+	}
+	switch {
+	case p.running:
+		status.State, status.ActiveState, status.SubState = DaemonStateRunning, "active", "running"
+		status.ActiveEnterTimestamp = p.startTime
+	case !p.stopped:
+		status.State, status.ActiveState, status.SubState = DaemonStateStarting, "activating", "auto-restart"
+	default:
+		status.State, status.ActiveState, status.SubState = DaemonStateStopped, "inactive", "dead"
+	}
+	return status, nil
+}
+
+// EnableDaemon is a no-op, daemons only run while nodeadm supervises them.
+func (m *processDaemonManager) EnableDaemon(name string) error {
+	return nil
+}
+
+// DisableDaemon is a no-op, daemons only run while nodeadm supervises them.
+func (m *processDaemonManager) DisableDaemon(name string) error {
+	return nil
+}
+
+func (m *processDaemonManager) WriteDropIn(name string, dropInName string, content []byte) error {
+	zap.L().Warn("Ignoring systemd drop-in, which is not supported without systemd", zap.String("name", name), zap.String("dropIn", dropInName))
+	return nil
+}
+
+func (m *processDaemonManager) RemoveDropIn(name string, dropInName string) error {
+	return nil
+}
+
+func (m *processDaemonManager) Reload() error {
+	return nil
+}
+
+// ManagesSystemDaemons is false, only the daemons with a ProcessSpec are run.
+func (m *processDaemonManager) ManagesSystemDaemons() bool {
+	return false
+}
+
+// Close stops every daemon that is running.
+func (m *processDaemonManager) Close() {
+	for name := range m.processes {
+		if err := m.StopDaemon(name); err != nil {
+			zap.L().Error("Failed to stop daemon process", zap.String("name", name), zap.Error(err))
+		}
+	}
+}
+
+// readEnvironmentFiles parses files in the format of systemd's EnvironmentFile
+// setting. Later files take precedence over earlier ones.
+func readEnvironmentFiles(paths []string) (map[string]string, error) {
+	env := make(map[string]string)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+				continue
+			}
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid line in environment file %s: %s", path, line)
+			}
+			value = strings.TrimSpace(value)
+			if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1]
+			}
+			env[strings.TrimSpace(key)] = value
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return env, nil
+}
+
+// lineBuffer is an io.Writer that keeps the last lines written to it.
+type lineBuffer struct {
+	mu      sync.Mutex
+	lines   []string
+	partial []byte
+	max     int
+}
+
+func newLineBuffer(max int) *lineBuffer {
+	return &lineBuffer{max: max}
+}
+
+func (b *lineBuffer) Write(data []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.partial = append(b.partial, data...)
+	for {
+		i := bytes.IndexByte(b.partial, '\n')
+		if i < 0 {
+			break
+		}
+		b.lines = append(b.lines, string(b.partial[:i]))
+		b.partial = b.partial[i+1:]
+	}
+	if len(b.lines) > b.max {
+		b.lines = b.lines[len(b.lines)-b.max:]
+	}
+	return len(data), nil
+}
+
+// Lines returns a copy of the buffered lines.
+func (b *lineBuffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.lines...)
+}
+
+var commandVariableRegex = regexp.MustCompile(`\$\{(\w+)\}|\$(\w+)`)
+
+// expandCommand substitutes environment variables in the command the same way
+// systemd does for ExecStart. An argument that is exactly `$VAR` is split on
+// whitespace into separate arguments, while `${VAR}` always expands in place.
+func expandCommand(command []string, env map[string]string) []string {
+	var args []string
+	for _, arg := range command {
+		if m := commandVariableRegex.FindStringSubmatch(arg); m != nil && m[2] != "" && m[0] == arg {
+			args = append(args, strings.Fields(env[m[2]])...)
+			continue
+		}
+		args = append(args, commandVariableRegex.ReplaceAllStringFunc(arg, func(ref string) string {
+			m := commandVariableRegex.FindStringSubmatch(ref)
+			return env[m[1]+m[2]]
+		}))
+	}
+	return args
+}
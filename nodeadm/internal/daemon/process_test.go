@@ -0,0 +1,82 @@
+package daemon
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadEnvironmentFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := path.Join(dir, "first")
+	second := path.Join(dir, "second")
+	assert.NoError(t, os.WriteFile(first, []byte("# comment\nA=1\nB=\"two words\"\n\nC='3'\n"), 0644))
+	assert.NoError(t, os.WriteFile(second, []byte("A=override\n"), 0644))
+
+	env, err := readEnvironmentFiles([]string{first, second, path.Join(dir, "missing")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "override", "B": "two words", "C": "3"}, env)
+}
+
+func TestExpandCommand(t *testing.T) {
+	env := map[string]string{"ARGS": "--a=1  --b=2", "NAME": "x y"}
+	var tests = []struct {
+		command      []string
+		expectedArgs []string
+	}{
+		{command: []string{"/bin/cmd", "$ARGS"}, expectedArgs: []string{"/bin/cmd", "--a=1", "--b=2"}},
+		{command: []string{"/bin/cmd", "${ARGS}"}, expectedArgs: []string{"/bin/cmd", "--a=1  --b=2"}},
+		{command: []string{"/bin/cmd", "--name=$NAME"}, expectedArgs: []string{"/bin/cmd", "--name=x y"}},
+		{command: []string{"/bin/cmd", "$MISSING"}, expectedArgs: []string{"/bin/cmd"}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expectedArgs, expandCommand(test.command, env))
+	}
+}
+
+func TestProcessDaemonManager(t *testing.T) {
+	oldProcessRestartBackoff := processRestartBackoff
+	t.Cleanup(func() { processRestartBackoff = oldProcessRestartBackoff })
+	processRestartBackoff = time.Millisecond
+	counter := path.Join(t.TempDir(), "counter")
+
+	manager := NewProcessDaemonManager(map[string]ProcessSpec{
+		"crashing": {Command: []string{"/bin/sh", "-c", "echo >> " + counter + "; echo crashing; exit 1"}},
+		"sleeping": {Command: []string{"/bin/sh", "-c", "exec sleep 60"}},
+	})
+	defer manager.Close()
+
+	assert.NoError(t, manager.StartDaemon("sleeping"))
+	status, err := manager.GetDaemonStatus("sleeping")
+	assert.NoError(t, err)
+	assert.Equal(t, DaemonStateRunning, status.State)
+	assert.NoError(t, manager.StopDaemon("sleeping"))
+	status, err = manager.GetDaemonStatus("sleeping")
+	assert.NoError(t, err)
+	assert.Equal(t, DaemonStateStopped, status.State)
+
+	assert.NoError(t, manager.StartDaemon("crashing"))
+	assert.Eventually(t, func() bool {
+		data, _ := os.ReadFile(counter)
+		return len(data) >= 3
+	}, 5*time.Second, 10*time.Millisecond, "crashing daemon was not restarted")
+	status, err = manager.GetDaemonStatus("crashing")
+	assert.NoError(t, err)
+	assert.NotZero(t, status.NRestarts)
+	assert.Equal(t, int32(1), status.ExecMainStatus)
+	assert.Contains(t, status.RecentLogs, "crashing")
+	assert.NoError(t, manager.StopDaemon("crashing"))
+
+	assert.Error(t, manager.StartDaemon("unknown"))
+}
+
+func TestLineBuffer(t *testing.T) {
+	b := newLineBuffer(2)
+	b.Write([]byte("one\ntw"))
+	b.Write([]byte("o\nthree\nfour"))
+	assert.Equal(t, []string{"two", "three"}, b.Lines())
+}
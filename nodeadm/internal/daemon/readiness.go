@@ -0,0 +1,52 @@
+package daemon
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// readinessPollInterval is the time between successive evaluations of a
+// daemon's readiness conditions.
+var readinessPollInterval = time.Second
+
+// ReadinessCondition is a named check that must pass before a daemon is
+// considered ready. Check returns a human-readable reason when the condition is
+// not (yet) true. Errors are treated as the condition being false, because a
+// daemon that was just started will commonly refuse connections for a while.
+type ReadinessCondition struct {
+	Name  string
+	Check func() (ready bool, reason string, err error)
+}
+
+// WaitForReadiness evaluates the conditions in order, polling each one until it
+// is true. If any condition is still false once the timeout has elapsed, the
+// returned error names that condition and the last reason it reported.
+func WaitForReadiness(daemonName string, timeout time.Duration, conditions ...ReadinessCondition) error {
+	deadline := time.Now().Add(timeout)
+	for _, condition := range conditions {
+		fields := []zap.Field{zap.String("name", daemonName), zap.String("condition", condition.Name)}
+		zap.L().Info("Waiting for readiness condition..", fields...)
+		var reason string
+		for {
+			ready, msg, err := condition.Check()
+			if err != nil {
+				msg = err.Error()
+			}
+			if ready {
+				break
+			}
+			if msg != reason {
+				zap.L().Info("Readiness condition is not yet true", append(fields, zap.String("reason", msg))...)
+				reason = msg
+			}
+			if !time.Now().Before(deadline) {
+				return fmt.Errorf("%s did not become ready within %s: condition %s never became true: %s", daemonName, timeout, condition.Name, reason)
+			}
+			time.Sleep(readinessPollInterval)
+		}
+		zap.L().Info("Readiness condition is true", fields...)
+	}
+	return nil
+}
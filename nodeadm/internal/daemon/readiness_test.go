@@ -0,0 +1,68 @@
+package daemon
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForReadiness(t *testing.T) {
+	oldReadinessPollInterval := readinessPollInterval
+	t.Cleanup(func() { readinessPollInterval = oldReadinessPollInterval })
+	readinessPollInterval = time.Millisecond
+
+	var tests = []struct {
+		name          string
+		conditions    []ReadinessCondition
+		expectedError string
+	}{
+		{
+			name: "all conditions true",
+			conditions: []ReadinessCondition{
+				{Name: "A", Check: func() (bool, string, error) { return true, "", nil }},
+				{Name: "B", Check: func() (bool, string, error) { return true, "", nil }},
+			},
+		},
+		{
+			name: "condition eventually true",
+			conditions: []ReadinessCondition{
+				{Name: "A", Check: eventually(3)},
+			},
+		},
+		{
+			name: "condition never true",
+			conditions: []ReadinessCondition{
+				{Name: "A", Check: func() (bool, string, error) { return true, "", nil }},
+				{Name: "B", Check: func() (bool, string, error) { return false, "still starting", nil }},
+			},
+			expectedError: "test did not become ready within 20ms: condition B never became true: still starting",
+		},
+		{
+			name: "condition check errors",
+			conditions: []ReadinessCondition{
+				{Name: "A", Check: func() (bool, string, error) { return false, "", errors.New("connection refused") }},
+			},
+			expectedError: "test did not become ready within 20ms: condition A never became true: connection refused",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := WaitForReadiness("test", 20*time.Millisecond, test.conditions...)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func eventually(attempts int) func() (bool, string, error) {
+	return func() (bool, string, error) {
+		attempts--
+		return attempts <= 0, "not yet", nil
+	}
+}
@@ -3,10 +3,22 @@
 package daemon
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/coreos/go-systemd/v22/dbus"
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
 )
 
 var _ DaemonManager = &systemdDaemonManager{}
@@ -15,6 +27,10 @@ type systemdDaemonManager struct {
 	conn *dbus.Conn
 }
 
+const (
+	dropInPerm = 0644
+)
+
 const (
 	ModeReplace = "replace"
 	TypeSymlink = "symlink"
@@ -51,18 +67,53 @@ func (m *systemdDaemonManager) RestartDaemon(name string) error {
 
 func (m *systemdDaemonManager) GetDaemonStatus(name string) (DaemonStatus, error) {
 	unitName := getServiceUnitName(name)
-	status, err := m.conn.GetUnitPropertyContext(context.TODO(), unitName, "ActiveState")
+	unitProperties, err := m.conn.GetUnitPropertiesContext(context.TODO(), unitName)
 	if err != nil {
-		return DaemonStatusUnknown, err
+		return DaemonStatus{State: DaemonStateUnknown}, err
 	}
-	switch status.Value.String() {
+	serviceProperties, err := m.conn.GetUnitTypePropertiesContext(context.TODO(), unitName, "Service")
+	if err != nil {
+		return DaemonStatus{State: DaemonStateUnknown}, err
+	}
+	status := DaemonStatus{}
+	status.ActiveState, _ = unitProperties["ActiveState"].(string)
+	status.SubState, _ = unitProperties["SubState"].(string)
+	status.NRestarts, _ = serviceProperties["NRestarts"].(uint32)
+	status.ExecMainStatus, _ = serviceProperties["ExecMainStatus"].(int32)
+	if timestamp, _ := unitProperties["ActiveEnterTimestamp"].(uint64); timestamp > 0 {
+		status.ActiveEnterTimestamp = time.UnixMicro(int64(timestamp))
+	}
+	switch status.ActiveState {
 	case "active":
-		return DaemonStatusRunning, nil
+		status.State = DaemonStateRunning
+	case "activating", "reloading":
+		status.State = DaemonStateStarting
+	case "deactivating":
+		status.State = DaemonStateStopping
 	case "inactive":
-		return DaemonStatusStopped, nil
+		status.State = DaemonStateStopped
+	case "failed":
+		status.State = DaemonStateFailed
 	default:
-		return DaemonStatusUnknown, nil
+		status.State = DaemonStateUnknown
+	}
+	status.RecentLogs = getJournalLines(unitName, daemonStatusLogLines)
+	return status, nil
+}
+
+// getJournalLines returns the last lines logged by a unit. The logs are only
+// informational, so failing to read the journal is not an error.
+func getJournalLines(unitName string, lines int) []string {
+	out, err := exec.Command("journalctl", "--unit", unitName, "--lines", strconv.Itoa(lines), "--no-pager", "--output", "cat").Output()
+	if err != nil {
+		zap.L().Warn("Failed to read journal", zap.String("unit", unitName), zap.Error(err))
+		return nil
+	}
+	trimmed := strings.TrimRight(string(out), "\n")
+	if trimmed == "" {
+		return nil
 	}
+	return strings.Split(trimmed, "\n")
 }
 
 func (m *systemdDaemonManager) EnableDaemon(name string) error {
@@ -95,10 +146,53 @@ func (m *systemdDaemonManager) DisableDaemon(name string) error {
 	return nil
 }
 
+func (m *systemdDaemonManager) WriteDropIn(name string, dropInName string, content []byte) error {
+	dropInPath := getDropInPath(name, dropInName)
+	if existing, err := os.ReadFile(dropInPath); err == nil && bytes.Equal(existing, content) {
+		return nil
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := util.WriteFileWithDir(dropInPath, content, dropInPerm); err != nil {
+		return err
+	}
+	return m.conn.ReloadContext(context.TODO())
+}
+
+func (m *systemdDaemonManager) RemoveDropIn(name string, dropInName string) error {
+	dropInPath := getDropInPath(name, dropInName)
+	if exists, err := util.IsFilePathExists(dropInPath); err != nil {
+		return err
+	} else if !exists {
+		return nil
+	}
+	if err := util.RemoveFile(dropInPath); err != nil {
+		return err
+	}
+	return m.conn.ReloadContext(context.TODO())
+}
+
+func (m *systemdDaemonManager) Reload() error {
+	return m.conn.ReloadContext(context.TODO())
+}
+
+func (m *systemdDaemonManager) ManagesSystemDaemons() bool {
+	return true
+}
+
 func (m *systemdDaemonManager) Close() {
 	m.conn.Close()
 }
 
+// getServiceUnitName returns the unit of a daemon, which is a service unless
+// the name is already a slice unit, whose drop-ins configure resource control
 func getServiceUnitName(name string) string {
+	if strings.HasSuffix(name, ".slice") {
+		return name
+	}
 	return fmt.Sprintf("%s.service", name)
 }
+
+func getDropInPath(name string, dropInName string) string {
+	return path.Join(unitConfigRoot, getServiceUnitName(name)+".d", dropInName+".conf")
+}
@@ -0,0 +1,148 @@
+package dag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Graph is a set of named steps with dependencies between them. Steps are run
+// concurrently as soon as all of their dependencies have finished.
+type Graph struct {
+	steps map[string]*step
+	order []string
+}
+
+type step struct {
+	name      string
+	run       func() error
+	dependsOn []string
+}
+
+func New() *Graph {
+	return &Graph{steps: make(map[string]*step)}
+}
+
+// Add adds a step that runs after every step it depends on has succeeded.
+func (g *Graph) Add(name string, run func() error, dependsOn ...string) {
+	g.steps[name] = &step{name: name, run: run, dependsOn: dependsOn}
+	g.order = append(g.order, name)
+}
+
+// Run runs every step in the graph. Once a step fails no further steps are
+// started, and the error of the first failed step is returned after the steps
+// that are already running have finished.
+func (g *Graph) Run() error {
+	if err := g.validate(); err != nil {
+		return err
+	}
+	start := time.Now()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		firstErr  error
+		remaining = make(map[string]int)
+		children  = make(map[string][]string)
+	)
+	for _, name := range g.order {
+		remaining[name] = len(g.steps[name].dependsOn)
+		for _, dependency := range g.steps[name].dependsOn {
+			children[dependency] = append(children[dependency], name)
+		}
+	}
+
+	var launch func(name string)
+	launch = func(name string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := g.steps[name]
+			stepStart := time.Now()
+			zap.L().Info("Starting step..", zap.String("step", name))
+			err := s.run()
+			duration := time.Since(stepStart)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				zap.L().Error("Step failed", zap.String("step", name), zap.Duration("duration", duration), zap.Error(err))
+				if firstErr == nil {
+					firstErr = fmt.Errorf("step %s failed: %w", name, err)
+				}
+				return
+			}
+			zap.L().Info("Finished step", zap.String("step", name), zap.Duration("duration", duration))
+			if firstErr != nil {
+				return
+			}
+			for _, child := range children[name] {
+				remaining[child]--
+				if remaining[child] == 0 {
+					launch(child)
+				}
+			}
+		}()
+	}
+
+	mu.Lock()
+	for _, name := range g.order {
+		if remaining[name] == 0 {
+			launch(name)
+		}
+	}
+	mu.Unlock()
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	zap.L().Info("Finished all steps", zap.Int("steps", len(g.steps)), zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// validate checks that every dependency exists and that there are no cycles.
+func (g *Graph) validate() error {
+	for _, name := range g.order {
+		for _, dependency := range g.steps[name].dependsOn {
+			if _, ok := g.steps[dependency]; !ok {
+				return fmt.Errorf("step %s depends on unknown step %s", name, dependency)
+			}
+		}
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("dependency cycle between steps: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		dependencies := append([]string{}, g.steps[name].dependsOn...)
+		sort.Strings(dependencies)
+		for _, dependency := range dependencies {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, name := range g.order {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
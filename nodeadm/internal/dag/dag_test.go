@@ -0,0 +1,78 @@
+package dag
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunOrdersDependencies(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func() error {
+		return func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	g := New()
+	g.Add("c", record("c"), "a", "b")
+	g.Add("a", record("a"))
+	g.Add("b", record("b"), "a")
+	g.Add("d", record("d"), "c")
+
+	assert.NoError(t, g.Run())
+	assert.Equal(t, []string{"a", "b", "c", "d"}, order)
+}
+
+func TestRunIsConcurrent(t *testing.T) {
+	// both steps can only finish if they are running at the same time
+	var wg sync.WaitGroup
+	wg.Add(2)
+	step := func() error {
+		wg.Done()
+		done := make(chan struct{})
+		go func() { wg.Wait(); close(done) }()
+		select {
+		case <-done:
+			return nil
+		case <-time.After(5 * time.Second):
+			return errors.New("steps did not run concurrently")
+		}
+	}
+
+	g := New()
+	g.Add("a", step)
+	g.Add("b", step)
+	assert.NoError(t, g.Run())
+}
+
+func TestRunStopsAfterFailure(t *testing.T) {
+	var ran atomic.Bool
+	g := New()
+	g.Add("a", func() error { return errors.New("boom") })
+	g.Add("b", func() error { ran.Store(true); return nil }, "a")
+
+	assert.EqualError(t, g.Run(), "step a failed: boom")
+	assert.False(t, ran.Load())
+}
+
+func TestRunValidation(t *testing.T) {
+	noop := func() error { return nil }
+
+	g := New()
+	g.Add("a", noop, "missing")
+	assert.EqualError(t, g.Run(), "step a depends on unknown step missing")
+
+	g = New()
+	g.Add("a", noop, "b")
+	g.Add("b", noop, "a")
+	assert.EqualError(t, g.Run(), "dependency cycle between steps: a -> b -> a")
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/url"
 	"os"
 	"path"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -41,12 +43,21 @@ func (k *kubelet) writeKubeletConfig(cfg *api.NodeConfig) error {
 	if err != nil {
 		return err
 	}
+	flags, err := parseKubeletFlags(cfg.Spec.Kubelet.Flags)
+	if err != nil {
+		return err
+	}
+	userConfig, userFlags, err := migrateKubeletFlags(kubeletVersion, flags, cfg.Spec.Kubelet.Config)
+	if err != nil {
+		return err
+	}
+	k.userFlags = userFlags
 	// tracking: https://github.com/kubernetes/enhancements/issues/3983
 	// for enabling drop-in configuration
 	if semver.Compare(kubeletVersion, "v1.29.0") < 0 {
-		return k.writeKubeletConfigToFile(cfg)
+		return k.writeKubeletConfigToFile(cfg, userConfig, kubeletVersion)
 	} else {
-		return k.writeKubeletConfigToDir(cfg)
+		return k.writeKubeletConfigToDir(cfg, userConfig, kubeletVersion)
 	}
 }
 
@@ -55,32 +66,37 @@ func (k *kubelet) writeKubeletConfig(cfg *api.NodeConfig) error {
 // KubeletConfiguration types:
 // https://pkg.go.dev/k8s.io/kubelet/config/v1beta1#KubeletConfiguration
 type kubeletConfig struct {
-	Address                  string                           `json:"address"`
-	Authentication           k8skubelet.KubeletAuthentication `json:"authentication"`
-	Authorization            k8skubelet.KubeletAuthorization  `json:"authorization"`
-	CgroupDriver             string                           `json:"cgroupDriver"`
-	CgroupRoot               string                           `json:"cgroupRoot"`
-	ClusterDNS               []string                         `json:"clusterDNS"`
-	ClusterDomain            string                           `json:"clusterDomain"`
-	ContainerRuntimeEndpoint string                           `json:"containerRuntimeEndpoint"`
-	EvictionHard             map[string]string                `json:"evictionHard,omitempty"`
-	FeatureGates             map[string]bool                  `json:"featureGates"`
-	HairpinMode              string                           `json:"hairpinMode"`
-	KubeAPIBurst             *int                             `json:"kubeAPIBurst,omitempty"`
-	KubeAPIQPS               *int                             `json:"kubeAPIQPS,omitempty"`
-	KubeReserved             map[string]string                `json:"kubeReserved,omitempty"`
-	KubeReservedCgroup       *string                          `json:"kubeReservedCgroup,omitempty"`
-	Logging                  loggingConfiguration             `json:"logging"`
-	MaxPods                  int32                            `json:"maxPods,omitempty"`
-	ProtectKernelDefaults    bool                             `json:"protectKernelDefaults"`
-	ProviderID               *string                          `json:"providerID,omitempty"`
-	ReadOnlyPort             int                              `json:"readOnlyPort"`
-	RegisterWithTaints       []v1.Taint                       `json:"registerWithTaints,omitempty"`
-	SerializeImagePulls      bool                             `json:"serializeImagePulls"`
-	ServerTLSBootstrap       bool                             `json:"serverTLSBootstrap"`
-	SystemReservedCgroup     *string                          `json:"systemReservedCgroup,omitempty"`
-	TLSCipherSuites          []string                         `json:"tlsCipherSuites"`
-	metav1.TypeMeta          `json:",inline"`
+	Address                          string                                        `json:"address"`
+	Authentication                   k8skubelet.KubeletAuthentication              `json:"authentication"`
+	Authorization                    k8skubelet.KubeletAuthorization               `json:"authorization"`
+	CgroupDriver                     string                                        `json:"cgroupDriver"`
+	CgroupRoot                       string                                        `json:"cgroupRoot"`
+	ClusterDNS                       []string                                      `json:"clusterDNS"`
+	ClusterDomain                    string                                        `json:"clusterDomain"`
+	ContainerRuntimeEndpoint         string                                        `json:"containerRuntimeEndpoint"`
+	EnforceNodeAllocatable           []string                                      `json:"enforceNodeAllocatable,omitempty"`
+	EvictionHard                     map[string]string                             `json:"evictionHard,omitempty"`
+	FeatureGates                     map[string]bool                               `json:"featureGates"`
+	HairpinMode                      string                                        `json:"hairpinMode"`
+	KubeAPIBurst                     *int                                          `json:"kubeAPIBurst,omitempty"`
+	KubeAPIQPS                       *int                                          `json:"kubeAPIQPS,omitempty"`
+	KubeReserved                     map[string]string                             `json:"kubeReserved,omitempty"`
+	KubeReservedCgroup               *string                                       `json:"kubeReservedCgroup,omitempty"`
+	Logging                          loggingConfiguration                          `json:"logging"`
+	MaxPods                          int32                                         `json:"maxPods,omitempty"`
+	ProtectKernelDefaults            bool                                          `json:"protectKernelDefaults"`
+	ProviderID                       *string                                       `json:"providerID,omitempty"`
+	ReadOnlyPort                     int                                           `json:"readOnlyPort"`
+	RegisterWithTaints               []v1.Taint                                    `json:"registerWithTaints,omitempty"`
+	SerializeImagePulls              bool                                          `json:"serializeImagePulls"`
+	ServerTLSBootstrap               bool                                          `json:"serverTLSBootstrap"`
+	ShutdownGracePeriod              *metav1.Duration                              `json:"shutdownGracePeriod,omitempty"`
+	ShutdownGracePeriodCriticalPods  *metav1.Duration                              `json:"shutdownGracePeriodCriticalPods,omitempty"`
+	ShutdownGracePeriodByPodPriority []k8skubelet.ShutdownGracePeriodByPodPriority `json:"shutdownGracePeriodByPodPriority,omitempty"`
+	SystemReserved                   map[string]string                             `json:"systemReserved,omitempty"`
+	SystemReservedCgroup             *string                                       `json:"systemReservedCgroup,omitempty"`
+	TLSCipherSuites                  []string                                      `json:"tlsCipherSuites"`
+	metav1.TypeMeta                  `json:",inline"`
 }
 
 type loggingConfiguration struct {
@@ -161,8 +177,8 @@ func (ksc *kubeletConfig) withFallbackClusterDns(cluster *api.ClusterDetails) er
 
 // To support worker nodes to continue to communicate and connect to local cluster even when the Outpost
 // is disconnected from the parent AWS Region, the following specific setup are required:
-//   - append entries to /etc/hosts with the mappings of control plane host IP address and API server
-//     domain name. So that the domain name can be resolved to IP addresses locally.
+//   - map the API server domain name to the control plane host IP addresses in a block of /etc/hosts
+//     that nodeadm manages. So that the domain name can be resolved to IP addresses locally.
 //   - use aws-iam-authenticator as bootstrap auth for kubelet TLS bootstrapping which downloads client
 //     X.509 certificate and generate kubelet kubeconfig file which uses the client cert. So that the
 //     worker node can be authentiacated through X.509 certificate which works for both connected and
@@ -179,23 +195,7 @@ func (ksc *kubeletConfig) withOutpostSetup(cfg *api.NodeConfig) error {
 			return err
 		}
 
-		// TODO: cleanup
-		ipAddresses, err := net.LookupHost(apiUrl.Host)
-		if err != nil {
-			return err
-		}
-		var ipHostMappings []string
-		for _, ip := range ipAddresses {
-			ipHostMappings = append(ipHostMappings, fmt.Sprintf("%s\t%s", ip, apiUrl.Host))
-		}
-		output := strings.Join(ipHostMappings, "\n") + "\n"
-		// append to /etc/hosts file with shuffled mappings of "IP address to API server domain name"
-		f, err := os.OpenFile("/etc/hosts", os.O_APPEND|os.O_WRONLY, kubeletConfigPerm)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		if _, err := f.WriteString(output); err != nil {
+		if err := system.UpdateHostsBlock(apiUrl.Hostname()); err != nil {
 			return err
 		}
 	}
@@ -203,7 +203,13 @@ func (ksc *kubeletConfig) withOutpostSetup(cfg *api.NodeConfig) error {
 }
 
 func (ksc *kubeletConfig) withNodeIp(cfg *api.NodeConfig, flags map[string]string) error {
-	nodeIp, err := getNodeIp(context.TODO(), imds.New(imds.Options{}), cfg)
+	var nodeIp string
+	var err error
+	if nodeIPOptionsSet(cfg.Spec.Kubelet.NodeIP) {
+		nodeIp, err = getSelectedNodeIP(context.TODO(), imds.New(imds.Options{}), cfg)
+	} else {
+		nodeIp, err = getNodeIp(context.TODO(), imds.New(imds.Options{}), cfg)
+	}
 	if err != nil {
 		return err
 	}
@@ -236,43 +242,50 @@ func (ksc *kubeletConfig) withVersionToggles(kubeletVersion string, flags map[st
 	}
 }
 
-func (ksc *kubeletConfig) withCloudProvider(kubeletVersion string, cfg *api.NodeConfig, flags map[string]string) {
+func (ksc *kubeletConfig) withCloudProvider(kubeletVersion string, cfg *api.NodeConfig, flags map[string]string) error {
 	if semver.Compare(kubeletVersion, "v1.26.0") >= 0 {
 		// ref: https://github.com/kubernetes/kubernetes/pull/121367
 		flags["cloud-provider"] = "external"
 		// provider ID needs to be specified when the cloud provider is external
 		ksc.ProviderID = ptr.String(getProviderId(cfg.Status.Instance.AvailabilityZone, cfg.Status.Instance.ID))
-		var nodeName string
-		if api.IsFeatureEnabled(api.InstanceIdNodeName, cfg.Spec.FeatureGates) {
-			zap.L().Info("Opt-in Instance Id naming strategy")
-			nodeName = cfg.Status.Instance.ID
-		} else {
-			// the name of the Node object default to EC2 PrivateDnsName
-			// see: https://github.com/awslabs/amazon-eks-ami/pull/1264
-			nodeName = cfg.Status.Instance.PrivateDNSName
-		}
-		flags["hostname-override"] = nodeName
+		flags["hostname-override"] = getNodeName(cfg)
 	} else {
+		// the in-tree cloud provider names the node after the private DNS
+		// name, and the node name cannot be overridden
+		if strategy := cfg.Spec.Kubelet.NodeName.Strategy; strategy != "" && strategy != api.NodeNameStrategyPrivateDNSName {
+			return fmt.Errorf("the %s node name strategy requires kubelet v1.26.0 or later, found %s", strategy, kubeletVersion)
+		}
 		flags["cloud-provider"] = "aws"
 	}
+	return nil
 }
 
-// When the DefaultReservedResources flag is enabled, override the kubelet
-// config with reserved cgroup values on behalf of the user
-func (ksc *kubeletConfig) withDefaultReservedResources(cfg *api.NodeConfig) {
-	ksc.SystemReservedCgroup = ptr.String("/system")
-	ksc.KubeReservedCgroup = ptr.String("/runtime")
-	maxPods, ok := MaxPodsPerInstanceType[cfg.Status.Instance.Type]
-	if !ok {
-		ksc.MaxPods = CalcMaxPods(cfg.Status.Instance.Region, cfg.Status.Instance.Type)
-	} else {
-		ksc.MaxPods = int32(maxPods)
+// Apply the node allocatable settings resolved from the reserved resources
+// options, resolving them now if that has not been done during enrichment
+func (ksc *kubeletConfig) withReservedResources(cfg *api.NodeConfig) error {
+	reserved := cfg.Status.ReservedResources
+	if reserved == nil {
+		var err error
+		if reserved, err = resolveReservedResources(cfg); err != nil {
+			return err
+		}
 	}
-	ksc.KubeReserved = map[string]string{
-		"cpu":               fmt.Sprintf("%dm", getCPUMillicoresToReserve()),
-		"ephemeral-storage": "1Gi",
-		"memory":            fmt.Sprintf("%dMi", getMemoryMebibytesToReserve(ksc.MaxPods)),
+	ksc.MaxPods = reserved.MaxPods
+	ksc.KubeReserved = reserved.KubeReserved
+	ksc.SystemReserved = reserved.SystemReserved
+	ksc.KubeReservedCgroup = nonEmpty(reserved.KubeReservedCgroup)
+	ksc.SystemReservedCgroup = nonEmpty(reserved.SystemReservedCgroup)
+	ksc.EvictionHard = reserved.EvictionHard
+	ksc.EnforceNodeAllocatable = reserved.EnforceNodeAllocatable
+	return nil
+}
+
+// nonEmpty returns nil for an empty string so that the field is omitted
+func nonEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
 	}
+	return s
 }
 
 // withPodInfraContainerImage determines whether to add the
@@ -314,24 +327,32 @@ func (k *kubelet) GenerateKubeletConfig(cfg *api.NodeConfig) (*kubeletConfig, er
 		return nil, err
 	}
 
+	if err := kubeletConfig.withGracefulShutdown(cfg, kubeletVersion); err != nil {
+		return nil, err
+	}
 	kubeletConfig.withVersionToggles(kubeletVersion, k.flags)
-	kubeletConfig.withCloudProvider(kubeletVersion, cfg, k.flags)
-	kubeletConfig.withDefaultReservedResources(cfg)
+	if err := kubeletConfig.withCloudProvider(kubeletVersion, cfg, k.flags); err != nil {
+		return nil, err
+	}
+	if err := kubeletConfig.withReservedResources(cfg); err != nil {
+		return nil, err
+	}
 
 	return &kubeletConfig, nil
 }
 
 // WriteConfig writes the kubelet config to a file.
 // This should only be used for kubelet versions < 1.28.
-func (k *kubelet) writeKubeletConfigToFile(cfg *api.NodeConfig) error {
+func (k *kubelet) writeKubeletConfigToFile(cfg *api.NodeConfig, userConfig api.InlineDocument, kubeletVersion string) error {
 	kubeletConfig, err := k.GenerateKubeletConfig(cfg)
 	if err != nil {
 		return err
 	}
 
 	var kubeletConfigBytes []byte
-	if cfg.Spec.Kubelet.Config != nil && len(cfg.Spec.Kubelet.Config) > 0 {
-		mergedMap, err := util.Merge(kubeletConfig, cfg.Spec.Kubelet.Config, json.Marshal, json.Unmarshal)
+	dropIns := sortedConfigDropIns(cfg.Spec.Kubelet.ConfigDropIns)
+	if len(userConfig) > 0 || len(dropIns) > 0 {
+		mergedMap, err := mergeAndValidateKubeletConfig(*kubeletConfig, userConfig, kubeletVersion, dropIns...)
 		if err != nil {
 			return err
 		}
@@ -353,14 +374,23 @@ func (k *kubelet) writeKubeletConfigToFile(cfg *api.NodeConfig) error {
 }
 
 // WriteKubeletConfigToDir writes nodeadm's generated kubelet config to the
-// standard config file and writes the user's provided config to a directory for
-// drop-in support. This is only supported on kubelet versions >= 1.28. see:
+// standard config file and writes the user's provided config and drop-ins to
+// a directory for drop-in support. This is only supported on kubelet versions
+// >= 1.28. see:
 // https://kubernetes.io/docs/tasks/administer-cluster/kubelet-config-file/#kubelet-conf-d
-func (k *kubelet) writeKubeletConfigToDir(cfg *api.NodeConfig) error {
+func (k *kubelet) writeKubeletConfigToDir(cfg *api.NodeConfig, userConfig api.InlineDocument, kubeletVersion string) error {
 	kubeletConfig, err := k.GenerateKubeletConfig(cfg)
 	if err != nil {
 		return err
 	}
+	// kubelet merges the drop-ins over the generated config, so validate the
+	// combination before writing any of them
+	dropIns := sortedConfigDropIns(cfg.Spec.Kubelet.ConfigDropIns)
+	if len(userConfig) > 0 || len(dropIns) > 0 {
+		if _, err := mergeAndValidateKubeletConfig(*kubeletConfig, userConfig, kubeletVersion, dropIns...); err != nil {
+			return err
+		}
+	}
 	kubeletConfigBytes, err := json.MarshalIndent(kubeletConfig, "", strings.Repeat(" ", 4))
 	if err != nil {
 		return err
@@ -374,27 +404,40 @@ func (k *kubelet) writeKubeletConfigToDir(cfg *api.NodeConfig) error {
 		return err
 	}
 
-	if cfg.Spec.Kubelet.Config != nil && len(cfg.Spec.Kubelet.Config) > 0 {
-		dirPath := path.Join(kubeletConfigRoot, kubeletConfigDir)
-		k.flags["config-dir"] = dirPath
+	dirPath := path.Join(kubeletConfigRoot, kubeletConfigDir)
+	var dropInFiles []configDropInFile
+	if len(userConfig) > 0 {
+		dropInFiles = append(dropInFiles, configDropInFile{name: userConfigDropInFileName, config: userConfig})
+	}
+	for _, dropIn := range dropIns {
+		dropInFiles = append(dropInFiles, configDropInFile{name: configDropInFileName(dropIn), config: dropIn.Config})
+	}
+	if err := pruneConfigDropIns(dirPath, dropInFiles); err != nil {
+		return err
+	}
+	if len(dropInFiles) == 0 {
+		return nil
+	}
 
-		zap.L().Info("Enabling kubelet config drop-in dir..")
-		k.setEnv("KUBELET_CONFIG_DROPIN_DIR_ALPHA", "on")
-		filePath := path.Join(dirPath, "00-nodeadm.conf")
+	k.flags["config-dir"] = dirPath
+	zap.L().Info("Enabling kubelet config drop-in dir..")
+	k.setEnv("KUBELET_CONFIG_DROPIN_DIR_ALPHA", "on")
+	for _, dropInFile := range dropInFiles {
+		filePath := path.Join(dirPath, dropInFile.name)
 
 		// merge in default type metadata like kind and apiVersion in case the
 		// user has not specified this, as it is required to qualify a drop-in
 		// config as a valid KubeletConfiguration
-		userKubeletConfigMap, err := util.Merge(defaultKubeletSubConfig().TypeMeta, cfg.Spec.Kubelet.Config, json.Marshal, json.Unmarshal)
+		dropInConfigMap, err := util.Merge(defaultKubeletSubConfig().TypeMeta, dropInFile.config, json.Marshal, json.Unmarshal)
 		if err != nil {
 			return err
 		}
-		userKubeletConfigBytes, err := json.MarshalIndent(userKubeletConfigMap, "", strings.Repeat(" ", 4))
+		dropInConfigBytes, err := json.MarshalIndent(dropInConfigMap, "", strings.Repeat(" ", 4))
 		if err != nil {
 			return err
 		}
 		zap.L().Info("Writing user kubelet config to drop-in file..", zap.String("path", filePath))
-		if err := util.WriteFileWithDir(filePath, userKubeletConfigBytes, kubeletConfigPerm); err != nil {
+		if err := util.WriteFileWithDir(filePath, dropInConfigBytes, kubeletConfigPerm); err != nil {
 			return err
 		}
 	}
@@ -402,6 +445,70 @@ func (k *kubelet) writeKubeletConfigToDir(cfg *api.NodeConfig) error {
 	return nil
 }
 
+// userConfigDropInFileName is the drop-in that holds the user's kubelet config
+const userConfigDropInFileName = "00-nodeadm.conf"
+
+// configDropInFilePattern matches the names of the drop-in files that nodeadm
+// owns. kubelet applies drop-ins in lexical order of their file names.
+var configDropInFilePattern = regexp.MustCompile(`^[0-9]{2}-nodeadm(-[a-z0-9]([-a-z0-9]*[a-z0-9])?)?\.conf$`)
+
+type configDropInFile struct {
+	name   string
+	config api.InlineDocument
+}
+
+func configDropInFileName(dropIn api.KubeletConfigDropIn) string {
+	return fmt.Sprintf("%02d-nodeadm-%s.conf", dropIn.Priority, dropIn.Name)
+}
+
+// sortedConfigDropIns returns the drop-ins in the order that kubelet applies
+// them
+func sortedConfigDropIns(dropIns []api.KubeletConfigDropIn) []api.KubeletConfigDropIn {
+	sorted := slices.Clone(dropIns)
+	slices.SortStableFunc(sorted, func(a, b api.KubeletConfigDropIn) int {
+		return strings.Compare(configDropInFileName(a), configDropInFileName(b))
+	})
+	return sorted
+}
+
+// pruneConfigDropIns removes the drop-in files that nodeadm previously wrote
+// and that are no longer configured. Drop-ins written by other tools are kept.
+func pruneConfigDropIns(dirPath string, dropInFiles []configDropInFile) error {
+	entries, err := os.ReadDir(dirPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !configDropInFilePattern.MatchString(entry.Name()) {
+			continue
+		}
+		if slices.ContainsFunc(dropInFiles, func(f configDropInFile) bool { return f.name == entry.Name() }) {
+			continue
+		}
+		filePath := path.Join(dirPath, entry.Name())
+		zap.L().Info("Removing stale kubelet config drop-in file..", zap.String("path", filePath))
+		if err := util.RemoveFile(filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getNodeName returns the name of the Node object that kubelet registers
+func getNodeName(cfg *api.NodeConfig) string {
+	if cfg.Status.NodeName != "" {
+		return cfg.Status.NodeName
+	}
+	if api.IsFeatureEnabled(api.InstanceIdNodeName, cfg.Spec.FeatureGates) {
+		return cfg.Status.Instance.ID
+	}
+	// the name of the Node object default to EC2 PrivateDnsName
+	// see: https://github.com/awslabs/amazon-eks-ami/pull/1264
+	return cfg.Status.Instance.PrivateDNSName
+}
+
 func getProviderId(availabilityZone, instanceId string) string {
 	return fmt.Sprintf("aws:///%s/%s", availabilityZone, instanceId)
 }
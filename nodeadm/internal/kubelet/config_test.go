@@ -1,6 +1,9 @@
 package kubelet
 
 import (
+	"encoding/json"
+	"os"
+	"path"
 	"testing"
 
 	"github.com/aws/smithy-go/ptr"
@@ -108,7 +111,7 @@ func TestProviderID(t *testing.T) {
 	for _, test := range tests {
 		kubeletAruments := make(map[string]string)
 		kubetConfig := defaultKubeletSubConfig()
-		kubetConfig.withCloudProvider(test.kubeletVersion, &nodeConfig, kubeletAruments)
+		assert.NoError(t, kubetConfig.withCloudProvider(test.kubeletVersion, &nodeConfig, kubeletAruments))
 		assert.Equal(t, test.expectedCloudProvider, kubeletAruments["cloud-provider"])
 		if kubeletAruments["cloud-provider"] == "external" {
 			assert.Equal(t, *kubetConfig.ProviderID, providerId)
@@ -116,3 +119,79 @@ func TestProviderID(t *testing.T) {
 		}
 	}
 }
+
+func TestNodeNameStrategyKubeletVersion(t *testing.T) {
+	var tests = []struct {
+		kubeletVersion string
+		strategy       api.NodeNameStrategy
+		expectedErr    string
+	}{
+		{kubeletVersion: "v1.25.0", strategy: ""},
+		{kubeletVersion: "v1.25.0", strategy: api.NodeNameStrategyPrivateDNSName},
+		{kubeletVersion: "v1.25.0", strategy: api.NodeNameStrategyInstanceID, expectedErr: "the InstanceID node name strategy requires kubelet v1.26.0 or later, found v1.25.0"},
+		{kubeletVersion: "v1.23.0", strategy: api.NodeNameStrategyTemplate, expectedErr: "the Template node name strategy requires kubelet v1.26.0 or later, found v1.23.0"},
+		{kubeletVersion: "v1.26.0", strategy: api.NodeNameStrategyInstanceID},
+	}
+
+	for _, test := range tests {
+		nodeConfig := api.NodeConfig{
+			Spec: api.NodeConfigSpec{
+				Kubelet: api.KubeletOptions{
+					NodeName: api.NodeNameOptions{Strategy: test.strategy},
+				},
+			},
+			Status: api.NodeConfigStatus{
+				Instance: api.InstanceDetails{
+					AvailabilityZone: "us-west-2f",
+					ID:               "i-123456789000",
+				},
+				NodeName: "i-123456789000",
+			},
+		}
+		kubeletConfig := defaultKubeletSubConfig()
+		err := kubeletConfig.withCloudProvider(test.kubeletVersion, &nodeConfig, make(map[string]string))
+		if test.expectedErr != "" {
+			assert.EqualError(t, err, test.expectedErr)
+		} else {
+			assert.NoError(t, err, test.kubeletVersion)
+		}
+	}
+}
+
+func TestConfigDropIns(t *testing.T) {
+	toDoc := func(raw string) api.InlineDocument {
+		var doc api.InlineDocument
+		assert.NoError(t, json.Unmarshal([]byte(raw), &doc))
+		return doc
+	}
+	dropIns := sortedConfigDropIns([]api.KubeletConfigDropIn{
+		{Name: "gpu", Priority: 50, Config: toDoc(`{"maxPods": 30}`)},
+		{Name: "eviction", Priority: 10, Config: toDoc(`{"maxPods": 20, "evictionHard": {"memory.available": "500Mi"}}`)},
+		{Name: "b-logging", Priority: 50, Config: toDoc(`{"logging": {"verbosity": 4}}`)},
+	})
+	var names []string
+	for _, dropIn := range dropIns {
+		names = append(names, configDropInFileName(dropIn))
+	}
+	assert.Equal(t, []string{"10-nodeadm-eviction.conf", "50-nodeadm-b-logging.conf", "50-nodeadm-gpu.conf"}, names)
+
+	merged, err := mergeAndValidateKubeletConfig(defaultKubeletSubConfig(), toDoc(`{"maxPods": 10}`), "", dropIns...)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(30), merged["maxPods"])
+	assert.Equal(t, "500Mi", merged["evictionHard"].(map[string]interface{})["memory.available"])
+
+	dirPath := t.TempDir()
+	for _, name := range []string{"00-nodeadm.conf", "10-nodeadm-eviction.conf", "20-nodeadm-removed.conf", "10-gpu-operator.conf", "nodeadm.conf"} {
+		assert.NoError(t, os.WriteFile(path.Join(dirPath, name), []byte("{}"), kubeletConfigPerm))
+	}
+	assert.NoError(t, pruneConfigDropIns(dirPath, []configDropInFile{{name: "10-nodeadm-eviction.conf"}}))
+	entries, err := os.ReadDir(dirPath)
+	assert.NoError(t, err)
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	assert.Equal(t, []string{"10-gpu-operator.conf", "10-nodeadm-eviction.conf", "nodeadm.conf"}, remaining)
+
+	assert.NoError(t, pruneConfigDropIns(path.Join(dirPath, "missing"), nil))
+}
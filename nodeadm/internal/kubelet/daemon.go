@@ -2,12 +2,22 @@ package kubelet
 
 import (
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/containerd"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
 )
 
 const KubeletDaemonName = "kubelet"
 
 var _ daemon.Daemon = &kubelet{}
+var _ daemon.DependentDaemon = &kubelet{}
+
+// ProcessSpec runs kubelet the same way as its systemd unit, for daemon
+// managers that supervise child processes.
+var ProcessSpec = daemon.ProcessSpec{
+	PreStart:         [][]string{{"/sbin/iptables", "-P", "FORWARD", "ACCEPT", "-w", "5"}},
+	Command:          []string{"/usr/bin/kubelet", "$" + kubeletArgsEnvironmentName},
+	EnvironmentFiles: []string{kubeletEnvironmentFilePath},
+}
 
 type kubelet struct {
 	daemonManager daemon.DaemonManager
@@ -15,6 +25,8 @@ type kubelet struct {
 	environment map[string]string
 	// kubelet config flags without leading dashes
 	flags map[string]string
+	// user-provided flags that were not migrated to the kubelet config
+	userFlags []kubeletFlag
 }
 
 func NewKubeletDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
@@ -41,17 +53,37 @@ func (k *kubelet) Configure(cfg *api.NodeConfig) error {
 	if err := k.writeKubeletEnvironment(cfg); err != nil {
 		return err
 	}
-	return nil
+	if err := daemon.ConfigureSystemdOptions(k.daemonManager, KubeletDaemonName, cfg.Spec.Kubelet.Systemd); err != nil {
+		return err
+	}
+	if err := configureHostsRefresh(k.daemonManager, cfg); err != nil {
+		return err
+	}
+	return writeLogindDropIn(cfg)
 }
 
 func (k *kubelet) EnsureRunning() error {
+	if err := applyLogindInhibitDelay(k.daemonManager); err != nil {
+		return err
+	}
 	return k.daemonManager.StartDaemon(KubeletDaemonName)
 }
 
-func (k *kubelet) PostLaunch(_ *api.NodeConfig) error {
+func (k *kubelet) WaitUntilReady(cfg *api.NodeConfig) error {
+	if err := waitForKubeletReady(cfg); err != nil {
+		return daemon.ErrorWithStatus(err, k.daemonManager, KubeletDaemonName)
+	}
 	return nil
 }
 
+func (k *kubelet) PostLaunch(cfg *api.NodeConfig) error {
+	return applyHostsRefresh(k.daemonManager, cfg)
+}
+
+func (k *kubelet) Dependencies() []string {
+	return []string{containerd.ContainerdDaemonName}
+}
+
 func (k *kubelet) Name() string {
 	return KubeletDaemonName
 }
@@ -3,13 +3,17 @@ package kubelet
 import (
 	"context"
 	_ "embed"
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/instancetypes"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
 	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
 )
 
 // default value from kubelet
@@ -52,12 +56,11 @@ func init() {
 //	# of ENI * (# of IPv4 per ENI - 1) + 2
 func CalcMaxPods(awsRegion string, instanceType string) int32 {
 	zap.L().Info("calculate the max pod for instance type", zap.String("instanceType", instanceType))
-	cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(awsRegion))
+	ec2Client, err := newEC2API(awsRegion)
 	if err != nil {
 		zap.L().Warn("error loading AWS SDK config when calculating the max pod, setting it to default value", zap.Error(err))
 		return defaultMaxPods
 	}
-	ec2Client := &util.EC2Client{Client: ec2.NewFromConfig(cfg)}
 	eniInfo, err := util.GetEniInfoForInstanceType(ec2Client, instanceType)
 	if err != nil {
 		zap.L().Warn("cannot find the max pod for input instance type, setting it to default value")
@@ -65,3 +68,105 @@ func CalcMaxPods(awsRegion string, instanceType string) int32 {
 	}
 	return eniInfo.EniCount*(eniInfo.PodsPerEniCount-1) + 2
 }
+
+const (
+	// pod limits of the max-pods-calculator.sh script, which depend on the
+	// number of vCPUs of the instance type
+	maxPodsCeilingForLowCPU  = 110
+	maxPodsCeilingForHighCPU = 250
+	highCPUThreshold         = 30
+
+	// number of IPv4 addresses in the /28 prefixes that the VPC CNI assigns
+	// to ENIs with prefix delegation
+	ipsPerPrefix                  = 16
+	prefixDelegationMinCNIVersion = "v1.9.0"
+)
+
+var newEC2API = func(awsRegion string) (util.EC2API, error) {
+	cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(awsRegion))
+	if err != nil {
+		return nil, err
+	}
+	return &util.EC2Client{Client: ec2.NewFromConfig(cfg)}, nil
+}
+
+// getMaxPods determines the maximum number of pods for the instance according
+// to the max pods policy
+func getMaxPods(cfg *api.NodeConfig) (int32, error) {
+	options := cfg.Spec.Kubelet.MaxPods
+	instanceType := cfg.Status.Instance.Type
+	switch options.Policy {
+	case "", api.MaxPodsPolicyEKS:
+		if info, ok := instancetypes.Get(instanceType); ok {
+			return info.MaxENIs*(info.IPv4PerENI-1) + 2, nil
+		}
+		if maxPods, ok := MaxPodsPerInstanceType[instanceType]; ok {
+			return int32(maxPods), nil
+		}
+		return CalcMaxPods(cfg.Status.Instance.Region, instanceType), nil
+	case api.MaxPodsPolicyCalculated:
+		info, err := getInstanceType(cfg.Status.Instance.Region, instanceType)
+		if err != nil {
+			return 0, fmt.Errorf("failed to calculate max pods: %w", err)
+		}
+		maxPods := calculateMaxPods(info, options)
+		zap.L().Info("Calculated max pods", zap.String("instanceType", instanceType), zap.Int32("maxPods", maxPods))
+		return maxPods, nil
+	case api.MaxPodsPolicyFixed:
+		if options.Value == nil {
+			return 0, fmt.Errorf("max pods value is required by the Fixed policy")
+		}
+		return *options.Value, nil
+	default:
+		return 0, fmt.Errorf("unknown max pods policy: %s", options.Policy)
+	}
+}
+
+// getInstanceType returns the attributes of the instance type from the
+// embedded dataset, falling back to the EC2 API for unknown instance types
+func getInstanceType(awsRegion string, instanceType string) (instancetypes.InstanceType, error) {
+	if info, ok := instancetypes.Get(instanceType); ok {
+		return info, nil
+	}
+	ec2Client, err := newEC2API(awsRegion)
+	if err != nil {
+		return instancetypes.InstanceType{}, err
+	}
+	info, err := util.GetInstanceTypeInfo(ec2Client, instanceType)
+	if err != nil {
+		return instancetypes.InstanceType{}, err
+	}
+	return instancetypes.InstanceType{
+		Name:       instanceType,
+		VCpus:      info.VCpuCount,
+		Hypervisor: info.Hypervisor,
+		MaxENIs:    info.EniCount,
+		IPv4PerENI: info.PodsPerEniCount,
+	}, nil
+}
+
+// calculateMaxPods ports the max-pods-calculator.sh script of the AL2 AMI
+func calculateMaxPods(info instancetypes.InstanceType, options api.MaxPodsOptions) int32 {
+	enis := info.MaxENIs
+	if options.CNIMaxENI != nil && *options.CNIMaxENI < enis {
+		enis = *options.CNIMaxENI
+	}
+	// the primary ENI is not used for pods with custom networking
+	if options.CNICustomNetworking {
+		enis--
+	}
+	// one IP address of each ENI is its primary address
+	ipsPerEni := info.IPv4PerENI - 1
+	if options.CNIPrefixDelegation && info.Hypervisor == "nitro" &&
+		semver.Compare(api.CanonicalVersion(options.CNIVersion), prefixDelegationMinCNIVersion) >= 0 {
+		ipsPerEni *= ipsPerPrefix
+	}
+	// pods using host networking, like the VPC CNI and kube-proxy
+	maxPods := enis*ipsPerEni + 2
+
+	ceiling := int32(maxPodsCeilingForLowCPU)
+	if info.VCpus > highCPUThreshold {
+		ceiling = maxPodsCeilingForHighCPU
+	}
+	return min(maxPods, ceiling)
+}
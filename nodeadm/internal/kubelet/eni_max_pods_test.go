@@ -0,0 +1,116 @@
+package kubelet
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/instancetypes"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+// fakeEC2API serves DescribeInstanceTypes from a fixed set of instance types
+type fakeEC2API map[string]types.InstanceTypeInfo
+
+func (f fakeEC2API) DescribeInstanceTypes(ctx context.Context, params *ec2.DescribeInstanceTypesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	var output ec2.DescribeInstanceTypesOutput
+	for _, instanceType := range params.InstanceTypes {
+		info, ok := f[string(instanceType)]
+		if !ok {
+			return nil, fmt.Errorf("invalid instance type %s", instanceType)
+		}
+		output.InstanceTypes = append(output.InstanceTypes, info)
+	}
+	return &output, nil
+}
+
+func instanceTypeInfo(instanceType string, vCPUs, enis, ipsPerEni int32, hypervisor types.InstanceTypeHypervisor) types.InstanceTypeInfo {
+	return types.InstanceTypeInfo{
+		InstanceType: types.InstanceType(instanceType),
+		Hypervisor:   hypervisor,
+		VCpuInfo:     &types.VCpuInfo{DefaultVCpus: ptr.Int32(vCPUs)},
+		MemoryInfo:   &types.MemoryInfo{SizeInMiB: ptr.Int64(int64(vCPUs) * 4096)},
+		NetworkInfo: &types.NetworkInfo{
+			MaximumNetworkInterfaces:  ptr.Int32(enis),
+			Ipv4AddressesPerInterface: ptr.Int32(ipsPerEni),
+		},
+	}
+}
+
+func TestCalculatedMaxPods(t *testing.T) {
+	// m5 instance types are in the embedded dataset, others are described
+	// through the API
+	oldNewEC2API := newEC2API
+	t.Cleanup(func() { newEC2API = oldNewEC2API })
+	newEC2API = func(string) (util.EC2API, error) {
+		return fakeEC2API{
+			"t2.medium": instanceTypeInfo("t2.medium", 2, 3, 6, types.InstanceTypeHypervisorXen),
+		}, nil
+	}
+
+	var tests = []struct {
+		name            string
+		instanceType    string
+		options         api.MaxPodsOptions
+		expectedMaxPods int32
+	}{
+		{name: "default", instanceType: "m5.large", expectedMaxPods: 29},
+		{name: "high cpu ceiling", instanceType: "m5.24xlarge", expectedMaxPods: 250},
+		{name: "custom networking", instanceType: "m5.large", options: api.MaxPodsOptions{CNICustomNetworking: true}, expectedMaxPods: 20},
+		{name: "max eni", instanceType: "m5.large", options: api.MaxPodsOptions{CNIMaxENI: ptr.Int32(2)}, expectedMaxPods: 20},
+		{name: "max eni above limit", instanceType: "m5.large", options: api.MaxPodsOptions{CNIMaxENI: ptr.Int32(8)}, expectedMaxPods: 29},
+		{name: "max eni with custom networking", instanceType: "m5.large", options: api.MaxPodsOptions{CNIMaxENI: ptr.Int32(2), CNICustomNetworking: true}, expectedMaxPods: 11},
+		{name: "prefix delegation", instanceType: "m5.large", options: api.MaxPodsOptions{CNIPrefixDelegation: true, CNIVersion: "1.18.0"}, expectedMaxPods: 110},
+		{name: "prefix delegation unsupported by cni", instanceType: "m5.large", options: api.MaxPodsOptions{CNIPrefixDelegation: true, CNIVersion: "1.8.0"}, expectedMaxPods: 29},
+		{name: "prefix delegation unsupported by hypervisor", instanceType: "t2.medium", options: api.MaxPodsOptions{CNIPrefixDelegation: true, CNIVersion: "v1.18.0"}, expectedMaxPods: 17},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.options.Policy = api.MaxPodsPolicyCalculated
+			if test.options.CNIVersion == "" {
+				test.options.CNIVersion = "1.18.0"
+			}
+			cfg := api.NodeConfig{
+				Spec:   api.NodeConfigSpec{Kubelet: api.KubeletOptions{MaxPods: test.options}},
+				Status: api.NodeConfigStatus{Instance: api.InstanceDetails{Type: test.instanceType, Region: "us-west-2"}},
+			}
+			maxPods, err := getMaxPods(&cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedMaxPods, maxPods)
+		})
+	}
+
+	cfg := api.NodeConfig{
+		Spec:   api.NodeConfigSpec{Kubelet: api.KubeletOptions{MaxPods: api.MaxPodsOptions{Policy: api.MaxPodsPolicyCalculated, CNIVersion: "1.18.0"}}},
+		Status: api.NodeConfigStatus{Instance: api.InstanceDetails{Type: "mock-type.large"}},
+	}
+	_, err := getMaxPods(&cfg)
+	assert.EqualError(t, err, "failed to calculate max pods: error describing instance type mock-type.large: invalid instance type mock-type.large")
+}
+
+func TestMaxPodsPolicies(t *testing.T) {
+	cfg := api.NodeConfig{Status: api.NodeConfigStatus{Instance: api.InstanceDetails{Type: "m5.large"}}}
+	maxPods, err := getMaxPods(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(29), maxPods)
+
+	cfg.Spec.Kubelet.MaxPods = api.MaxPodsOptions{Policy: api.MaxPodsPolicyFixed, Value: ptr.Int32(42)}
+	maxPods, err = getMaxPods(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(42), maxPods)
+}
+
+func TestInstanceTypeDatasetMatchesENIMaxPods(t *testing.T) {
+	for instanceType, expectedMaxPods := range MaxPodsPerInstanceType {
+		if info, ok := instancetypes.Get(instanceType); ok {
+			assert.Equal(t, int32(expectedMaxPods), info.MaxENIs*(info.IPv4PerENI-1)+2, instanceType)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package kubelet
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
@@ -19,19 +20,15 @@ const (
 func (k *kubelet) writeKubeletEnvironment(cfg *api.NodeConfig) error {
 	// transform kubelet flags into a single string and write them to the
 	// kubelet environment variable
-	var kubeletFlags []string
-	for flag, value := range k.flags {
-		kubeletFlags = append(kubeletFlags, fmt.Sprintf("--%s=%s", flag, value))
-	}
-	// append user-provided flags at the end to give them precedence
-	kubeletFlags = append(kubeletFlags, cfg.Spec.Kubelet.Flags...)
+	kubeletFlags := buildKubeletArgs(k.flags, k.userFlags)
 	// expose these flags via an environment variable scoped to nodeadm
 	k.environment[kubeletArgsEnvironmentName] = strings.Join(kubeletFlags, " ")
-	// write additional environment variables
+	// write additional environment variables in a stable order
 	var kubeletEnvironment []string
 	for eKey, eValue := range k.environment {
 		kubeletEnvironment = append(kubeletEnvironment, fmt.Sprintf(`%s="%s"`, eKey, eValue))
 	}
+	sort.Strings(kubeletEnvironment)
 	return util.WriteFileWithDir(kubeletEnvironmentFilePath, []byte(strings.Join(kubeletEnvironment, "\n")), kubeletConfigPerm)
 }
 
@@ -0,0 +1,343 @@
+package kubelet
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// kubeletFlag is a single command-line kubelet argument
+type kubeletFlag struct {
+	name     string
+	value    string
+	hasValue bool
+}
+
+func (f kubeletFlag) String() string {
+	if !f.hasValue {
+		return "--" + f.name
+	}
+	return fmt.Sprintf("--%s=%s", f.name, f.value)
+}
+
+// kubeletValueFlags are the kubelet flags that take a value, which may be given
+// as the following argument. Any other flag is a boolean, or must be given its
+// value after an equals sign.
+var kubeletValueFlags = []string{
+	"address", "allowed-unsafe-sysctls", "authentication-token-webhook-cache-ttl", "authorization-mode",
+	"authorization-webhook-cache-authorized-ttl", "authorization-webhook-cache-unauthorized-ttl",
+	"azure-container-registry-config", "bootstrap-kubeconfig", "cert-dir", "cgroup-driver", "cgroup-root",
+	"client-ca-file", "cloud-config", "cloud-provider", "cluster-dns", "cluster-domain", "config", "config-dir",
+	"container-log-max-files", "container-log-max-size", "container-runtime", "container-runtime-endpoint",
+	"cpu-cfs-quota-period", "cpu-manager-policy", "cpu-manager-policy-options", "cpu-manager-reconcile-period",
+	"enforce-node-allocatable", "event-burst", "event-qps", "eviction-hard", "eviction-max-pod-grace-period",
+	"eviction-minimum-reclaim", "eviction-pressure-transition-period", "eviction-soft", "eviction-soft-grace-period",
+	"experimental-mounter-path", "feature-gates", "file-check-frequency", "hairpin-mode", "healthz-bind-address",
+	"healthz-port", "hostname-override", "http-check-frequency", "image-credential-provider-bin-dir",
+	"image-credential-provider-config", "image-gc-high-threshold", "image-gc-low-threshold", "image-service-endpoint",
+	"iptables-drop-bit", "iptables-masquerade-bit", "kube-api-burst", "kube-api-content-type", "kube-api-qps",
+	"kube-reserved", "kube-reserved-cgroup", "kubeconfig", "kubelet-cgroups", "lock-file", "log-flush-frequency",
+	"log-json-info-buffer-size", "logging-format", "manifest-url", "manifest-url-header", "max-open-files", "max-pods",
+	"maximum-dead-containers", "maximum-dead-containers-per-container", "memory-manager-policy",
+	"minimum-container-ttl-duration", "minimum-image-ttl-duration", "node-ip", "node-labels",
+	"node-status-max-images", "node-status-update-frequency", "oom-score-adj", "pod-cidr", "pod-infra-container-image",
+	"pod-manifest-path", "pod-max-pids", "pods-per-core", "port", "provider-id", "qos-reserved", "read-only-port",
+	"register-with-taints", "registry-burst", "registry-qps", "reserved-cpus", "reserved-memory", "resolv-conf",
+	"root-dir", "runtime-cgroups", "runtime-request-timeout", "streaming-connection-idle-timeout", "sync-frequency",
+	"system-cgroups", "system-reserved", "system-reserved-cgroup", "tls-cert-file", "tls-cipher-suites",
+	"tls-min-version", "tls-private-key-file", "topology-manager-policy", "topology-manager-policy-options",
+	"topology-manager-scope", "v", "vmodule", "volume-plugin-dir", "volume-stats-agg-period",
+}
+
+// parseKubeletFlags parses user-provided kubelet arguments. An argument may
+// hold several flags, which are split like a shell splits words. A flag that
+// takes a value may be given it as the following argument, in the same way
+// that kubelet reads them. Values cannot contain whitespace, because the
+// kubelet unit splits its arguments on whitespace.
+func parseKubeletFlags(args []string) ([]kubeletFlag, error) {
+	var flags []kubeletFlag
+	// takesValue is whether the last flag takes a value that has not been
+	// given yet
+	takesValue := false
+	for _, arg := range args {
+		tokens, err := splitWords(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubelet flags %q: %w", arg, err)
+		}
+		for _, token := range tokens {
+			if takesValue {
+				flags[len(flags)-1].value = token
+				flags[len(flags)-1].hasValue = true
+				takesValue = false
+			} else if !strings.HasPrefix(token, "-") {
+				zap.L().Warn("Ignoring kubelet argument that is not a flag", zap.String("argument", token))
+				continue
+			} else {
+				name, value, hasValue := strings.Cut(strings.TrimLeft(token, "-"), "=")
+				flags = append(flags, kubeletFlag{name: name, value: value, hasValue: hasValue})
+				takesValue = !hasValue && slices.Contains(kubeletValueFlags, name)
+			}
+			if flag := flags[len(flags)-1]; strings.ContainsAny(flag.value, " \t\n") {
+				return nil, fmt.Errorf("value of kubelet flag --%s cannot contain whitespace: %q", flag.name, flag.value)
+			}
+		}
+	}
+	if takesValue {
+		return nil, fmt.Errorf("kubelet flag --%s requires a value", flags[len(flags)-1].name)
+	}
+	return flags, nil
+}
+
+// splitWords splits a string into words like a POSIX shell, without any
+// expansion. Whitespace separates words unless it is quoted. Single quotes
+// preserve every character. Outside of quotes a backslash escapes the next
+// character, and inside double quotes it escapes a double quote, backslash,
+// dollar sign or backtick.
+func splitWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			word.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '"':
+			i++
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`", s[i+1]) >= 0 {
+					i++
+				}
+				word.WriteByte(s[i])
+			}
+			if i == len(s) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inWord = true
+		case c == '\\':
+			if i+1 < len(s) {
+				i++
+				word.WriteByte(s[i])
+			}
+			inWord = true
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// buildKubeletArgs merges the generated flags with the user's flags and returns
+// them sorted by name. User flags take precedence over generated flags of the
+// same name, which is reported since it usually breaks nodeadm's setup.
+func buildKubeletArgs(generatedFlags map[string]string, userFlags []kubeletFlag) []string {
+	overridden := make(map[string]bool)
+	for _, flag := range userFlags {
+		if generatedValue, ok := generatedFlags[flag.name]; ok && !overridden[flag.name] {
+			zap.L().Warn("User-provided kubelet flag overrides generated flag",
+				zap.String("flag", flag.name),
+				zap.String("generatedValue", generatedValue),
+				zap.String("userValue", flag.value))
+			overridden[flag.name] = true
+		}
+	}
+	var flags []kubeletFlag
+	for name, value := range generatedFlags {
+		if !overridden[name] {
+			flags = append(flags, kubeletFlag{name: name, value: value, hasValue: true})
+		}
+	}
+	// user flags keep their relative order, so repeated flags resolve the same
+	// way as before sorting
+	flags = append(flags, userFlags...)
+	sort.SliceStable(flags, func(i, j int) bool {
+		return flags[i].name < flags[j].name
+	})
+	args := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		args = append(args, flag.String())
+	}
+	return args
+}
+
+// configFlag describes a kubelet flag that has an equivalent
+// KubeletConfiguration field.
+type configFlag struct {
+	field string
+	// deprecatedSince is the first kubelet version that both has the field and
+	// deprecates the flag in favor of it
+	deprecatedSince string
+	convert         func(value string, hasValue bool) (interface{}, error)
+}
+
+var configFlags = map[string]configFlag{
+	"cgroup-driver":           {field: "cgroupDriver", deprecatedSince: "v1.10.0", convert: stringValue},
+	"cluster-dns":             {field: "clusterDNS", deprecatedSince: "v1.10.0", convert: listValue},
+	"cluster-domain":          {field: "clusterDomain", deprecatedSince: "v1.10.0", convert: stringValue},
+	"container-log-max-files": {field: "containerLogMaxFiles", deprecatedSince: "v1.14.0", convert: intValue},
+	"container-log-max-size":  {field: "containerLogMaxSize", deprecatedSince: "v1.14.0", convert: stringValue},
+	"eviction-hard":           {field: "evictionHard", deprecatedSince: "v1.10.0", convert: evictionValue},
+	"eviction-soft":           {field: "evictionSoft", deprecatedSince: "v1.10.0", convert: evictionValue},
+	"feature-gates":           {field: "featureGates", deprecatedSince: "v1.10.0", convert: featureGatesValue},
+	"healthz-bind-address":    {field: "healthzBindAddress", deprecatedSince: "v1.10.0", convert: stringValue},
+	"healthz-port":            {field: "healthzPort", deprecatedSince: "v1.10.0", convert: intValue},
+	"image-gc-high-threshold": {field: "imageGCHighThresholdPercent", deprecatedSince: "v1.10.0", convert: intValue},
+	"image-gc-low-threshold":  {field: "imageGCLowThresholdPercent", deprecatedSince: "v1.10.0", convert: intValue},
+	"kube-api-burst":          {field: "kubeAPIBurst", deprecatedSince: "v1.10.0", convert: intValue},
+	"kube-api-qps":            {field: "kubeAPIQPS", deprecatedSince: "v1.10.0", convert: intValue},
+	"kube-reserved":           {field: "kubeReserved", deprecatedSince: "v1.10.0", convert: mapValue},
+	"max-pods":                {field: "maxPods", deprecatedSince: "v1.10.0", convert: intValue},
+	"pods-per-core":           {field: "podsPerCore", deprecatedSince: "v1.10.0", convert: intValue},
+	"register-with-taints":    {field: "registerWithTaints", deprecatedSince: "v1.23.0", convert: taintsValue},
+	"serialize-image-pulls":   {field: "serializeImagePulls", deprecatedSince: "v1.10.0", convert: boolValue},
+	"system-reserved":         {field: "systemReserved", deprecatedSince: "v1.10.0", convert: mapValue},
+}
+
+// migrateKubeletFlags moves user flags that the kubelet version deprecates in
+// favor of a KubeletConfiguration field into a copy of the user's kubelet
+// configuration. Flags take precedence over the configuration file in kubelet,
+// so migrated values replace the user's configured values. The remaining flags
+// are returned unchanged.
+func migrateKubeletFlags(kubeletVersion string, flags []kubeletFlag, userConfig api.InlineDocument) (api.InlineDocument, []kubeletFlag, error) {
+	var remaining []kubeletFlag
+	var migrated api.InlineDocument
+	for _, flag := range flags {
+		configFlag, ok := configFlags[flag.name]
+		if !ok || semver.Compare(kubeletVersion, configFlag.deprecatedSince) < 0 {
+			remaining = append(remaining, flag)
+			continue
+		}
+		value, err := configFlag.convert(flag.value, flag.hasValue)
+		if err != nil {
+			zap.L().Warn("Unable to migrate kubelet flag to config, passing it as a flag", zap.String("flag", flag.name), zap.Error(err))
+			remaining = append(remaining, flag)
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if migrated == nil {
+			migrated = make(api.InlineDocument, len(userConfig))
+			for k, v := range userConfig {
+				migrated[k] = v
+			}
+		}
+		zap.L().Info("Migrating deprecated kubelet flag to config", zap.String("flag", flag.name), zap.String("field", configFlag.field))
+		migrated[configFlag.field] = runtime.RawExtension{Raw: raw}
+	}
+	if migrated == nil {
+		return userConfig, remaining, nil
+	}
+	return migrated, remaining, nil
+}
+
+func stringValue(value string, _ bool) (interface{}, error) {
+	return value, nil
+}
+
+func intValue(value string, _ bool) (interface{}, error) {
+	return strconv.ParseInt(value, 10, 32)
+}
+
+func boolValue(value string, hasValue bool) (interface{}, error) {
+	if !hasValue {
+		return true, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func listValue(value string, _ bool) (interface{}, error) {
+	return splitList(value), nil
+}
+
+func mapValue(value string, _ bool) (interface{}, error) {
+	result := make(map[string]string)
+	for _, item := range splitList(value) {
+		k, v, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid key=value pair %q", item)
+		}
+		result[k] = v
+	}
+	return result, nil
+}
+
+func evictionValue(value string, _ bool) (interface{}, error) {
+	result := make(map[string]string)
+	for _, item := range splitList(value) {
+		k, v, ok := strings.Cut(item, "<")
+		if !ok {
+			return nil, fmt.Errorf("invalid eviction threshold %q", item)
+		}
+		result[k] = v
+	}
+	return result, nil
+}
+
+func featureGatesValue(value string, _ bool) (interface{}, error) {
+	result := make(map[string]bool)
+	for _, item := range splitList(value) {
+		k, v, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid feature gate %q", item)
+		}
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid feature gate %q: %w", item, err)
+		}
+		result[k] = enabled
+	}
+	return result, nil
+}
+
+// taintsValue parses taints in the key[=value]:effect format of
+// --register-with-taints
+func taintsValue(value string, _ bool) (interface{}, error) {
+	var taints []v1.Taint
+	for _, item := range splitList(value) {
+		keyValue, effect, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid taint %q", item)
+		}
+		key, taintValue, _ := strings.Cut(keyValue, "=")
+		taints = append(taints, v1.Taint{Key: key, Value: taintValue, Effect: v1.TaintEffect(effect)})
+	}
+	return taints, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
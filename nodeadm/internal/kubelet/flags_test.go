@@ -0,0 +1,127 @@
+package kubelet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestParseKubeletFlags(t *testing.T) {
+	flags, err := parseKubeletFlags([]string{
+		"--v=5", "--max-pods=20 -v", "2", "--fail-swap-on",
+		`--node-labels 'team=a' --cluster-domain="cluster.local" --tls-cipher-suites=a\,b`,
+		"--register-node", "ignored",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []kubeletFlag{
+		{name: "v", value: "5", hasValue: true},
+		{name: "max-pods", value: "20", hasValue: true},
+		{name: "v", value: "2", hasValue: true},
+		{name: "fail-swap-on"},
+		{name: "node-labels", value: "team=a", hasValue: true},
+		{name: "cluster-domain", value: "cluster.local", hasValue: true},
+		{name: "tls-cipher-suites", value: "a,b", hasValue: true},
+		{name: "register-node"},
+	}, flags)
+
+	_, err = parseKubeletFlags([]string{`--node-labels="team=a`})
+	assert.EqualError(t, err, `invalid kubelet flags "--node-labels=\"team=a": unterminated double quote`)
+
+	_, err = parseKubeletFlags([]string{"--node-labels", "'team=a b'"})
+	assert.EqualError(t, err, `value of kubelet flag --node-labels cannot contain whitespace: "team=a b"`)
+
+	_, err = parseKubeletFlags([]string{"--fail-swap-on", "--node-labels"})
+	assert.EqualError(t, err, "kubelet flag --node-labels requires a value")
+}
+
+func TestSplitWords(t *testing.T) {
+	var tests = []struct {
+		s             string
+		expectedWords []string
+		expectedErr   string
+	}{
+		{s: "  a\tb  c ", expectedWords: []string{"a", "b", "c"}},
+		{s: `a'b c'd "e \"f\" \$g \h" i\ j`, expectedWords: []string{"ab cd", `e "f" $g \h`, "i j"}},
+		{s: `''`, expectedWords: []string{""}},
+		{s: `'a`, expectedErr: "unterminated single quote"},
+	}
+
+	for _, test := range tests {
+		words, err := splitWords(test.s)
+		if test.expectedErr != "" {
+			assert.EqualError(t, err, test.expectedErr)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.expectedWords, words)
+	}
+}
+
+func TestBuildKubeletArgs(t *testing.T) {
+	generated := map[string]string{
+		"node-ip":           "10.0.0.1",
+		"hostname-override": "i-1234567890abcdef0",
+		"cloud-provider":    "external",
+	}
+	userFlags, err := parseKubeletFlags([]string{"--v=5", "--node-ip=10.0.0.2", "--node-labels=a=b", "--node-ip=10.0.0.3"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"--cloud-provider=external",
+		"--hostname-override=i-1234567890abcdef0",
+		"--node-ip=10.0.0.2",
+		"--node-ip=10.0.0.3",
+		"--node-labels=a=b",
+		"--v=5",
+	}, buildKubeletArgs(generated, userFlags))
+}
+
+func TestMigrateKubeletFlags(t *testing.T) {
+	var userConfig api.InlineDocument
+	assert.NoError(t, json.Unmarshal([]byte(`{"maxPods": 10, "clusterDomain": "example.com"}`), &userConfig))
+	flags, err := parseKubeletFlags([]string{
+		"--max-pods=20",
+		"--register-with-taints=foo=bar:NoSchedule,baz:NoExecute",
+		"--kube-reserved=cpu=100m,memory=1Gi",
+		"--serialize-image-pulls",
+		"--healthz-port=10249",
+		"--image-gc-high-threshold=high",
+		"--node-labels=a=b",
+	})
+	assert.NoError(t, err)
+
+	var tests = []struct {
+		kubeletVersion    string
+		expectedConfig    string
+		expectedRemaining []string
+	}{
+		{
+			kubeletVersion:    "v1.22.0",
+			expectedConfig:    `{"clusterDomain":"example.com","healthzPort":10249,"kubeReserved":{"cpu":"100m","memory":"1Gi"},"maxPods":20,"serializeImagePulls":true}`,
+			expectedRemaining: []string{"register-with-taints", "image-gc-high-threshold", "node-labels"},
+		},
+		{
+			kubeletVersion:    "v1.29.0",
+			expectedConfig:    `{"clusterDomain":"example.com","healthzPort":10249,"kubeReserved":{"cpu":"100m","memory":"1Gi"},"maxPods":20,"registerWithTaints":[{"key":"foo","value":"bar","effect":"NoSchedule"},{"key":"baz","effect":"NoExecute"}],"serializeImagePulls":true}`,
+			expectedRemaining: []string{"image-gc-high-threshold", "node-labels"},
+		},
+	}
+
+	for _, test := range tests {
+		migratedConfig, remaining, err := migrateKubeletFlags(test.kubeletVersion, flags, userConfig)
+		assert.NoError(t, err)
+		migratedConfigJson, err := json.Marshal(migratedConfig)
+		assert.NoError(t, err)
+		assert.JSONEq(t, test.expectedConfig, string(migratedConfigJson))
+		var remainingNames []string
+		for _, flag := range remaining {
+			remainingNames = append(remainingNames, flag.name)
+		}
+		assert.Equal(t, test.expectedRemaining, remainingNames)
+	}
+	// the user's config is not modified
+	assert.Len(t, userConfig, 2)
+}
@@ -0,0 +1,173 @@
+package kubelet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+	k8skubelet "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	logindDaemonName = "systemd-logind"
+	logindDropInPerm = 0644
+)
+
+// logindDropInPath raises the systemd-logind inhibit delay, which caps how long
+// kubelet can delay the shutdown of the node
+var logindDropInPath = "/etc/systemd/logind.conf.d/90-nodeadm-graceful-shutdown.conf"
+
+// gracefulShutdownByPodPriorityMinVersion is the first kubelet version with
+// the GracefulNodeShutdownBasedOnPodPriority feature gate
+const gracefulShutdownByPodPriorityMinVersion = "v1.23.0"
+
+// withGracefulShutdown applies the shutdown grace periods
+func (ksc *kubeletConfig) withGracefulShutdown(cfg *api.NodeConfig, kubeletVersion string) error {
+	options := cfg.Spec.Kubelet.GracefulShutdown
+	ksc.ShutdownGracePeriod = options.GracePeriod
+	ksc.ShutdownGracePeriodCriticalPods = options.CriticalPodsGracePeriod
+	if len(options.ByPodPriority) == 0 {
+		return nil
+	}
+	if semver.Compare(kubeletVersion, gracefulShutdownByPodPriorityMinVersion) < 0 {
+		return fmt.Errorf("graceful shutdown by pod priority requires kubelet %s or later, found %s", gracefulShutdownByPodPriorityMinVersion, kubeletVersion)
+	}
+	for _, period := range options.ByPodPriority {
+		ksc.ShutdownGracePeriodByPodPriority = append(ksc.ShutdownGracePeriodByPodPriority, k8skubelet.ShutdownGracePeriodByPodPriority{
+			Priority:                   period.Priority,
+			ShutdownGracePeriodSeconds: period.GracePeriodSeconds,
+		})
+	}
+	// TODO: remove when 1.23 is EOL
+	// shutdown grace periods by pod priority are alpha in 1.23, and beta and
+	// enabled by default from 1.24
+	if semver.Compare(kubeletVersion, "v1.24.0") < 0 {
+		ksc.FeatureGates["GracefulNodeShutdownBasedOnPodPriority"] = true
+	}
+	return nil
+}
+
+// gracefulShutdownPeriod returns the total time that kubelet delays the
+// shutdown of the node. kubelet sums the periods by pod priority.
+func gracefulShutdownPeriod(options api.GracefulShutdownOptions) time.Duration {
+	if options.GracePeriod != nil {
+		return options.GracePeriod.Duration
+	}
+	var total time.Duration
+	for _, period := range options.ByPodPriority {
+		total += time.Duration(period.GracePeriodSeconds) * time.Second
+	}
+	return total
+}
+
+func renderLogindDropIn(options api.GracefulShutdownOptions) []byte {
+	period := gracefulShutdownPeriod(options)
+	if period <= 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	buf.WriteString("# Generated by nodeadm from the NodeConfig, do not edit.\n")
+	fmt.Fprintf(&buf, "\n[Login]\nInhibitDelayMaxSec=%d\n", int64(math.Ceil(period.Seconds())))
+	return buf.Bytes()
+}
+
+// writeLogindDropIn writes the systemd-logind drop-in for the graceful
+// shutdown period, or removes it when graceful shutdown is disabled.
+func writeLogindDropIn(cfg *api.NodeConfig) error {
+	content := renderLogindDropIn(cfg.Spec.Kubelet.GracefulShutdown)
+	existing, err := os.ReadFile(logindDropInPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	exists := err == nil
+	if content == nil {
+		if !exists {
+			return nil
+		}
+		zap.L().Info("Removing systemd-logind drop-in..", zap.String("path", logindDropInPath))
+		return util.RemoveFile(logindDropInPath)
+	}
+	if exists && bytes.Equal(existing, content) {
+		return nil
+	}
+	zap.L().Info("Writing systemd-logind drop-in..", zap.String("path", logindDropInPath))
+	return util.WriteFileWithDir(logindDropInPath, content, logindDropInPerm)
+}
+
+// readLogindDropIn returns the inhibit delay configured by the
+// systemd-logind drop-in, or zero when there is no drop-in
+func readLogindDropIn() (time.Duration, error) {
+	content, err := os.ReadFile(logindDropInPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found || strings.TrimSpace(key) != "InhibitDelayMaxSec" {
+			continue
+		}
+		seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid InhibitDelayMaxSec in %s: %w", logindDropInPath, err)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, nil
+}
+
+// getLogindInhibitDelay returns the inhibit delay that the running
+// systemd-logind uses
+var getLogindInhibitDelay = func() (time.Duration, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	property, err := conn.Object("org.freedesktop.login1", "/org/freedesktop/login1").GetProperty("org.freedesktop.login1.Manager.InhibitDelayMaxUSec")
+	if err != nil {
+		return 0, err
+	}
+	usec, ok := property.Value().(uint64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected InhibitDelayMaxUSec of type %s", property.Signature())
+	}
+	return time.Duration(usec) * time.Microsecond, nil
+}
+
+// applyLogindInhibitDelay restarts systemd-logind when the inhibit delay it
+// runs with differs from the drop-in. This must happen before kubelet starts,
+// as kubelet reads the inhibit delay when it starts. Without a drop-in the
+// inhibit delay is not nodeadm's to manage, so it is left as is.
+func applyLogindInhibitDelay(daemonManager daemon.DaemonManager) error {
+	configured, err := readLogindDropIn()
+	if err != nil || configured == 0 {
+		return err
+	}
+	if !daemonManager.ManagesSystemDaemons() {
+		zap.L().Warn("Not restarting systemd-logind, which is not managed by the daemon manager")
+		return nil
+	}
+	current, err := getLogindInhibitDelay()
+	if err != nil {
+		return fmt.Errorf("failed to get the systemd-logind inhibit delay: %w", err)
+	}
+	if current == configured {
+		return nil
+	}
+	zap.L().Info("Restarting systemd-logind to apply the inhibit delay..", zap.Duration("current", current), zap.Duration("configured", configured))
+	return daemonManager.RestartDaemon(logindDaemonName)
+}
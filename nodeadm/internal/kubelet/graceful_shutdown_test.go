@@ -0,0 +1,138 @@
+package kubelet
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon/daemontest"
+)
+
+func TestGracefulShutdownPeriod(t *testing.T) {
+	assert.Equal(t, time.Duration(0), gracefulShutdownPeriod(api.GracefulShutdownOptions{}))
+	assert.Equal(t, 45*time.Second, gracefulShutdownPeriod(api.GracefulShutdownOptions{
+		GracePeriod:             &metav1.Duration{Duration: 45 * time.Second},
+		CriticalPodsGracePeriod: &metav1.Duration{Duration: 15 * time.Second},
+	}))
+	assert.Equal(t, 90*time.Second, gracefulShutdownPeriod(api.GracefulShutdownOptions{
+		ByPodPriority: []api.ShutdownGracePeriodByPodPriority{
+			{Priority: 0, GracePeriodSeconds: 60},
+			{Priority: 100000, GracePeriodSeconds: 20},
+			{Priority: 2000000000, GracePeriodSeconds: 10},
+		},
+	}))
+}
+
+func TestWriteLogindDropIn(t *testing.T) {
+	oldLogindDropInPath := logindDropInPath
+	t.Cleanup(func() { logindDropInPath = oldLogindDropInPath })
+	logindDropInPath = path.Join(t.TempDir(), "logind.conf.d", "90-nodeadm-graceful-shutdown.conf")
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Kubelet: api.KubeletOptions{
+				GracefulShutdown: api.GracefulShutdownOptions{
+					GracePeriod: &metav1.Duration{Duration: 90500 * time.Millisecond},
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, writeLogindDropIn(&cfg))
+	content, err := os.ReadFile(logindDropInPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Generated by nodeadm from the NodeConfig, do not edit.\n\n[Login]\nInhibitDelayMaxSec=91\n", string(content))
+	delay, err := readLogindDropIn()
+	assert.NoError(t, err)
+	assert.Equal(t, 91*time.Second, delay)
+
+	cfg.Spec.Kubelet.GracefulShutdown = api.GracefulShutdownOptions{}
+	assert.NoError(t, writeLogindDropIn(&cfg))
+	assert.NoFileExists(t, logindDropInPath)
+	delay, err = readLogindDropIn()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), delay)
+	assert.NoError(t, writeLogindDropIn(&cfg))
+}
+
+func TestApplyLogindInhibitDelay(t *testing.T) {
+	oldLogindDropInPath, oldGetLogindInhibitDelay := logindDropInPath, getLogindInhibitDelay
+	t.Cleanup(func() {
+		logindDropInPath, getLogindInhibitDelay = oldLogindDropInPath, oldGetLogindInhibitDelay
+	})
+	logindDropInPath = path.Join(t.TempDir(), "logind.conf.d", "90-nodeadm-graceful-shutdown.conf")
+	currentDelay := 5 * time.Second
+	getLogindInhibitDelay = func() (time.Duration, error) {
+		return currentDelay, nil
+	}
+
+	// without a drop-in, the inhibit delay is left as is
+	daemonManager := &daemontest.FakeDaemonManager{SystemDaemons: true}
+	assert.NoError(t, applyLogindInhibitDelay(daemonManager))
+	assert.Empty(t, daemonManager.Restarted)
+
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Kubelet: api.KubeletOptions{
+				GracefulShutdown: api.GracefulShutdownOptions{
+					GracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+		},
+	}
+	assert.NoError(t, writeLogindDropIn(&cfg))
+	assert.NoError(t, applyLogindInhibitDelay(daemonManager))
+	assert.Equal(t, []string{logindDaemonName}, daemonManager.Restarted)
+
+	// systemd-logind already runs with the configured inhibit delay, such as
+	// when the config phase ran in an earlier invocation
+	currentDelay = 30 * time.Second
+	daemonManager = &daemontest.FakeDaemonManager{SystemDaemons: true}
+	assert.NoError(t, applyLogindInhibitDelay(daemonManager))
+	assert.Empty(t, daemonManager.Restarted)
+
+	// daemon managers that only run nodeadm's own daemons cannot restart it
+	currentDelay = 5 * time.Second
+	daemonManager = &daemontest.FakeDaemonManager{}
+	assert.NoError(t, applyLogindInhibitDelay(daemonManager))
+	assert.Empty(t, daemonManager.Restarted)
+}
+
+func TestGracefulShutdownByPodPriorityFeatureGate(t *testing.T) {
+	var tests = []struct {
+		kubeletVersion string
+		expectedGate   bool
+		expectedErr    string
+	}{
+		{kubeletVersion: "v1.22.17", expectedErr: "graceful shutdown by pod priority requires kubelet v1.23.0 or later, found v1.22.17"},
+		{kubeletVersion: "v1.23.0", expectedGate: true},
+		{kubeletVersion: "v1.24.0", expectedGate: false},
+		{kubeletVersion: "v1.29.0", expectedGate: false},
+	}
+
+	for _, test := range tests {
+		cfg := api.NodeConfig{
+			Spec: api.NodeConfigSpec{
+				Kubelet: api.KubeletOptions{
+					GracefulShutdown: api.GracefulShutdownOptions{
+						ByPodPriority: []api.ShutdownGracePeriodByPodPriority{{Priority: 0, GracePeriodSeconds: 30}},
+					},
+				},
+			},
+		}
+		kubeletConfig := defaultKubeletSubConfig()
+		err := kubeletConfig.withGracefulShutdown(&cfg, test.kubeletVersion)
+		if test.expectedErr != "" {
+			assert.EqualError(t, err, test.expectedErr)
+			continue
+		}
+		assert.NoError(t, err)
+		_, present := kubeletConfig.FeatureGates["GracefulNodeShutdownBasedOnPodPriority"]
+		assert.Equal(t, test.expectedGate, present, test.kubeletVersion)
+		assert.Len(t, kubeletConfig.ShutdownGracePeriodByPodPriority, 1)
+	}
+}
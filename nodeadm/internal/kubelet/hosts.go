@@ -0,0 +1,61 @@
+package kubelet
+
+import (
+	"net/url"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+// hostsRefreshDaemonName is the service that periodically refreshes the API
+// server addresses in /etc/hosts on outposts, using the environment written to
+// its drop-in.
+const hostsRefreshDaemonName = "nodeadm-hosts-refresh"
+
+func hostsRefreshEnabled(cfg *api.NodeConfig) bool {
+	enabled := cfg.Spec.Cluster.EnableOutpost
+	return enabled != nil && *enabled && cfg.Spec.Cluster.HostsRefreshInterval != nil
+}
+
+// configureHostsRefresh writes the settings of the hosts refresh service, or
+// removes them when refreshing is disabled.
+func configureHostsRefresh(daemonManager daemon.DaemonManager, cfg *api.NodeConfig) error {
+	var options api.SystemdOptions
+	if hostsRefreshEnabled(cfg) {
+		apiURL, err := url.Parse(cfg.Spec.Cluster.APIServerEndpoint)
+		if err != nil {
+			return err
+		}
+		options.Environment = map[string]string{
+			"NODEADM_HOSTS_HOSTNAME":         apiURL.Hostname(),
+			"NODEADM_HOSTS_REFRESH_INTERVAL": cfg.Spec.Cluster.HostsRefreshInterval.Duration.String(),
+		}
+	}
+	return daemon.ConfigureSystemdOptions(daemonManager, hostsRefreshDaemonName, options)
+}
+
+// applyHostsRefresh restarts the hosts refresh service to pick up any change of
+// its settings, or stops it when refreshing is disabled.
+func applyHostsRefresh(daemonManager daemon.DaemonManager, cfg *api.NodeConfig) error {
+	if !daemonManager.ManagesSystemDaemons() {
+		if hostsRefreshEnabled(cfg) {
+			zap.L().Warn("Not starting the hosts refresh service, which is not managed by the daemon manager")
+		}
+		return nil
+	}
+	if hostsRefreshEnabled(cfg) {
+		zap.L().Info("Restarting the hosts refresh service..")
+		return daemonManager.RestartDaemon(hostsRefreshDaemonName)
+	}
+	status, err := daemonManager.GetDaemonStatus(hostsRefreshDaemonName)
+	if err != nil {
+		return err
+	}
+	if status.State == daemon.DaemonStateRunning || status.State == daemon.DaemonStateStarting {
+		zap.L().Info("Stopping the hosts refresh service..")
+		return daemonManager.StopDaemon(hostsRefreshDaemonName)
+	}
+	return nil
+}
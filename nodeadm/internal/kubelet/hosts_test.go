@@ -0,0 +1,51 @@
+package kubelet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon/daemontest"
+)
+
+func TestApplyHostsRefresh(t *testing.T) {
+	enabled := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{
+				EnableOutpost:        ptr.Bool(true),
+				HostsRefreshInterval: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+	}
+	disabled := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{EnableOutpost: ptr.Bool(true)},
+		},
+	}
+	running := map[string]daemon.DaemonStatus{hostsRefreshDaemonName: {State: daemon.DaemonStateRunning}}
+
+	daemonManager := &daemontest.FakeDaemonManager{SystemDaemons: true}
+	assert.NoError(t, applyHostsRefresh(daemonManager, &enabled))
+	assert.Equal(t, []string{hostsRefreshDaemonName}, daemonManager.Restarted)
+
+	daemonManager = &daemontest.FakeDaemonManager{SystemDaemons: true, Statuses: running}
+	assert.NoError(t, applyHostsRefresh(daemonManager, &disabled))
+	assert.Equal(t, []string{hostsRefreshDaemonName}, daemonManager.Stopped)
+
+	// a service that is not running is left alone
+	daemonManager = &daemontest.FakeDaemonManager{SystemDaemons: true}
+	assert.NoError(t, applyHostsRefresh(daemonManager, &disabled))
+	assert.Empty(t, daemonManager.Stopped)
+
+	// daemon managers that only run nodeadm's own daemons cannot run the service
+	daemonManager = &daemontest.FakeDaemonManager{Statuses: running}
+	assert.NoError(t, applyHostsRefresh(daemonManager, &enabled))
+	assert.NoError(t, applyHostsRefresh(daemonManager, &disabled))
+	assert.Empty(t, daemonManager.Restarted)
+	assert.Empty(t, daemonManager.Stopped)
+}
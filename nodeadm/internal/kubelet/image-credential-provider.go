@@ -1,85 +1,103 @@
 package kubelet
 
 import (
-	"bytes"
-	_ "embed"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
-	"path/filepath"
-	"text/template"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/credentialprovider"
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
-	"go.uber.org/zap"
 	"golang.org/x/mod/semver"
 )
 
 const (
-	// #nosec G101 //constant path, not credential
-	imageCredentialProviderRoot = "/etc/eks/image-credential-provider"
-	// #nosec G101 //constant path, not credential
-	imageCredentialProviderConfig = "config.json"
-	imageCredentialProviderPerm   = 0644
-	// #nosec G101 //constant path, not credential
-	ecrCredentialProviderBinPathEnvironmentName = "ECR_CREDENTIAL_PROVIDER_BIN_PATH"
-)
+	imageCredentialProviderPerm = 0644
 
-var (
-	//go:embed image-credential-provider.template.json
-	imageCredentialProviderTemplateData string
-	imageCredentialProviderTemplate     = template.Must(template.New("image-credential-provider").Parse(imageCredentialProviderTemplateData))
-	imageCredentialProviderConfigPath   = path.Join(imageCredentialProviderRoot, imageCredentialProviderConfig)
+	defaultCredentialProviderCacheDuration = 12 * time.Hour
 )
 
 func (k *kubelet) writeImageCredentialProviderConfig(cfg *api.NodeConfig) error {
-	// fallback default for image credential provider binary if not overridden
-	ecrCredentialProviderBinPath := path.Join(imageCredentialProviderRoot, "ecr-credential-provider")
-	if binPath, set := os.LookupEnv(ecrCredentialProviderBinPathEnvironmentName); set {
-		zap.L().Info("picked up image credential provider binary path from environment", zap.String("bin-path", binPath))
-		ecrCredentialProviderBinPath = binPath
+	binDir, providers := credentialprovider.ResolveProviders(cfg.Spec.Kubelet.ImageCredentialProviders)
+	for _, provider := range providers {
+		if err := ensureCredentialProviderBinaryExists(path.Join(binDir, provider.Name)); err != nil {
+			return err
+		}
 	}
-	if err := ensureCredentialProviderBinaryExists(ecrCredentialProviderBinPath); err != nil {
+
+	kubeletVersion, err := GetKubeletVersion()
+	if err != nil {
 		return err
 	}
-
-	config, err := generateImageCredentialProviderConfig(cfg, ecrCredentialProviderBinPath)
+	config, err := generateImageCredentialProviderConfig(providers, kubeletVersion)
 	if err != nil {
 		return err
 	}
 
-	k.flags["image-credential-provider-bin-dir"] = path.Dir(ecrCredentialProviderBinPath)
-	k.flags["image-credential-provider-config"] = imageCredentialProviderConfigPath
-
-	return util.WriteFileWithDir(imageCredentialProviderConfigPath, config, imageCredentialProviderPerm)
-}
+	k.flags["image-credential-provider-bin-dir"] = binDir
+	k.flags["image-credential-provider-config"] = credentialprovider.ConfigPath
 
-type imageCredentialProviderTemplateVars struct {
-	ConfigApiVersion   string
-	ProviderApiVersion string
-	EcrProviderName    string
+	return util.WriteFileWithDir(credentialprovider.ConfigPath, config, imageCredentialProviderPerm)
 }
 
-func generateImageCredentialProviderConfig(cfg *api.NodeConfig, ecrCredentialProviderBinPath string) ([]byte, error) {
-	templateVars := imageCredentialProviderTemplateVars{
-		EcrProviderName: filepath.Base(ecrCredentialProviderBinPath),
+func generateImageCredentialProviderConfig(providers []api.ImageCredentialProvider, kubeletVersion string) ([]byte, error) {
+	configAPIVersion, providerAPIVersion := credentialProviderAPIVersions(kubeletVersion)
+	config := credentialprovider.Config{
+		APIVersion: configAPIVersion,
+		Kind:       "CredentialProviderConfig",
 	}
-	kubeletVersion, err := GetKubeletVersion()
-	if err != nil {
-		return nil, err
+	for _, provider := range providers {
+		matchImages := provider.MatchImages
+		if len(matchImages) == 0 {
+			matchImages = credentialprovider.ECRMatchImages
+		}
+		cacheDuration := defaultCredentialProviderCacheDuration
+		if provider.DefaultCacheDuration != nil {
+			cacheDuration = provider.DefaultCacheDuration.Duration
+		}
+		var env []credentialprovider.EnvVar
+		for name, value := range provider.Env {
+			env = append(env, credentialprovider.EnvVar{Name: name, Value: value})
+		}
+		sort.Slice(env, func(i, j int) bool {
+			return env[i].Name < env[j].Name
+		})
+		config.Providers = append(config.Providers, credentialprovider.Provider{
+			Name:                 provider.Name,
+			MatchImages:          matchImages,
+			DefaultCacheDuration: formatDuration(cacheDuration),
+			APIVersion:           providerAPIVersion,
+			Args:                 provider.Args,
+			Env:                  env,
+		})
 	}
+	return json.MarshalIndent(config, "", "  ")
+}
+
+// credentialProviderAPIVersions returns the CredentialProviderConfig and
+// CredentialProviderRequest API versions supported by the kubelet version
+func credentialProviderAPIVersions(kubeletVersion string) (string, string) {
 	if semver.Compare(kubeletVersion, "v1.27.0") < 0 {
-		templateVars.ConfigApiVersion = "kubelet.config.k8s.io/v1alpha1"
-		templateVars.ProviderApiVersion = "credentialprovider.kubelet.k8s.io/v1alpha1"
-	} else {
-		templateVars.ConfigApiVersion = "kubelet.config.k8s.io/v1"
-		templateVars.ProviderApiVersion = "credentialprovider.kubelet.k8s.io/v1"
+		return "kubelet.config.k8s.io/v1alpha1", "credentialprovider.kubelet.k8s.io/v1alpha1"
+	}
+	return "kubelet.config.k8s.io/v1", "credentialprovider.kubelet.k8s.io/v1"
+}
+
+// formatDuration formats a duration without trailing zero units, such as 12h
+// rather than 12h0m0s
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
 	}
-	var buf bytes.Buffer
-	if err := imageCredentialProviderTemplate.Execute(&buf, templateVars); err != nil {
-		return nil, err
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
 	}
-	return buf.Bytes(), nil
+	return s
 }
 
 func ensureCredentialProviderBinaryExists(binPath string) error {
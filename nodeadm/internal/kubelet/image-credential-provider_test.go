@@ -0,0 +1,125 @@
+package kubelet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/credentialprovider"
+)
+
+func TestGenerateImageCredentialProviderConfig(t *testing.T) {
+	t.Setenv(credentialprovider.BinPathEnvironmentName, "/usr/local/bin/ecr-credential-provider")
+	binDir, providers := credentialprovider.ResolveProviders(api.ImageCredentialProviderOptions{})
+	assert.Equal(t, "/usr/local/bin", binDir)
+
+	config, err := generateImageCredentialProviderConfig(providers, "v1.26.0")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"apiVersion": "kubelet.config.k8s.io/v1alpha1",
+		"kind": "CredentialProviderConfig",
+		"providers": [
+			{
+				"name": "ecr-credential-provider",
+				"matchImages": [
+					"*.dkr.ecr.*.amazonaws.com",
+					"*.dkr.ecr.*.amazonaws.com.cn",
+					"*.dkr.ecr-fips.*.amazonaws.com",
+					"*.dkr.ecr.*.c2s.ic.gov",
+					"*.dkr.ecr.*.sc2s.sgov.gov"
+				],
+				"defaultCacheDuration": "12h",
+				"apiVersion": "credentialprovider.kubelet.k8s.io/v1alpha1"
+			}
+		]
+	}`, string(config))
+
+	binDir, providers = credentialprovider.ResolveProviders(api.ImageCredentialProviderOptions{
+		BinDir: "/opt/credential-providers",
+		Providers: []api.ImageCredentialProvider{
+			{
+				Name:        "ecr-credential-provider",
+				MatchImages: []string{"*.dkr.ecr.*.vpce.amazonaws.com"},
+			},
+			{
+				Name:                 "registry-helper",
+				MatchImages:          []string{"registry.example.com:5000/team"},
+				DefaultCacheDuration: &metav1.Duration{Duration: 90 * time.Minute},
+				Args:                 []string{"--verbose"},
+				Env:                  map[string]string{"REGION": "us-west-2", "AUTH_MODE": "token"},
+			},
+		},
+	})
+	assert.Equal(t, "/opt/credential-providers", binDir)
+
+	config, err = generateImageCredentialProviderConfig(providers, "v1.29.0")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"apiVersion": "kubelet.config.k8s.io/v1",
+		"kind": "CredentialProviderConfig",
+		"providers": [
+			{
+				"name": "ecr-credential-provider",
+				"matchImages": ["*.dkr.ecr.*.vpce.amazonaws.com"],
+				"defaultCacheDuration": "12h",
+				"apiVersion": "credentialprovider.kubelet.k8s.io/v1"
+			},
+			{
+				"name": "registry-helper",
+				"matchImages": ["registry.example.com:5000/team"],
+				"defaultCacheDuration": "1h30m",
+				"apiVersion": "credentialprovider.kubelet.k8s.io/v1",
+				"args": ["--verbose"],
+				"env": [
+					{"name": "AUTH_MODE", "value": "token"},
+					{"name": "REGION", "value": "us-west-2"}
+				]
+			}
+		]
+	}`, string(config))
+}
+
+func TestValidateImageCredentialProviders(t *testing.T) {
+	var tests = []struct {
+		name          string
+		provider      api.ImageCredentialProvider
+		expectedError string
+	}{
+		{name: "ecr defaults", provider: api.ImageCredentialProvider{Name: "ecr-credential-provider"}},
+		{name: "ports and paths", provider: api.ImageCredentialProvider{Name: "helper", MatchImages: []string{"*.example.com:443/path", "registry.*"}}},
+		{name: "missing match images", provider: api.ImageCredentialProvider{Name: "helper"}, expectedError: "Image credential provider helper has no matchImages"},
+		{name: "path name", provider: api.ImageCredentialProvider{Name: "bin/helper", MatchImages: []string{"example.com"}}, expectedError: `Invalid image credential provider name: "bin/helper"`},
+		{name: "scheme", provider: api.ImageCredentialProvider{Name: "helper", MatchImages: []string{"https://example.com"}}, expectedError: `Invalid matchImages pattern "https://example.com" for image credential provider helper: pattern must not have a scheme`},
+		{name: "glob in path", provider: api.ImageCredentialProvider{Name: "helper", MatchImages: []string{"example.com/*"}}, expectedError: `Invalid matchImages pattern "example.com/*" for image credential provider helper: globs are only allowed in the domain`},
+		{name: "negative cache duration", provider: api.ImageCredentialProvider{Name: "helper", MatchImages: []string{"example.com"}, DefaultCacheDuration: &metav1.Duration{Duration: -time.Second}}, expectedError: "Image credential provider helper defaultCacheDuration must not be negative"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Cluster: api.ClusterDetails{
+						Name:                 "my-cluster",
+						APIServerEndpoint:    "https://example.com",
+						CertificateAuthority: []byte("ca"),
+						CIDR:                 "10.100.0.0/16",
+					},
+					Kubelet: api.KubeletOptions{
+						ImageCredentialProviders: api.ImageCredentialProviderOptions{
+							Providers: []api.ImageCredentialProvider{test.provider},
+						},
+					},
+				},
+			}
+			err := api.ValidateNodeConfig(&cfg)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
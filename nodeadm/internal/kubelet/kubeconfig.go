@@ -3,6 +3,8 @@ package kubelet
 import (
 	"bytes"
 	_ "embed"
+	"fmt"
+	"os"
 	"path"
 	"text/template"
 
@@ -15,6 +17,10 @@ const (
 	kubeconfigFile          = "kubeconfig"
 	kubeconfigBootstrapFile = "bootstrap-kubeconfig"
 	kubeconfigPerm          = 0644
+	// kubeconfigSecretPerm is used when the kubeconfig holds a credential
+	kubeconfigSecretPerm = 0600
+
+	awsIAMAuthenticatorPath = "/usr/bin/aws-iam-authenticator"
 )
 
 var (
@@ -30,37 +36,96 @@ func (k *kubelet) writeKubeconfig(cfg *api.NodeConfig) error {
 	if err != nil {
 		return err
 	}
-	if enabled := cfg.Spec.Cluster.EnableOutpost; enabled != nil && *enabled {
-		// kubelet bootstrap kubeconfig uses aws-iam-authenticator with cluster id to authenticate to cluster
-		//   - if "aws eks describe-cluster" is bypassed, for local outpost, the value of CLUSTER_NAME parameter will be cluster id.
-		//   - otherwise, the cluster id will use the id returned by "aws eks describe-cluster".
+	perm := os.FileMode(kubeconfigPerm)
+	if cfg.Spec.Cluster.Auth.Mode == api.ClusterAuthModeBootstrapToken {
+		perm = kubeconfigSecretPerm
+	}
+	if usesBootstrapKubeconfig(cfg) {
+		// kubelet requests a client certificate with the bootstrap kubeconfig
+		// and writes the kubeconfig that uses it. On local outposts, this lets
+		// the node keep authenticating while disconnected from the region.
 		k.flags["bootstrap-kubeconfig"] = kubeconfigBootstrapPath
-		return util.WriteFileWithDir(kubeconfigBootstrapPath, kubeconfig, kubeconfigPerm)
+		k.flags["kubeconfig"] = kubeconfigPath
+		return util.WriteFileWithDir(kubeconfigBootstrapPath, kubeconfig, perm)
 	} else {
 		k.flags["kubeconfig"] = kubeconfigPath
-		return util.WriteFileWithDir(kubeconfigPath, kubeconfig, kubeconfigPerm)
+		return util.WriteFileWithDir(kubeconfigPath, kubeconfig, perm)
 	}
 }
 
+// usesBootstrapKubeconfig returns whether kubelet uses TLS bootstrapping to
+// get its client certificate
+func usesBootstrapKubeconfig(cfg *api.NodeConfig) bool {
+	if cfg.Spec.Cluster.Auth.Mode == api.ClusterAuthModeBootstrapToken {
+		return true
+	}
+	enabled := cfg.Spec.Cluster.EnableOutpost
+	return enabled != nil && *enabled
+}
+
 type kubeconfigTemplateVars struct {
-	Cluster           string
-	Region            string
 	APIServerEndpoint string
 	CaCertPath        string
+	ExecCommand       string
+	ExecArgs          []string
+	Token             string
+	ClientCertificate string
+	ClientKey         string
 }
 
-func generateKubeconfig(cfg *api.NodeConfig) ([]byte, error) {
-	cluster := cfg.Spec.Cluster.Name
+// getAuthClusterName returns the cluster identifier that kubelet uses to
+// authenticate with the cluster.
+func getAuthClusterName(cfg *api.NodeConfig) string {
 	if enabled := cfg.Spec.Cluster.EnableOutpost; enabled != nil && *enabled {
-		cluster = cfg.Spec.Cluster.ID
+		return cfg.Spec.Cluster.ID
 	}
+	if cfg.Spec.Cluster.Auth.Mode == api.ClusterAuthModeAWSIAMAuthenticator && cfg.Spec.Cluster.ID != "" {
+		return cfg.Spec.Cluster.ID
+	}
+	return cfg.Spec.Cluster.Name
+}
 
+// getExecCredentialCommand returns the exec credential plugin command of the
+// auth mode, or false if the mode does not use one.
+func getExecCredentialCommand(cfg *api.NodeConfig) (string, []string, bool) {
+	auth := cfg.Spec.Cluster.Auth
+	switch auth.Mode {
+	case "", api.ClusterAuthModeEKSGetToken:
+		args := []string{"eks", "get-token", "--cluster-name", getAuthClusterName(cfg), "--region", cfg.Status.Instance.Region}
+		if auth.RoleARN != "" {
+			args = append(args, "--role-arn", auth.RoleARN)
+		}
+		return "aws", args, true
+	case api.ClusterAuthModeAWSIAMAuthenticator:
+		args := []string{"token", "-i", getAuthClusterName(cfg), "--region", cfg.Status.Instance.Region}
+		if auth.RoleARN != "" {
+			args = append(args, "--role", auth.RoleARN)
+		}
+		return awsIAMAuthenticatorPath, args, true
+	default:
+		return "", nil, false
+	}
+}
+
+func generateKubeconfig(cfg *api.NodeConfig) ([]byte, error) {
 	config := kubeconfigTemplateVars{
-		Cluster:           cluster,
-		Region:            cfg.Status.Instance.Region,
 		APIServerEndpoint: cfg.Spec.Cluster.APIServerEndpoint,
 		CaCertPath:        caCertificatePath,
 	}
+	switch auth := cfg.Spec.Cluster.Auth; auth.Mode {
+	case api.ClusterAuthModeBootstrapToken:
+		config.Token = auth.BootstrapToken
+	case api.ClusterAuthModeClientCertificate:
+		config.ClientCertificate = auth.ClientCertificate
+		config.ClientKey = auth.ClientKey
+	default:
+		command, args, ok := getExecCredentialCommand(cfg)
+		if !ok {
+			return nil, fmt.Errorf("unknown cluster auth mode: %s", auth.Mode)
+		}
+		config.ExecCommand = command
+		config.ExecArgs = args
+	}
 
 	var buf bytes.Buffer
 	if err := kubeconfigTemplate.Execute(&buf, config); err != nil {
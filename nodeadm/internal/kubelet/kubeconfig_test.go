@@ -0,0 +1,101 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+const expectedDefaultKubeconfig = `---
+apiVersion: v1
+kind: Config
+clusters:
+  - name: kubernetes
+    cluster:
+      certificate-authority: /etc/kubernetes/pki/ca.crt
+      server: https://example.com
+current-context: kubelet
+contexts:
+  - name: kubelet
+    context:
+      cluster: kubernetes
+      user: kubelet
+users:
+  - name: kubelet
+    user:
+      exec:
+        apiVersion: client.authentication.k8s.io/v1beta1
+        command: aws
+        args:
+          - "eks"
+          - "get-token"
+          - "--cluster-name"
+          - "my-cluster"
+          - "--region"
+          - "us-west-2"
+`
+
+func TestGenerateKubeconfig(t *testing.T) {
+	var tests = []struct {
+		name         string
+		cluster      api.ClusterDetails
+		expectedUser string
+	}{
+		{
+			name:         "eks get-token with role",
+			cluster:      api.ClusterDetails{Auth: api.ClusterAuthOptions{RoleARN: "arn:aws:iam::123456789012:role/node"}},
+			expectedUser: `{"exec":{"apiVersion":"client.authentication.k8s.io/v1beta1","command":"aws","args":["eks","get-token","--cluster-name","my-cluster","--region","us-west-2","--role-arn","arn:aws:iam::123456789012:role/node"]}}`,
+		},
+		{
+			name:         "aws-iam-authenticator with cluster id",
+			cluster:      api.ClusterDetails{ID: "my-cluster-id", Auth: api.ClusterAuthOptions{Mode: api.ClusterAuthModeAWSIAMAuthenticator}},
+			expectedUser: `{"exec":{"apiVersion":"client.authentication.k8s.io/v1beta1","command":"/usr/bin/aws-iam-authenticator","args":["token","-i","my-cluster-id","--region","us-west-2"]}}`,
+		},
+		{
+			name:         "bootstrap token",
+			cluster:      api.ClusterDetails{Auth: api.ClusterAuthOptions{Mode: api.ClusterAuthModeBootstrapToken, BootstrapToken: "abcdef.0123456789abcdef"}},
+			expectedUser: `{"token":"abcdef.0123456789abcdef"}`,
+		},
+		{
+			name:         "client certificate",
+			cluster:      api.ClusterDetails{Auth: api.ClusterAuthOptions{Mode: api.ClusterAuthModeClientCertificate, ClientCertificate: "/etc/kubernetes/pki/kubelet.crt", ClientKey: "/etc/kubernetes/pki/kubelet.key"}},
+			expectedUser: `{"client-certificate":"/etc/kubernetes/pki/kubelet.crt","client-key":"/etc/kubernetes/pki/kubelet.key"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.cluster.Name = "my-cluster"
+			test.cluster.APIServerEndpoint = "https://example.com"
+			cfg := api.NodeConfig{
+				Spec:   api.NodeConfigSpec{Cluster: test.cluster},
+				Status: api.NodeConfigStatus{Instance: api.InstanceDetails{Region: "us-west-2"}},
+			}
+			kubeconfig, err := generateKubeconfig(&cfg)
+			assert.NoError(t, err)
+			var parsed struct {
+				Users []struct {
+					User map[string]interface{} `json:"user"`
+				} `json:"users"`
+			}
+			assert.NoError(t, yaml.Unmarshal(kubeconfig, &parsed))
+			assert.Len(t, parsed.Users, 1)
+			user, err := yaml.Marshal(parsed.Users[0].User)
+			assert.NoError(t, err)
+			assert.YAMLEq(t, test.expectedUser, string(user))
+		})
+	}
+
+	cfg := api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Cluster: api.ClusterDetails{Name: "my-cluster", APIServerEndpoint: "https://example.com"},
+		},
+		Status: api.NodeConfigStatus{Instance: api.InstanceDetails{Region: "us-west-2"}},
+	}
+	kubeconfig, err := generateKubeconfig(&cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDefaultKubeconfig, string(kubeconfig))
+}
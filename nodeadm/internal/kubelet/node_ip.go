@@ -0,0 +1,171 @@
+package kubelet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+// hostInterface is a network interface on the host with its global unicast
+// addresses
+type hostInterface struct {
+	name      string
+	mac       string
+	addresses []net.IP
+}
+
+var getHostInterfaces = func() ([]hostInterface, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var hostInterfaces []hostInterface
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) == 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		hostIface := hostInterface{name: iface.Name, mac: iface.HardwareAddr.String()}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.IsGlobalUnicast() {
+				hostIface.addresses = append(hostIface.addresses, ipNet.IP)
+			}
+		}
+		hostInterfaces = append(hostInterfaces, hostIface)
+	}
+	return hostInterfaces, nil
+}
+
+// getMACForDeviceIndex returns the MAC address of the ENI attached at a device
+// index
+var getMACForDeviceIndex = func(ctx context.Context, imdsClient *imds.Client, deviceIndex int32) (string, error) {
+	macs, err := getMetadata(ctx, imdsClient, "network/interfaces/macs")
+	if err != nil {
+		return "", err
+	}
+	for _, mac := range strings.Fields(macs) {
+		mac = strings.TrimSuffix(mac, "/")
+		deviceNumber, err := getMetadata(ctx, imdsClient, fmt.Sprintf("network/interfaces/macs/%s/device-number", mac))
+		if err != nil {
+			return "", err
+		}
+		if deviceNumber == strconv.Itoa(int(deviceIndex)) {
+			return mac, nil
+		}
+	}
+	return "", fmt.Errorf("no network interface is attached at device index %d", deviceIndex)
+}
+
+func getMetadata(ctx context.Context, imdsClient *imds.Client, path string) (string, error) {
+	res, err := imdsClient.GetMetadata(ctx, &imds.GetMetadataInput{Path: path})
+	if err != nil {
+		return "", err
+	}
+	content, err := io.ReadAll(res.Content)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func nodeIPOptionsSet(options api.NodeIPOptions) bool {
+	return options.Interface != "" || options.MAC != "" || options.DeviceIndex != nil || len(options.CIDRs) > 0 || options.DualStack
+}
+
+// getSelectedNodeIP returns the --node-ip selected by the NodeIPOptions from
+// the addresses present on the host
+func getSelectedNodeIP(ctx context.Context, imdsClient *imds.Client, cfg *api.NodeConfig) (string, error) {
+	ipFamily, err := api.GetCIDRIpFamily(cfg.Spec.Cluster.CIDR)
+	if err != nil {
+		return "", err
+	}
+	options := cfg.Spec.Kubelet.NodeIP
+	mac := options.MAC
+	if options.DeviceIndex != nil {
+		if mac, err = getMACForDeviceIndex(ctx, imdsClient, *options.DeviceIndex); err != nil {
+			return "", err
+		}
+	} else if options.Interface == "" && mac == "" && len(options.CIDRs) == 0 {
+		mac = cfg.Status.Instance.MAC
+	}
+	interfaces, err := getHostInterfaces()
+	if err != nil {
+		return "", err
+	}
+	return selectNodeIP(options, ipFamily, mac, interfaces)
+}
+
+// selectNodeIP picks the first address of each IP family from the interfaces
+// that match the interface name or MAC address, if any, and the CIDRs. The
+// cluster's IP family comes first.
+func selectNodeIP(options api.NodeIPOptions, ipFamily api.IPFamily, mac string, interfaces []hostInterface) (string, error) {
+	var candidates []hostInterface
+	for _, iface := range interfaces {
+		if options.Interface != "" && iface.name != options.Interface {
+			continue
+		}
+		if mac != "" && !strings.EqualFold(iface.mac, mac) {
+			continue
+		}
+		candidates = append(candidates, iface)
+	}
+	description := "the host"
+	if options.Interface != "" {
+		description = fmt.Sprintf("network interface %s", options.Interface)
+	} else if mac != "" {
+		description = fmt.Sprintf("network interface %s", mac)
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("%s was not found", description)
+	}
+
+	families := []api.IPFamily{ipFamily}
+	if options.DualStack {
+		if ipFamily == api.IPFamilyIPv4 {
+			families = append(families, api.IPFamilyIPv6)
+		} else {
+			families = append(families, api.IPFamilyIPv4)
+		}
+	}
+	var nodeIPs []string
+	for _, family := range families {
+		ip := findAddress(candidates, family, options.CIDRs)
+		if ip == nil {
+			if len(options.CIDRs) > 0 {
+				return "", fmt.Errorf("no %s address on %s is in %s", family, description, strings.Join(options.CIDRs, ", "))
+			}
+			return "", fmt.Errorf("no %s address on %s", family, description)
+		}
+		nodeIPs = append(nodeIPs, ip.String())
+	}
+	return strings.Join(nodeIPs, ","), nil
+}
+
+func findAddress(interfaces []hostInterface, family api.IPFamily, cidrs []string) net.IP {
+	for _, iface := range interfaces {
+		for _, ip := range iface.addresses {
+			if (ip.To4() != nil) != (family == api.IPFamilyIPv4) {
+				continue
+			}
+			if len(cidrs) == 0 {
+				return ip
+			}
+			for _, cidr := range cidrs {
+				if _, ipNet, err := net.ParseCIDR(cidr); err == nil && ipNet.Contains(ip) {
+					return ip
+				}
+			}
+		}
+	}
+	return nil
+}
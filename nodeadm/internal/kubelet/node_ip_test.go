@@ -0,0 +1,58 @@
+package kubelet
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestSelectNodeIP(t *testing.T) {
+	interfaces := []hostInterface{
+		{
+			name:      "ens5",
+			mac:       "0a:00:00:00:00:01",
+			addresses: []net.IP{net.ParseIP("10.0.0.10"), net.ParseIP("2600:1f14::10")},
+		},
+		{
+			name:      "ens6",
+			mac:       "0a:00:00:00:00:02",
+			addresses: []net.IP{net.ParseIP("10.1.0.20"), net.ParseIP("100.64.0.20")},
+		},
+	}
+
+	var tests = []struct {
+		name          string
+		options       api.NodeIPOptions
+		ipFamily      api.IPFamily
+		mac           string
+		expectedIP    string
+		expectedError string
+	}{
+		{name: "primary interface", ipFamily: api.IPFamilyIPv4, mac: "0a:00:00:00:00:01", expectedIP: "10.0.0.10"},
+		{name: "interface name", options: api.NodeIPOptions{Interface: "ens6"}, ipFamily: api.IPFamilyIPv4, expectedIP: "10.1.0.20"},
+		{name: "mac address is case insensitive", options: api.NodeIPOptions{MAC: "0A:00:00:00:00:02"}, ipFamily: api.IPFamilyIPv4, mac: "0A:00:00:00:00:02", expectedIP: "10.1.0.20"},
+		{name: "cidr across interfaces", options: api.NodeIPOptions{CIDRs: []string{"100.64.0.0/10"}}, ipFamily: api.IPFamilyIPv4, expectedIP: "100.64.0.20"},
+		{name: "interface and cidr", options: api.NodeIPOptions{Interface: "ens6", CIDRs: []string{"10.0.0.0/8"}}, ipFamily: api.IPFamilyIPv4, expectedIP: "10.1.0.20"},
+		{name: "ipv6 cluster", ipFamily: api.IPFamilyIPv6, mac: "0a:00:00:00:00:01", expectedIP: "2600:1f14::10"},
+		{name: "dual-stack ipv4 cluster", options: api.NodeIPOptions{DualStack: true}, ipFamily: api.IPFamilyIPv4, mac: "0a:00:00:00:00:01", expectedIP: "10.0.0.10,2600:1f14::10"},
+		{name: "dual-stack ipv6 cluster", options: api.NodeIPOptions{DualStack: true}, ipFamily: api.IPFamilyIPv6, mac: "0a:00:00:00:00:01", expectedIP: "2600:1f14::10,10.0.0.10"},
+		{name: "missing interface", options: api.NodeIPOptions{Interface: "ens7"}, ipFamily: api.IPFamilyIPv4, expectedError: "network interface ens7 was not found"},
+		{name: "missing family", options: api.NodeIPOptions{Interface: "ens6", DualStack: true}, ipFamily: api.IPFamilyIPv4, expectedError: "no ipv6 address on network interface ens6"},
+		{name: "no address in cidr", options: api.NodeIPOptions{CIDRs: []string{"192.168.0.0/16"}}, ipFamily: api.IPFamilyIPv4, expectedError: "no ipv4 address on the host is in 192.168.0.0/16"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeIP, err := selectNodeIP(test.options, test.ipFamily, test.mac, interfaces)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedIP, nodeIP)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package kubelet
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+const (
+	defaultReadinessTimeout = 3 * time.Minute
+
+	defaultHealthzBindAddress = "127.0.0.1"
+	defaultHealthzPort        = 10248
+
+	readinessRequestTimeout = 5 * time.Second
+
+	kubeletClientCertificatePath = "/var/lib/kubelet/pki/kubelet-client-current.pem"
+)
+
+func waitForKubeletReady(cfg *api.NodeConfig) error {
+	readiness := cfg.Spec.Kubelet.Readiness
+	timeout := defaultReadinessTimeout
+	if readiness.Timeout != nil {
+		timeout = readiness.Timeout.Duration
+	}
+
+	var conditions []daemon.ReadinessCondition
+	healthzURL, err := getHealthzURL(kubeletConfigRoot)
+	if err != nil {
+		return err
+	}
+	if healthzURL != "" {
+		conditions = append(conditions, daemon.ReadinessCondition{
+			Name:  "Healthz",
+			Check: healthzCheck(healthzURL),
+		})
+	} else {
+		zap.L().Info("Skipping kubelet healthz readiness check because the healthz endpoint is disabled")
+	}
+	if waitForRegistration := readiness.WaitForNodeRegistration; waitForRegistration != nil && *waitForRegistration {
+		check, err := nodeRegistrationCheck(cfg)
+		if err != nil {
+			return err
+		}
+		conditions = append(conditions, daemon.ReadinessCondition{
+			Name:  "NodeRegistered",
+			Check: check,
+		})
+	}
+	return daemon.WaitForReadiness(KubeletDaemonName, timeout, conditions...)
+}
+
+// getHealthzURL returns the URL of kubelet's healthz endpoint in the kubelet
+// configuration that nodeadm wrote, which holds the user's configuration, its
+// drop-ins and any migrated flags. The drop-ins are applied in the same order
+// as kubelet. An empty URL is returned if the endpoint has been disabled.
+func getHealthzURL(configRoot string) (string, error) {
+	config := struct {
+		HealthzBindAddress string `json:"healthzBindAddress"`
+		HealthzPort        int32  `json:"healthzPort"`
+	}{
+		HealthzBindAddress: defaultHealthzBindAddress,
+		HealthzPort:        defaultHealthzPort,
+	}
+	dropInPaths, err := filepath.Glob(path.Join(configRoot, kubeletConfigDir, "*.conf"))
+	if err != nil {
+		return "", err
+	}
+	for _, configPath := range append([]string{path.Join(configRoot, kubeletConfigFile)}, dropInPaths...) {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return "", err
+		}
+		// fields that a file does not set keep their previous values
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return "", fmt.Errorf("invalid kubelet config %s: %v", configPath, err)
+		}
+	}
+	if config.HealthzPort == 0 {
+		return "", nil
+	}
+	address := config.HealthzBindAddress
+	// kubelet listens on every interface for an unspecified address, so
+	// loopback is always reachable.
+	if ip := net.ParseIP(address); address == "" || (ip != nil && ip.IsUnspecified()) {
+		address = defaultHealthzBindAddress
+	}
+	return fmt.Sprintf("http://%s/healthz", net.JoinHostPort(address, strconv.Itoa(int(config.HealthzPort)))), nil
+}
+
+func healthzCheck(healthzURL string) func() (bool, string, error) {
+	client := &http.Client{Timeout: readinessRequestTimeout}
+	return func() (bool, string, error) {
+		resp, err := client.Get(healthzURL)
+		if err != nil {
+			return false, "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return false, "", err
+		}
+		if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != "ok" {
+			return false, fmt.Sprintf("%s returned %s: %s", healthzURL, resp.Status, strings.TrimSpace(string(body))), nil
+		}
+		return true, "", nil
+	}
+}
+
+// nodeRegistrationCheck returns a check that is true once this node's Node
+// object exists in the cluster. It authenticates with the same identity that
+// kubelet uses.
+func nodeRegistrationCheck(cfg *api.NodeConfig) (func() (bool, string, error), error) {
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(cfg.Spec.Cluster.CertificateAuthority) {
+		return nil, fmt.Errorf("failed to parse cluster certificate authority")
+	}
+	nodeName := getNodeName(cfg)
+	nodeURL, err := url.JoinPath(cfg.Spec.Cluster.APIServerEndpoint, "api/v1/nodes", nodeName)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{RootCAs: certPool}
+	_, _, usesExecCredential := getExecCredentialCommand(cfg)
+	switch cfg.Spec.Cluster.Auth.Mode {
+	case api.ClusterAuthModeClientCertificate:
+		certificate, err := tls.LoadX509KeyPair(cfg.Spec.Cluster.Auth.ClientCertificate, cfg.Spec.Cluster.Auth.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	case api.ClusterAuthModeBootstrapToken:
+		// bootstrap tokens cannot read nodes, so the client certificate that
+		// kubelet receives is used once it exists
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			certificate, err := tls.LoadX509KeyPair(kubeletClientCertificatePath, kubeletClientCertificatePath)
+			if err != nil {
+				return nil, fmt.Errorf("kubelet client certificate is not available yet: %w", err)
+			}
+			return &certificate, nil
+		}
+	}
+	client := &http.Client{
+		Timeout:   readinessRequestTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	// tokens are valid for 15 minutes, which is longer than any sensible
+	// readiness timeout, so a token is fetched once and then reused.
+	var token string
+	return func() (bool, string, error) {
+		if usesExecCredential && token == "" {
+			t, err := getAuthToken(cfg)
+			if err != nil {
+				return false, "", err
+			}
+			token = t
+		}
+		req, err := http.NewRequest(http.MethodGet, nodeURL, nil)
+		if err != nil {
+			return false, "", err
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return false, "", err
+		}
+		defer resp.Body.Close()
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return true, "", nil
+		case http.StatusNotFound:
+			return false, fmt.Sprintf("node %s is not registered yet", nodeName), nil
+		default:
+			return false, fmt.Sprintf("kube-apiserver returned %s for node %s", resp.Status, nodeName), nil
+		}
+	}, nil
+}
+
+// getAuthToken fetches a bearer token for the cluster the same way that the
+// exec credential plugin in kubelet's kubeconfig does.
+func getAuthToken(cfg *api.NodeConfig) (string, error) {
+	command, args, _ := getExecCredentialCommand(cfg)
+	if command == "aws" {
+		args = append(args, "--output", "json")
+	}
+	// #nosec G204 Subprocess launched with variable
+	out, err := exec.Command(command, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get token for cluster: %v", err)
+	}
+	var credential struct {
+		Status struct {
+			Token string `json:"token"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(out, &credential); err != nil {
+		return "", err
+	}
+	if credential.Status.Token == "" {
+		return "", fmt.Errorf("no token in credential for cluster")
+	}
+	return credential.Status.Token, nil
+}
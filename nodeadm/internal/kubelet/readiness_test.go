@@ -0,0 +1,69 @@
+package kubelet
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHealthzURL(t *testing.T) {
+	var tests = []struct {
+		name        string
+		config      string
+		dropIns     map[string]string
+		expectedURL string
+	}{
+		{
+			name:        "defaults",
+			config:      `{"address": "0.0.0.0"}`,
+			expectedURL: "http://127.0.0.1:10248/healthz",
+		},
+		{
+			name:        "custom port and address",
+			config:      `{"healthzPort": 10249, "healthzBindAddress": "10.0.0.1"}`,
+			expectedURL: "http://10.0.0.1:10249/healthz",
+		},
+		{
+			name:        "unspecified address uses loopback",
+			config:      `{"healthzBindAddress": "0.0.0.0"}`,
+			expectedURL: "http://127.0.0.1:10248/healthz",
+		},
+		{
+			name:        "disabled endpoint",
+			config:      `{"healthzPort": 0}`,
+			expectedURL: "",
+		},
+		{
+			name:   "drop-ins in order",
+			config: `{"healthzPort": 10249}`,
+			dropIns: map[string]string{
+				"10-nodeadm-port.conf": `{"healthzPort": 10250, "healthzBindAddress": "10.0.0.1"}`,
+				"00-nodeadm.conf":      `{"healthzPort": 10251}`,
+				"20-user.conf":         "healthzBindAddress: 10.0.0.2\n",
+				"30-ignored.json":      `{"healthzPort": 0}`,
+			},
+			expectedURL: "http://10.0.0.2:10250/healthz",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configRoot := t.TempDir()
+			assert.NoError(t, os.WriteFile(path.Join(configRoot, kubeletConfigFile), []byte(test.config), 0644))
+			if len(test.dropIns) > 0 {
+				assert.NoError(t, os.Mkdir(path.Join(configRoot, kubeletConfigDir), 0755))
+			}
+			for name, content := range test.dropIns {
+				assert.NoError(t, os.WriteFile(path.Join(configRoot, kubeletConfigDir, name), []byte(content), 0644))
+			}
+			url, err := getHealthzURL(configRoot)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedURL, url)
+		})
+	}
+
+	_, err := getHealthzURL(t.TempDir())
+	assert.Error(t, err)
+}
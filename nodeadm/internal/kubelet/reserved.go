@@ -0,0 +1,132 @@
+package kubelet
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/aws/smithy-go/ptr"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/system"
+)
+
+const (
+	kubeletRootDir = "/var/lib/kubelet"
+
+	defaultKubeReservedCgroup   = "/runtime"
+	defaultSystemReservedCgroup = "/system"
+)
+
+// getCapacity returns the node's capacity of a resource, in millicores for cpu
+// and in bytes otherwise
+var getCapacity = func(resource string) (int64, error) {
+	switch resource {
+	case "cpu":
+		milliCores, err := system.GetMilliNumCores()
+		return int64(milliCores), err
+	case "memory":
+		return system.GetMemoryBytes()
+	case "ephemeral-storage":
+		// the kubelet root directory may not exist yet on the first boot
+		dir := kubeletRootDir
+		for {
+			if _, err := os.Stat(dir); err == nil || !errors.Is(err, fs.ErrNotExist) || dir == "/" {
+				break
+			}
+			dir = path.Dir(dir)
+		}
+		return system.GetFilesystemBytes(dir)
+	default:
+		return 0, fmt.Errorf("capacity of %s is unknown", resource)
+	}
+}
+
+// ResolveReservedResources calculates the node allocatable settings for this
+// instance from the ReservedResourcesOptions and records them in the status.
+func ResolveReservedResources(cfg *api.NodeConfig) error {
+	reserved, err := resolveReservedResources(cfg)
+	if err != nil {
+		return err
+	}
+	cfg.Status.ReservedResources = reserved
+	return nil
+}
+
+func resolveReservedResources(cfg *api.NodeConfig) (*api.ReservedResources, error) {
+	maxPods, err := getMaxPods(cfg)
+	if err != nil {
+		return nil, err
+	}
+	options := cfg.Spec.Kubelet.ReservedResources
+	reserved := &api.ReservedResources{
+		MaxPods:                maxPods,
+		KubeReservedCgroup:     ptr.String(defaultKubeReservedCgroup),
+		SystemReservedCgroup:   ptr.String(defaultSystemReservedCgroup),
+		EvictionHard:           defaultKubeletSubConfig().EvictionHard,
+		EnforceNodeAllocatable: options.EnforceNodeAllocatable,
+	}
+	if options.KubeReservedCgroup != nil {
+		reserved.KubeReservedCgroup = options.KubeReservedCgroup
+	}
+	if options.SystemReservedCgroup != nil {
+		reserved.SystemReservedCgroup = options.SystemReservedCgroup
+	}
+	if options.EvictionHard != nil {
+		reserved.EvictionHard = options.EvictionHard
+	}
+
+	switch options.Policy {
+	case "", api.ReservationPolicyEKS:
+		reserved.KubeReserved = map[string]string{
+			"cpu":               fmt.Sprintf("%dm", getCPUMillicoresToReserve()),
+			"ephemeral-storage": "1Gi",
+			"memory":            fmt.Sprintf("%dMi", getMemoryMebibytesToReserve(reserved.MaxPods)),
+		}
+		for name, value := range options.KubeReserved {
+			reserved.KubeReserved[name] = value
+		}
+		reserved.SystemReserved = options.SystemReserved
+	case api.ReservationPolicyFixed:
+		reserved.KubeReserved = options.KubeReserved
+		reserved.SystemReserved = options.SystemReserved
+	case api.ReservationPolicyPercentage:
+		if reserved.KubeReserved, err = reservePercentages(options.KubeReserved); err != nil {
+			return nil, err
+		}
+		if reserved.SystemReserved, err = reservePercentages(options.SystemReserved); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown reserved resources policy: %s", options.Policy)
+	}
+	return reserved, nil
+}
+
+// reservePercentages converts percentages of the node's capacity into
+// quantities
+func reservePercentages(percentages map[string]string) (map[string]string, error) {
+	if len(percentages) == 0 {
+		return nil, nil
+	}
+	quantities := make(map[string]string, len(percentages))
+	for resource, value := range percentages {
+		fraction, err := api.ParsePercentage(value)
+		if err != nil {
+			return nil, err
+		}
+		capacity, err := getCapacity(resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s capacity: %w", resource, err)
+		}
+		reserved := int64(fraction * float64(capacity))
+		if resource == "cpu" {
+			quantities[resource] = fmt.Sprintf("%dm", reserved)
+		} else {
+			quantities[resource] = fmt.Sprintf("%dMi", reserved/(1024*1024))
+		}
+	}
+	return quantities, nil
+}
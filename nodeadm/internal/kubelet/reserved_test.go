@@ -0,0 +1,105 @@
+package kubelet
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestResolveReservedResources(t *testing.T) {
+	oldGetCapacity := getCapacity
+	t.Cleanup(func() { getCapacity = oldGetCapacity })
+	getCapacity = func(resource string) (int64, error) {
+		return map[string]int64{
+			"cpu":               4000,
+			"memory":            16 * 1024 * 1024 * 1024,
+			"ephemeral-storage": 100 * 1024 * 1024 * 1024,
+		}[resource], nil
+	}
+
+	var tests = []struct {
+		name                   string
+		options                api.ReservedResourcesOptions
+		expectedKubeReserved   map[string]string
+		expectedSystemReserved map[string]string
+	}{
+		{
+			name: "eks formula with overrides",
+			options: api.ReservedResourcesOptions{
+				KubeReserved:   map[string]string{"ephemeral-storage": "5Gi"},
+				SystemReserved: map[string]string{"memory": "200Mi"},
+			},
+			expectedKubeReserved: map[string]string{
+				"cpu":               "80m",
+				"ephemeral-storage": "5Gi",
+				"memory":            "574Mi",
+			},
+			expectedSystemReserved: map[string]string{"memory": "200Mi"},
+		},
+		{
+			name: "fixed",
+			options: api.ReservedResourcesOptions{
+				Policy:       api.ReservationPolicyFixed,
+				KubeReserved: map[string]string{"cpu": "500m"},
+			},
+			expectedKubeReserved: map[string]string{"cpu": "500m"},
+		},
+		{
+			name: "percentage",
+			options: api.ReservedResourcesOptions{
+				Policy:         api.ReservationPolicyPercentage,
+				KubeReserved:   map[string]string{"cpu": "5%", "memory": "10%"},
+				SystemReserved: map[string]string{"ephemeral-storage": "0.5%"},
+			},
+			expectedKubeReserved:   map[string]string{"cpu": "200m", "memory": "1638Mi"},
+			expectedSystemReserved: map[string]string{"ephemeral-storage": "512Mi"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := api.NodeConfig{
+				Spec: api.NodeConfigSpec{
+					Kubelet: api.KubeletOptions{ReservedResources: test.options},
+				},
+				Status: api.NodeConfigStatus{
+					Instance: api.InstanceDetails{Type: "m5.large", Region: "us-west-2"},
+				},
+			}
+			reserved, err := resolveReservedResources(&cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(29), reserved.MaxPods)
+			if test.options.Policy == "" {
+				// the cpu reservation depends on the host running the test
+				delete(reserved.KubeReserved, "cpu")
+				delete(test.expectedKubeReserved, "cpu")
+			}
+			assert.Equal(t, test.expectedKubeReserved, reserved.KubeReserved)
+			assert.Equal(t, test.expectedSystemReserved, reserved.SystemReserved)
+		})
+	}
+}
+
+func TestWithReservedResources(t *testing.T) {
+	cfg := api.NodeConfig{
+		Status: api.NodeConfigStatus{
+			ReservedResources: &api.ReservedResources{
+				MaxPods:                10,
+				KubeReservedCgroup:     ptr.String(""),
+				SystemReservedCgroup:   ptr.String("/custom"),
+				EvictionHard:           map[string]string{"memory.available": "5%"},
+				EnforceNodeAllocatable: []string{"pods", "system-reserved"},
+			},
+		},
+	}
+	kubeletConfig := defaultKubeletSubConfig()
+	assert.NoError(t, kubeletConfig.withReservedResources(&cfg))
+	assert.Equal(t, int32(10), kubeletConfig.MaxPods)
+	assert.Nil(t, kubeletConfig.KubeReservedCgroup)
+	assert.Equal(t, ptr.String("/custom"), kubeletConfig.SystemReservedCgroup)
+	assert.Equal(t, map[string]string{"memory.available": "5%"}, kubeletConfig.EvictionHard)
+	assert.Equal(t, []string{"pods", "system-reserved"}, kubeletConfig.EnforceNodeAllocatable)
+}
@@ -0,0 +1,251 @@
+package kubelet
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8skubelet "k8s.io/kubelet/config/v1beta1"
+	sigsjson "sigs.k8s.io/json"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+// kubeletConfigTypesVersion is the kubelet minor version of the vendored
+// KubeletConfiguration types. Newer kubelets may accept fields that these
+// types do not know about.
+const kubeletConfigTypesVersion = "v1.29"
+
+// ValidateUserKubeletConfig validates the user's kubelet configuration merged
+// onto nodeadm's defaults, without requiring any instance details.
+func ValidateUserKubeletConfig(cfg *api.NodeConfig) error {
+	if len(cfg.Spec.Kubelet.Config) == 0 && len(cfg.Spec.Kubelet.ConfigDropIns) == 0 {
+		return nil
+	}
+	kubeletVersion, err := GetKubeletVersion()
+	if err != nil {
+		zap.L().Debug("Unable to detect kubelet version, validating against vendored types", zap.Error(err))
+	}
+	_, err = mergeAndValidateKubeletConfig(defaultKubeletSubConfig(), cfg.Spec.Kubelet.Config, kubeletVersion, sortedConfigDropIns(cfg.Spec.Kubelet.ConfigDropIns)...)
+	return err
+}
+
+// mergeAndValidateKubeletConfig merges the user's kubelet configuration and
+// then each drop-in, in order, onto the generated one, validates the result,
+// and returns the merged map.
+func mergeAndValidateKubeletConfig(kubeletConfig kubeletConfig, userConfig api.InlineDocument, kubeletVersion string, dropIns ...api.KubeletConfigDropIn) (map[string]interface{}, error) {
+	mergedMap, err := util.Merge(kubeletConfig, userConfig, json.Marshal, json.Unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	for _, dropIn := range dropIns {
+		if mergedMap, err = util.Merge(mergedMap, dropIn.Config, json.Marshal, json.Unmarshal); err != nil {
+			return nil, fmt.Errorf("failed to merge kubelet config drop-in %s: %w", dropIn.Name, err)
+		}
+	}
+	data, err := json.Marshal(mergedMap)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateKubeletConfig(data, kubeletVersion); err != nil {
+		return nil, err
+	}
+	return mergedMap, nil
+}
+
+// validateKubeletConfig strictly decodes the kubelet configuration into the
+// upstream KubeletConfiguration type and checks it against a subset of the
+// kubelet's own validation rules, so that common mistakes are reported before
+// any file is written instead of when kubelet fails to start. The upstream
+// validation lives in k8s.io/kubernetes, which is not a dependency, so a
+// configuration that passes may still be rejected by kubelet.
+func validateKubeletConfig(data []byte, kubeletVersion string) error {
+	var config k8skubelet.KubeletConfiguration
+	strictErrs, err := sigsjson.UnmarshalStrict(data, &config, sigsjson.DisallowDuplicateFields, sigsjson.DisallowUnknownFields)
+	if err != nil {
+		return fmt.Errorf("invalid kubelet configuration: %w", err)
+	}
+	var allErrs field.ErrorList
+	for _, strictErr := range strictErrs {
+		if kubeletVersion != "" && semver.Compare(semver.MajorMinor(kubeletVersion), kubeletConfigTypesVersion) > 0 {
+			zap.L().Warn("Unable to validate kubelet configuration field", zap.String("kubeletVersion", kubeletVersion), zap.Error(strictErr))
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(kubeletConfigPath, strictErr.Error()))
+	}
+	allErrs = append(allErrs, validateKubeletConfiguration(&config)...)
+	if len(allErrs) > 0 {
+		return fmt.Errorf("invalid kubelet configuration: %w", allErrs.ToAggregate())
+	}
+	return nil
+}
+
+// kubeletConfigPath is the path of the kubelet configuration in a NodeConfig,
+// used to report errors in the user's terms.
+var kubeletConfigPath = field.NewPath("spec", "kubelet", "config")
+
+var (
+	validHairpinModes            = []string{"promiscuous-bridge", "hairpin-veth", "none"}
+	validCgroupDrivers           = []string{"systemd", "cgroupfs"}
+	validCPUManagerPolicies      = []string{"none", "static"}
+	validMemoryManagerPolicies   = []string{"None", "Static"}
+	validTopologyManagerPolicies = []string{"none", "best-effort", "restricted", "single-numa-node"}
+	validTopologyManagerScopes   = []string{"container", "pod"}
+)
+
+// validateKubeletConfiguration ports some of the checks of the upstream
+// kubelet's ValidateKubeletConfiguration that do not depend on the host: port
+// and address syntax, non-negative rates and limits, known policy names,
+// threshold ranges and reserved resource quantities. Rules that depend on
+// feature gates, or that relate several fields, are not ported.
+func validateKubeletConfiguration(c *k8skubelet.KubeletConfiguration) field.ErrorList {
+	var allErrs field.ErrorList
+	root := kubeletConfigPath
+
+	allErrs = append(allErrs, validatePort(root.Child("port"), c.Port)...)
+	allErrs = append(allErrs, validatePort(root.Child("readOnlyPort"), c.ReadOnlyPort)...)
+	if c.HealthzPort != nil {
+		allErrs = append(allErrs, validatePort(root.Child("healthzPort"), *c.HealthzPort)...)
+	}
+	allErrs = append(allErrs, validateIP(root.Child("address"), c.Address)...)
+	allErrs = append(allErrs, validateIP(root.Child("healthzBindAddress"), c.HealthzBindAddress)...)
+	for i, ip := range c.ClusterDNS {
+		allErrs = append(allErrs, validateIP(root.Child("clusterDNS").Index(i), ip)...)
+	}
+
+	allErrs = append(allErrs, validateNonNegative(root.Child("registryPullQPS"), c.RegistryPullQPS)...)
+	allErrs = append(allErrs, validateNonNegative(root.Child("registryBurst"), &c.RegistryBurst)...)
+	allErrs = append(allErrs, validateNonNegative(root.Child("eventRecordQPS"), c.EventRecordQPS)...)
+	allErrs = append(allErrs, validateNonNegative(root.Child("eventBurst"), &c.EventBurst)...)
+	allErrs = append(allErrs, validateNonNegative(root.Child("kubeAPIQPS"), c.KubeAPIQPS)...)
+	allErrs = append(allErrs, validateNonNegative(root.Child("kubeAPIBurst"), &c.KubeAPIBurst)...)
+	allErrs = append(allErrs, validateNonNegative(root.Child("maxPods"), &c.MaxPods)...)
+	allErrs = append(allErrs, validateNonNegative(root.Child("podsPerCore"), &c.PodsPerCore)...)
+	allErrs = append(allErrs, validateNonNegative(root.Child("nodeLeaseDurationSeconds"), &c.NodeLeaseDurationSeconds)...)
+	if c.MaxOpenFiles < 0 {
+		allErrs = append(allErrs, field.Invalid(root.Child("maxOpenFiles"), c.MaxOpenFiles, "must be greater than or equal to 0"))
+	}
+	if c.NodeStatusMaxImages != nil && *c.NodeStatusMaxImages < -1 {
+		allErrs = append(allErrs, field.Invalid(root.Child("nodeStatusMaxImages"), *c.NodeStatusMaxImages, "must be greater than or equal to -1"))
+	}
+	if c.OOMScoreAdj != nil && (*c.OOMScoreAdj < -1000 || *c.OOMScoreAdj > 1000) {
+		allErrs = append(allErrs, field.Invalid(root.Child("oomScoreAdj"), *c.OOMScoreAdj, "must be between -1000 and 1000"))
+	}
+
+	allErrs = append(allErrs, validatePercent(root.Child("imageGCHighThresholdPercent"), c.ImageGCHighThresholdPercent)...)
+	allErrs = append(allErrs, validatePercent(root.Child("imageGCLowThresholdPercent"), c.ImageGCLowThresholdPercent)...)
+	if c.ImageGCHighThresholdPercent != nil && c.ImageGCLowThresholdPercent != nil && *c.ImageGCLowThresholdPercent >= *c.ImageGCHighThresholdPercent {
+		allErrs = append(allErrs, field.Invalid(root.Child("imageGCLowThresholdPercent"), *c.ImageGCLowThresholdPercent, "must be less than imageGCHighThresholdPercent"))
+	}
+	if c.ImageMaximumGCAge.Duration != 0 && c.ImageMaximumGCAge.Duration <= c.ImageMinimumGCAge.Duration {
+		allErrs = append(allErrs, field.Invalid(root.Child("imageMaximumGCAge"), c.ImageMaximumGCAge.Duration.String(), "must be greater than imageMinimumGCAge"))
+	}
+
+	if c.IPTablesMasqueradeBit != nil && (*c.IPTablesMasqueradeBit < 0 || *c.IPTablesMasqueradeBit > 31) {
+		allErrs = append(allErrs, field.Invalid(root.Child("iptablesMasqueradeBit"), *c.IPTablesMasqueradeBit, "must be between 0 and 31"))
+	}
+	if c.IPTablesDropBit != nil && (*c.IPTablesDropBit < 0 || *c.IPTablesDropBit > 31) {
+		allErrs = append(allErrs, field.Invalid(root.Child("iptablesDropBit"), *c.IPTablesDropBit, "must be between 0 and 31"))
+	}
+	if c.IPTablesMasqueradeBit != nil && c.IPTablesDropBit != nil && *c.IPTablesMasqueradeBit == *c.IPTablesDropBit {
+		allErrs = append(allErrs, field.Invalid(root.Child("iptablesDropBit"), *c.IPTablesDropBit, "must be different from iptablesMasqueradeBit"))
+	}
+
+	allErrs = append(allErrs, validateEnum(root.Child("hairpinMode"), c.HairpinMode, validHairpinModes)...)
+	allErrs = append(allErrs, validateEnum(root.Child("cgroupDriver"), c.CgroupDriver, validCgroupDrivers)...)
+	allErrs = append(allErrs, validateEnum(root.Child("cpuManagerPolicy"), c.CPUManagerPolicy, validCPUManagerPolicies)...)
+	allErrs = append(allErrs, validateEnum(root.Child("memoryManagerPolicy"), c.MemoryManagerPolicy, validMemoryManagerPolicies)...)
+	allErrs = append(allErrs, validateEnum(root.Child("topologyManagerPolicy"), c.TopologyManagerPolicy, validTopologyManagerPolicies)...)
+	allErrs = append(allErrs, validateEnum(root.Child("topologyManagerScope"), c.TopologyManagerScope, validTopologyManagerScopes)...)
+
+	if c.ContainerLogMaxFiles != nil && *c.ContainerLogMaxFiles < 2 {
+		allErrs = append(allErrs, field.Invalid(root.Child("containerLogMaxFiles"), *c.ContainerLogMaxFiles, "must be greater than 1"))
+	}
+	if c.ContainerLogMaxSize != "" {
+		if _, err := resource.ParseQuantity(c.ContainerLogMaxSize); err != nil {
+			allErrs = append(allErrs, field.Invalid(root.Child("containerLogMaxSize"), c.ContainerLogMaxSize, err.Error()))
+		}
+	}
+	if c.ShutdownGracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(root.Child("shutdownGracePeriod"), c.ShutdownGracePeriod.Duration.String(), "must be greater than or equal to 0"))
+	}
+	if c.ShutdownGracePeriodCriticalPods.Duration < 0 || c.ShutdownGracePeriodCriticalPods.Duration > c.ShutdownGracePeriod.Duration {
+		allErrs = append(allErrs, field.Invalid(root.Child("shutdownGracePeriodCriticalPods"), c.ShutdownGracePeriodCriticalPods.Duration.String(), "must be between 0 and shutdownGracePeriod"))
+	}
+	if c.CPUCFSQuotaPeriod != nil && c.CPUCFSQuotaPeriod.Duration != 0 && (c.CPUCFSQuotaPeriod.Duration.Microseconds() < 1000 || c.CPUCFSQuotaPeriod.Duration.Seconds() > 1) {
+		allErrs = append(allErrs, field.Invalid(root.Child("cpuCFSQuotaPeriod"), c.CPUCFSQuotaPeriod.Duration.String(), "must be between 1ms and 1s"))
+	}
+
+	allErrs = append(allErrs, validateResourceList(root.Child("kubeReserved"), c.KubeReserved)...)
+	allErrs = append(allErrs, validateResourceList(root.Child("systemReserved"), c.SystemReserved)...)
+	for i, taint := range c.RegisterWithTaints {
+		if taint.Key == "" {
+			allErrs = append(allErrs, field.Required(root.Child("registerWithTaints").Index(i).Child("key"), ""))
+		}
+		switch taint.Effect {
+		case "NoSchedule", "PreferNoSchedule", "NoExecute":
+		default:
+			allErrs = append(allErrs, field.NotSupported(root.Child("registerWithTaints").Index(i).Child("effect"), taint.Effect, []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}))
+		}
+	}
+	return allErrs
+}
+
+// validatePort allows 0, which leaves the port unset or disables it
+func validatePort(path *field.Path, port int32) field.ErrorList {
+	if port >= 0 && port <= 65535 {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, port, "must be between 0 and 65535")}
+}
+
+func validateIP(path *field.Path, ip string) field.ErrorList {
+	if ip == "" || net.ParseIP(ip) != nil {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, ip, "must be a valid IP address")}
+}
+
+func validateNonNegative(path *field.Path, value *int32) field.ErrorList {
+	if value == nil || *value >= 0 {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, *value, "must be greater than or equal to 0")}
+}
+
+func validatePercent(path *field.Path, value *int32) field.ErrorList {
+	if value == nil || (*value >= 0 && *value <= 100) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, *value, "must be between 0 and 100")}
+}
+
+func validateEnum(path *field.Path, value string, valid []string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	for _, v := range valid {
+		if value == v {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(path, value, valid)}
+}
+
+func validateResourceList(path *field.Path, resources map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
+	for name, value := range resources {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Key(name), value, err.Error()))
+		} else if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Key(name), value, "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
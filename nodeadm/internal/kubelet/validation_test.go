@@ -0,0 +1,67 @@
+package kubelet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+)
+
+func TestMergeAndValidateKubeletConfig(t *testing.T) {
+	var tests = []struct {
+		name           string
+		userConfig     string
+		kubeletVersion string
+		expectedErr    string
+	}{
+		{
+			name:       "valid",
+			userConfig: `{"maxPods": 58, "registerWithTaints": [{"key": "a", "effect": "NoSchedule"}]}`,
+		},
+		{
+			name:        "unknown field",
+			userConfig:  `{"maxPod": 58}`,
+			expectedErr: `invalid kubelet configuration: spec.kubelet.config: Forbidden: unknown field "maxPod"`,
+		},
+		{
+			name:           "unknown field on newer kubelet",
+			userConfig:     `{"someFutureField": true}`,
+			kubeletVersion: "v1.31.0",
+		},
+		{
+			name:        "wrong type",
+			userConfig:  `{"maxPods": "58"}`,
+			expectedErr: "invalid kubelet configuration: json: cannot unmarshal string into Go struct field KubeletConfiguration.maxPods of type int32",
+		},
+		{
+			name:        "image gc thresholds",
+			userConfig:  `{"imageGCHighThresholdPercent": 50, "imageGCLowThresholdPercent": 80}`,
+			expectedErr: "invalid kubelet configuration: spec.kubelet.config.imageGCLowThresholdPercent: Invalid value: 80: must be less than imageGCHighThresholdPercent",
+		},
+		{
+			name:        "hairpin mode",
+			userConfig:  `{"hairpinMode": "hairpin"}`,
+			expectedErr: `invalid kubelet configuration: spec.kubelet.config.hairpinMode: Unsupported value: "hairpin": supported values: "promiscuous-bridge", "hairpin-veth", "none"`,
+		},
+		{
+			name:        "multiple errors",
+			userConfig:  `{"oomScoreAdj": 2000, "kubeReserved": {"memory": "lots"}}`,
+			expectedErr: "invalid kubelet configuration: [spec.kubelet.config.oomScoreAdj: Invalid value: 2000: must be between -1000 and 1000, spec.kubelet.config.kubeReserved[memory]: Invalid value: \"lots\": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$']",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var userConfig api.InlineDocument
+			assert.NoError(t, json.Unmarshal([]byte(test.userConfig), &userConfig))
+			_, err := mergeAndValidateKubeletConfig(defaultKubeletSubConfig(), userConfig, test.kubeletVersion)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package snapshotter
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"go.uber.org/zap"
+)
+
+// SnapshotterDaemonName is the name of the proxy snapshotter daemon in the
+// graph of daemons, independent of the systemd unit that it runs as
+const SnapshotterDaemonName = "snapshotter"
+
+var _ daemon.Daemon = &snapshotter{}
+
+// ProcessSpecs run the proxy snapshotters that nodeadm knows about the same way
+// as their systemd units, for daemon managers that supervise child processes.
+// Validation rejects any other proxy snapshotter daemon under such a manager.
+var ProcessSpecs = map[string]daemon.ProcessSpec{
+	"soci-snapshotter": {
+		Command: []string{"/usr/local/bin/soci-snapshotter-grpc"},
+	},
+	"stargz-snapshotter": {
+		Command: []string{"/usr/local/bin/containerd-stargz-grpc", "--config=/etc/containerd-stargz-grpc/config.toml"},
+	},
+}
+
+var (
+	readinessTimeout     = time.Minute
+	socketConnectTimeout = 5 * time.Second
+)
+
+type snapshotter struct {
+	daemonManager daemon.DaemonManager
+	// whether Configure resolved the selected snapshotter
+	configured bool
+	// the selected proxy snapshotter, or nil if containerd uses a built-in
+	// snapshotter and there is nothing to run
+	proxy *api.ProxySnapshotterOptions
+}
+
+// NewSnapshotterDaemon returns the daemon of the selected proxy snapshotter,
+// which must be ready before containerd starts because containerd does not
+// reconnect to proxy plugins that were unavailable at startup.
+func NewSnapshotterDaemon(daemonManager daemon.DaemonManager) daemon.Daemon {
+	return &snapshotter{
+		daemonManager: daemonManager,
+	}
+}
+
+func (s *snapshotter) Configure(cfg *api.NodeConfig) error {
+	s.proxy = api.GetProxySnapshotter(cfg.Spec.Containerd.Snapshotter)
+	s.configured = true
+	return nil
+}
+
+func (s *snapshotter) EnsureRunning() error {
+	if !s.configured {
+		zap.L().Warn("Not starting a proxy snapshotter, because the snapshotter was not configured")
+		return nil
+	}
+	if err := s.stopUnselectedProxies(); err != nil {
+		return err
+	}
+	if s.proxy == nil || s.proxy.Daemon == "" {
+		return nil
+	}
+	zap.L().Info("Starting proxy snapshotter..", zap.String("daemon", s.proxy.Daemon))
+	return s.daemonManager.StartDaemon(s.proxy.Daemon)
+}
+
+// stopUnselectedProxies stops the proxy snapshotters that nodeadm knows about
+// and that are no longer selected, such as after switching back to a built-in
+// snapshotter, so that they do not keep running next to containerd.
+func (s *snapshotter) stopUnselectedProxies() error {
+	var daemonNames []string
+	for name := range ProcessSpecs {
+		if s.proxy == nil || s.proxy.Daemon != name {
+			daemonNames = append(daemonNames, name)
+		}
+	}
+	sort.Strings(daemonNames)
+	for _, name := range daemonNames {
+		status, err := s.daemonManager.GetDaemonStatus(name)
+		if err != nil {
+			zap.L().Warn("Failed to get status of proxy snapshotter", zap.String("daemon", name), zap.Error(err))
+			continue
+		}
+		if status.State == daemon.DaemonStateRunning || status.State == daemon.DaemonStateStarting {
+			zap.L().Info("Stopping proxy snapshotter that is no longer selected..", zap.String("daemon", name))
+			if err := s.daemonManager.StopDaemon(name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *snapshotter) WaitUntilReady(cfg *api.NodeConfig) error {
+	if s.proxy == nil {
+		return nil
+	}
+	err := daemon.WaitForReadiness(SnapshotterDaemonName, readinessTimeout, daemon.ReadinessCondition{
+		Name:  "SocketReady",
+		Check: socketReadyCheck(s.proxy.Address),
+	})
+	if err != nil && s.proxy.Daemon != "" {
+		return daemon.ErrorWithStatus(err, s.daemonManager, s.proxy.Daemon)
+	}
+	return err
+}
+
+func (s *snapshotter) PostLaunch(cfg *api.NodeConfig) error {
+	return nil
+}
+
+func (s *snapshotter) Name() string {
+	return SnapshotterDaemonName
+}
+
+func socketReadyCheck(address string) func() (bool, string, error) {
+	return func() (bool, string, error) {
+		conn, err := net.DialTimeout("unix", address, socketConnectTimeout)
+		if err != nil {
+			return false, fmt.Sprintf("socket %s is not accepting connections", address), err
+		}
+		return true, "", conn.Close()
+	}
+}
@@ -0,0 +1,95 @@
+package snapshotter
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon/daemontest"
+)
+
+func snapshotterConfig(options api.SnapshotterOptions) *api.NodeConfig {
+	return &api.NodeConfig{
+		Spec: api.NodeConfigSpec{
+			Containerd: api.ContainerdOptions{Snapshotter: options},
+		},
+	}
+}
+
+func TestBuiltinSnapshotter(t *testing.T) {
+	// a proxy snapshotter that was selected before keeps running until it is
+	// stopped
+	daemonManager := &daemontest.FakeDaemonManager{
+		Statuses: map[string]daemon.DaemonStatus{"soci-snapshotter": {State: daemon.DaemonStateRunning}},
+	}
+	cfg := snapshotterConfig(api.SnapshotterOptions{})
+	d := NewSnapshotterDaemon(daemonManager)
+
+	assert.NoError(t, d.Configure(cfg))
+	assert.NoError(t, d.EnsureRunning())
+	assert.NoError(t, d.WaitUntilReady(cfg))
+	assert.Empty(t, daemonManager.Started)
+	assert.Equal(t, []string{"soci-snapshotter"}, daemonManager.Stopped)
+}
+
+func TestUnconfiguredSnapshotter(t *testing.T) {
+	daemonManager := &daemontest.FakeDaemonManager{
+		Statuses: map[string]daemon.DaemonStatus{"soci-snapshotter": {State: daemon.DaemonStateRunning}},
+	}
+	d := NewSnapshotterDaemon(daemonManager)
+
+	assert.NoError(t, d.EnsureRunning())
+	assert.Empty(t, daemonManager.Started)
+	assert.Empty(t, daemonManager.Stopped)
+}
+
+func TestProxySnapshotter(t *testing.T) {
+	address := filepath.Join(t.TempDir(), "snapshotter.sock")
+	listener, err := net.Listen("unix", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	daemonManager := &daemontest.FakeDaemonManager{
+		Statuses: map[string]daemon.DaemonStatus{
+			"soci-snapshotter":   {State: daemon.DaemonStateRunning},
+			"stargz-snapshotter": {State: daemon.DaemonStateRunning},
+		},
+	}
+	cfg := snapshotterConfig(api.SnapshotterOptions{
+		Name:  "soci",
+		Proxy: api.ProxySnapshotterOptions{Address: address},
+	})
+	d := NewSnapshotterDaemon(daemonManager)
+
+	assert.NoError(t, d.Configure(cfg))
+	assert.NoError(t, d.EnsureRunning())
+	assert.NoError(t, d.WaitUntilReady(cfg))
+	assert.Equal(t, []string{"soci-snapshotter"}, daemonManager.Started)
+	assert.Equal(t, []string{"stargz-snapshotter"}, daemonManager.Stopped)
+}
+
+func TestProxySnapshotterNotReady(t *testing.T) {
+	oldReadinessTimeout := readinessTimeout
+	t.Cleanup(func() { readinessTimeout = oldReadinessTimeout })
+	readinessTimeout = 10 * time.Millisecond
+
+	daemonManager := &daemontest.FakeDaemonManager{}
+	cfg := snapshotterConfig(api.SnapshotterOptions{
+		Name:  "stargz",
+		Proxy: api.ProxySnapshotterOptions{Address: filepath.Join(t.TempDir(), "missing.sock")},
+	})
+	d := NewSnapshotterDaemon(daemonManager)
+
+	assert.NoError(t, d.Configure(cfg))
+	assert.NoError(t, d.EnsureRunning())
+	err := d.WaitUntilReady(cfg)
+	assert.ErrorContains(t, err, "condition SocketReady never became true")
+	assert.ErrorContains(t, err, "stargz-snapshotter status")
+}
@@ -6,3 +6,9 @@ type SystemAspect interface {
 	Name() string
 	Setup(*api.NodeConfig) error
 }
+
+// DependentAspect is implemented by aspects that must be set up after other
+// aspects. Dependencies returns the names of those aspects.
+type DependentAspect interface {
+	Dependencies() []string
+}
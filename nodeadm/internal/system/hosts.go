@@ -0,0 +1,218 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/util"
+)
+
+const (
+	hostsBlockBegin = "# BEGIN nodeadm managed block, do not edit"
+	hostsBlockEnd   = "# END nodeadm managed block"
+	hostsPerm       = 0644
+
+	dnsLookupTimeout = 30 * time.Second
+)
+
+var (
+	hostsPath = "/etc/hosts"
+	// resolvConfPaths are searched in order for nameservers. systemd-resolved
+	// lists its upstream servers in the first, while /etc/resolv.conf points to
+	// its stub resolver.
+	resolvConfPaths = []string{"/run/systemd/resolve/resolv.conf", "/etc/resolv.conf"}
+
+	lookupHostDNS = queryNameservers
+)
+
+// UpdateHostsBlock maps a hostname to its current addresses in the block of
+// /etc/hosts that nodeadm manages, replacing the previously managed mappings.
+// The block is ignored when resolving the hostname so that stale addresses
+// are not carried over. If the hostname cannot be resolved, such as while an
+// outpost is disconnected from its parent region, the last known mappings are
+// kept.
+func UpdateHostsBlock(hostname string) error {
+	content, err := os.ReadFile(hostsPath)
+	if err != nil {
+		return err
+	}
+	unmanaged, managed := splitHostsBlock(string(content), hostname)
+	addresses, err := resolveHost(unmanaged, hostname)
+	if err != nil {
+		if len(managed) > 0 {
+			zap.L().Warn("Failed to resolve host, keeping the last known addresses in /etc/hosts", zap.String("host", hostname), zap.Error(err))
+			return nil
+		}
+		return fmt.Errorf("failed to resolve %s: %w", hostname, err)
+	}
+	if sameAddresses(addresses, managed) {
+		addresses = managed
+	} else {
+		// shuffled so that nodes spread their connections across the control
+		// plane instances
+		rand.Shuffle(len(addresses), func(i, j int) {
+			addresses[i], addresses[j] = addresses[j], addresses[i]
+		})
+		zap.L().Info("Updating managed addresses in /etc/hosts", zap.String("host", hostname), zap.Strings("addresses", addresses))
+	}
+	updated := renderHosts(unmanaged, hostname, addresses)
+	if updated == string(content) {
+		return nil
+	}
+	if err := util.WriteFileWithDir(hostsPath, []byte(updated), hostsPerm); err != nil {
+		if !errors.Is(err, syscall.EBUSY) {
+			return err
+		}
+		// /etc/hosts is a mount point in containers, which cannot be replaced
+		return util.WriteFileInPlace(hostsPath, []byte(updated), hostsPerm)
+	}
+	return nil
+}
+
+// splitHostsBlock returns the lines of a hosts file outside of the managed
+// block, and the addresses of the hostname in the managed block.
+func splitHostsBlock(content, hostname string) ([]string, []string) {
+	var unmanaged, managed []string
+	inBlock := false
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		switch {
+		case line == hostsBlockBegin:
+			inBlock = true
+		case line == hostsBlockEnd:
+			inBlock = false
+		case inBlock:
+			if address, name, ok := strings.Cut(line, "\t"); ok && name == hostname {
+				managed = append(managed, address)
+			}
+		default:
+			unmanaged = append(unmanaged, line)
+		}
+	}
+	if len(unmanaged) == 1 && unmanaged[0] == "" {
+		unmanaged = nil
+	}
+	return unmanaged, managed
+}
+
+func renderHosts(unmanaged []string, hostname string, addresses []string) string {
+	var b strings.Builder
+	for _, line := range unmanaged {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(hostsBlockBegin + "\n")
+	for _, address := range addresses {
+		fmt.Fprintf(&b, "%s\t%s\n", address, hostname)
+	}
+	b.WriteString(hostsBlockEnd + "\n")
+	return b.String()
+}
+
+func sameAddresses(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// resolveHost resolves a hostname in the same order as the system resolver,
+// first from the unmanaged hosts entries and then from DNS.
+func resolveHost(unmanagedHosts []string, hostname string) ([]string, error) {
+	if net.ParseIP(hostname) != nil {
+		return []string{hostname}, nil
+	}
+	var addresses []string
+	for _, line := range unmanagedHosts {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			continue
+		}
+		for _, name := range fields[1:] {
+			if strings.EqualFold(name, hostname) && !slices.Contains(addresses, fields[0]) {
+				addresses = append(addresses, fields[0])
+			}
+		}
+	}
+	if len(addresses) > 0 {
+		return addresses, nil
+	}
+	return lookupHostDNS(hostname)
+}
+
+// queryNameservers resolves a hostname with the upstream nameservers. The
+// system resolver answers from /etc/hosts, including the managed block, so the
+// lookup runs without it, and systemd-resolved also answers from /etc/hosts,
+// so the Go resolver dials the upstream nameservers rather than its stub. The
+// search domains and options of /etc/resolv.conf still apply.
+func queryNameservers(hostname string) ([]string, error) {
+	nameservers, err := readNameservers()
+	if err != nil {
+		return nil, err
+	}
+	var next atomic.Uint32
+	var dialed atomic.Bool
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialed.Store(true)
+			// retries rotate through the upstream nameservers
+			nameserver := nameservers[int(next.Add(1)-1)%len(nameservers)]
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, net.JoinHostPort(nameserver, "53"))
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+	var addresses []string
+	err = withoutHostsFile(func() error {
+		var err error
+		addresses, err = resolver.LookupHost(ctx, hostname)
+		return err
+	})
+	if err == nil && !dialed.Load() {
+		// the Go resolver caches /etc/hosts for a few seconds, so a recent
+		// lookup in this process may still answer from the managed block
+		return nil, fmt.Errorf("%s was resolved from the cached /etc/hosts rather than DNS", hostname)
+	}
+	return addresses, err
+}
+
+// readNameservers returns the nameservers of the first resolv.conf that lists
+// any.
+func readNameservers() ([]string, error) {
+	for _, path := range resolvConfPaths {
+		f, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		var nameservers []string
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if fields := strings.Fields(scanner.Text()); len(fields) >= 2 && fields[0] == "nameserver" {
+				nameservers = append(nameservers, fields[1])
+			}
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		if len(nameservers) > 0 {
+			return nameservers, nil
+		}
+	}
+	return nil, fmt.Errorf("no nameservers found in %s", strings.Join(resolvConfPaths, ", "))
+}
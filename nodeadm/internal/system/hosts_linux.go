@@ -0,0 +1,36 @@
+//go:build linux
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+)
+
+// withoutHostsFile runs fn in a private mount namespace in which /etc/hosts is
+// empty. The namespace belongs to a thread that only runs fn, and the Go
+// resolver reads /etc/hosts on the goroutine that calls LookupHost.
+func withoutHostsFile(fn func() error) error {
+	errs := make(chan error, 1)
+	go func() {
+		// the thread is never unlocked, so it exits with the goroutine instead
+		// of running other goroutines in the namespace
+		runtime.LockOSThread()
+		if err := syscall.Unshare(syscall.CLONE_NEWNS); err != nil {
+			errs <- fmt.Errorf("failed to create a mount namespace: %w", err)
+			return
+		}
+		if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+			errs <- fmt.Errorf("failed to make mounts private: %w", err)
+			return
+		}
+		if err := syscall.Mount(os.DevNull, "/etc/hosts", "", syscall.MS_BIND, ""); err != nil {
+			errs <- fmt.Errorf("failed to hide /etc/hosts: %w", err)
+			return
+		}
+		errs <- fn()
+	}()
+	return <-errs
+}
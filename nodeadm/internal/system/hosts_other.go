@@ -0,0 +1,7 @@
+//go:build !linux
+
+package system
+
+func withoutHostsFile(fn func() error) error {
+	return fn()
+}
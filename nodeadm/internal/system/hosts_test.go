@@ -0,0 +1,78 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateHostsBlock(t *testing.T) {
+	oldHostsPath, oldLookupHostDNS := hostsPath, lookupHostDNS
+	t.Cleanup(func() { hostsPath, lookupHostDNS = oldHostsPath, oldLookupHostDNS })
+	hostsPath = path.Join(t.TempDir(), "hosts")
+	assert.NoError(t, os.WriteFile(hostsPath, []byte("127.0.0.1\tlocalhost\n"), 0644))
+
+	dnsAddresses := []string{"10.0.0.2"}
+	var dnsErr error
+	lookupHostDNS = func(hostname string) ([]string, error) {
+		return dnsAddresses, dnsErr
+	}
+	expectHosts := func(addresses ...string) {
+		t.Helper()
+		expected := "127.0.0.1\tlocalhost\n" + hostsBlockBegin + "\n"
+		for _, address := range addresses {
+			expected += address + "\tapi.example.com\n"
+		}
+		expected += hostsBlockEnd + "\n"
+		content, err := os.ReadFile(hostsPath)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(content))
+	}
+
+	assert.NoError(t, UpdateHostsBlock("api.example.com"))
+	expectHosts("10.0.0.2")
+
+	// reruns do not duplicate the entries
+	assert.NoError(t, UpdateHostsBlock("api.example.com"))
+	expectHosts("10.0.0.2")
+
+	// stale addresses are replaced
+	dnsAddresses = []string{"10.0.0.3"}
+	assert.NoError(t, UpdateHostsBlock("api.example.com"))
+	expectHosts("10.0.0.3")
+
+	// the last known addresses are kept while the host cannot be resolved
+	dnsErr = fmt.Errorf("i/o timeout")
+	assert.NoError(t, UpdateHostsBlock("api.example.com"))
+	expectHosts("10.0.0.3")
+
+	// static entries outside of the block take precedence over DNS
+	assert.NoError(t, UpdateHostsBlock("localhost"))
+	content, err := os.ReadFile(hostsPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1\tlocalhost\n"+hostsBlockBegin+"\n127.0.0.1\tlocalhost\n"+hostsBlockEnd+"\n", string(content))
+
+	// resolution fails without any last known addresses
+	assert.Error(t, UpdateHostsBlock("api.example.com"))
+}
+
+func TestReadNameservers(t *testing.T) {
+	oldResolvConfPaths := resolvConfPaths
+	t.Cleanup(func() { resolvConfPaths = oldResolvConfPaths })
+	dir := t.TempDir()
+	resolvConfPaths = []string{path.Join(dir, "missing.conf"), path.Join(dir, "stub.conf"), path.Join(dir, "resolv.conf")}
+	assert.NoError(t, os.WriteFile(resolvConfPaths[1], []byte("search example.com\n"), 0644))
+	assert.NoError(t, os.WriteFile(resolvConfPaths[2], []byte(
+		"# upstream nameservers\nnameserver 10.0.0.2\nnameserver fd00::2\nsearch ec2.internal\n"), 0644))
+
+	nameservers, err := readNameservers()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2", "fd00::2"}, nameservers)
+
+	resolvConfPaths = resolvConfPaths[:2]
+	_, err = readNameservers()
+	assert.Error(t, err)
+}
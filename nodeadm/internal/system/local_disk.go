@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/aws/instancetypes"
 	"go.uber.org/zap"
 )
 
@@ -26,6 +27,10 @@ func (a *localDiskAspect) Setup(cfg *api.NodeConfig) error {
 		zap.L().Info("Not configuring local disks!")
 		return nil
 	}
+	if info, ok := instancetypes.Get(cfg.Status.Instance.Type); ok && info.NVMeCount == 0 {
+		zap.L().Info("Not configuring local disks, instance type has no instance store volumes", zap.String("instanceType", info.Name))
+		return nil
+	}
 	strategy := strings.ToLower(string(cfg.Spec.Instance.LocalStorage.Strategy))
 	// #nosec G204 Subprocess launched with variable
 	cmd := exec.Command("setup-local-disks", strategy)
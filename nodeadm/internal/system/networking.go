@@ -20,7 +20,6 @@ const (
 	// https://github.com/amazonlinux/amazon-ec2-net-utils/blob/c6626fb5cd094bbfeb62c456fe088011dbab3f95/systemd/network/80-ec2.network
 	ec2NetworkConfigurationName = "80-ec2.network"
 	eksPrimaryENIOnlyConfName   = "10-eks_primary_eni_only.conf"
-	networkConfDropInDirPerms   = 0755
 	networkConfFilePerms        = 0644
 )
 
@@ -77,10 +76,7 @@ func (a *networkingAspect) ensureEKSNetworkConfiguration(cfg *api.NodeConfig) er
 		return fmt.Errorf("failed to generate eks_primary_eni_only network configuration: %w", err)
 	}
 	zap.L().Info("writing eks_primary_eni_only network configuration")
-	if err := os.MkdirAll(networkCfgDropInDir, networkConfDropInDirPerms); err != nil {
-		return fmt.Errorf("failed to create network configuration drop-in directory %s: %w", networkCfgDropInDir, err)
-	}
-	if err := os.WriteFile(eksPrimaryENIOnlyConfPathName, eksPrimaryENIOnlyConfContent, networkConfFilePerms); err != nil {
+	if err := util.WriteFileWithDir(eksPrimaryENIOnlyConfPathName, eksPrimaryENIOnlyConfContent, networkConfFilePerms); err != nil {
 		return fmt.Errorf("failed to write eks_primary_eni_only network configuration: %w", err)
 	}
 	if err := a.reloadNetworkConfigurations(); err != nil {
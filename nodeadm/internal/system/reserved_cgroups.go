@@ -0,0 +1,161 @@
+package system
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+)
+
+const (
+	reservedCgroupsAspectName = "reserved-cgroups"
+	// sliceDropInName holds the resource control settings of a reserved slice
+	sliceDropInName = "90-nodeadm"
+	// daemonSliceDropInName moves a daemon into a reserved slice. It sorts
+	// before the drop-in of the systemd options in the NodeConfig, so that a
+	// Slice set there takes precedence.
+	daemonSliceDropInName = "80-nodeadm-slice"
+
+	sshdDaemonName = "sshd"
+	// defaultServiceSlice is the slice of services that do not set one
+	defaultServiceSlice = "system.slice"
+)
+
+// the daemons that run in each reserved cgroup
+var (
+	kubeReservedDaemons   = []string{"containerd", "kubelet"}
+	systemReservedDaemons = []string{sshdDaemonName}
+)
+
+// NewReservedCgroupsAspect constructs new reservedCgroupsAspect.
+func NewReservedCgroupsAspect(daemonManager daemon.DaemonManager) *reservedCgroupsAspect {
+	return &reservedCgroupsAspect{daemonManager: daemonManager}
+}
+
+var _ SystemAspect = &reservedCgroupsAspect{}
+
+// reservedCgroupsAspect configures the systemd slices of the kube and system
+// reserved cgroups to hold the reserved resources, and moves the daemons that
+// the reservations are meant for into them.
+type reservedCgroupsAspect struct {
+	daemonManager daemon.DaemonManager
+}
+
+// Name returns the name of this aspect.
+func (a *reservedCgroupsAspect) Name() string {
+	return reservedCgroupsAspectName
+}
+
+// Setup executes the logic of this aspect.
+func (a *reservedCgroupsAspect) Setup(cfg *api.NodeConfig) error {
+	reserved := cfg.Status.ReservedResources
+	if reserved == nil {
+		return nil
+	}
+	kubeSlice := cgroupToSliceUnit(reserved.KubeReservedCgroup)
+	systemSlice := cgroupToSliceUnit(reserved.SystemReservedCgroup)
+	if kubeSlice != "" && kubeSlice == systemSlice {
+		zap.L().Warn("Kube and system reserved cgroups are the same, only configuring the kube reserved slice", zap.String("slice", kubeSlice))
+		systemSlice = ""
+	}
+	if err := a.configureSlice(kubeSlice, reserved.KubeReserved, slices.Contains(reserved.EnforceNodeAllocatable, "kube-reserved"), kubeReservedDaemons); err != nil {
+		return fmt.Errorf("failed to configure kube reserved slice: %w", err)
+	}
+	if err := a.configureSlice(systemSlice, reserved.SystemReserved, slices.Contains(reserved.EnforceNodeAllocatable, "system-reserved"), systemReservedDaemons); err != nil {
+		return fmt.Errorf("failed to configure system reserved slice: %w", err)
+	}
+	// sshd is already running, unlike containerd and kubelet which are started
+	// after the system aspects, so it is restarted to move it into its slice.
+	// It runs in system.slice by default.
+	if systemSlice != "" && systemSlice != defaultServiceSlice {
+		if status, err := a.daemonManager.GetDaemonStatus(sshdDaemonName); err == nil && status.State == daemon.DaemonStateRunning {
+			return a.daemonManager.RestartDaemon(sshdDaemonName)
+		}
+	}
+	return nil
+}
+
+func (a *reservedCgroupsAspect) configureSlice(slice string, reserved map[string]string, enforced bool, daemons []string) error {
+	if slice == "" {
+		return nil
+	}
+	content, err := renderSliceDropIn(reserved, enforced)
+	if err != nil {
+		return err
+	}
+	zap.L().Info("Configuring reserved slice..", zap.String("slice", slice), zap.Strings("daemons", daemons))
+	if err := a.daemonManager.WriteDropIn(slice, sliceDropInName, content); err != nil {
+		return err
+	}
+	daemonContent := []byte(fmt.Sprintf("# Generated by nodeadm from the reserved resources, do not edit.\n\n[Service]\nSlice=%s\n", slice))
+	for _, name := range daemons {
+		if err := a.daemonManager.WriteDropIn(name, daemonSliceDropInName, daemonContent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cgroupToSliceUnit returns the slice unit of a cgroup with the systemd cgroup
+// driver, which kubelet derives the same way, such as runtime.slice for
+// /runtime and a-b.slice for /a/b.
+func cgroupToSliceUnit(cgroup *string) string {
+	if cgroup == nil {
+		return ""
+	}
+	trimmed := strings.Trim(*cgroup, "/")
+	if trimmed == "" {
+		return ""
+	}
+	return strings.ReplaceAll(trimmed, "/", "-") + ".slice"
+}
+
+// renderSliceDropIn sets the CPU weight of the slice relative to the pods from
+// the reserved CPU, the same way kubelet weighs the pods by their allocatable
+// CPU, and protects the reserved memory. The memory is throttled above the
+// reservation only when the reservation is enforced, as it is otherwise not a
+// limit.
+func renderSliceDropIn(reserved map[string]string, enforced bool) ([]byte, error) {
+	var settings []string
+	if value, ok := reserved["cpu"]; ok {
+		cpu, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpu reservation %q: %w", value, err)
+		}
+		settings = append(settings, fmt.Sprintf("CPUWeight=%d", milliCPUToWeight(cpu.MilliValue())))
+	}
+	memoryHigh := "infinity"
+	if value, ok := reserved["memory"]; ok {
+		memory, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory reservation %q: %w", value, err)
+		}
+		settings = append(settings, fmt.Sprintf("MemoryMin=%d", memory.Value()))
+		if enforced {
+			memoryHigh = fmt.Sprint(memory.Value())
+		}
+	}
+	settings = append(settings, "MemoryHigh="+memoryHigh)
+	var buf bytes.Buffer
+	buf.WriteString("# Generated by nodeadm from the reserved resources, do not edit.\n")
+	buf.WriteString("\n[Slice]\n" + strings.Join(settings, "\n") + "\n")
+	return buf.Bytes(), nil
+}
+
+// milliCPUToWeight converts millicores into CPU shares and then into a cgroup
+// v2 CPU weight, as kubelet does for the pods' cgroups
+func milliCPUToWeight(milliCPU int64) int64 {
+	const (
+		minShares = 2
+		maxShares = 262144
+	)
+	shares := milliCPU * 1024 / 1000
+	shares = max(minShares, min(shares, maxShares))
+	return 1 + ((shares-minShares)*9999)/(maxShares-minShares)
+}
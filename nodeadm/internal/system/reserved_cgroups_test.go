@@ -0,0 +1,46 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/api"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon"
+	"github.com/awslabs/amazon-eks-ami/nodeadm/internal/daemon/daemontest"
+)
+
+func TestReservedCgroupsAspect(t *testing.T) {
+	daemonManager := &daemontest.FakeDaemonManager{
+		Statuses: map[string]daemon.DaemonStatus{sshdDaemonName: {State: daemon.DaemonStateRunning}},
+	}
+	cfg := api.NodeConfig{
+		Status: api.NodeConfigStatus{
+			ReservedResources: &api.ReservedResources{
+				KubeReserved:           map[string]string{"cpu": "70m", "ephemeral-storage": "1Gi", "memory": "893Mi"},
+				SystemReserved:         map[string]string{"cpu": "100m", "memory": "100Mi"},
+				KubeReservedCgroup:     ptr.String("/runtime"),
+				SystemReservedCgroup:   ptr.String("/reserved/system"),
+				EnforceNodeAllocatable: []string{"pods", "system-reserved"},
+			},
+		},
+	}
+	assert.NoError(t, NewReservedCgroupsAspect(daemonManager).Setup(&cfg))
+
+	header := "# Generated by nodeadm from the reserved resources, do not edit.\n\n"
+	assert.Equal(t, map[string]string{
+		"runtime.slice/90-nodeadm":         header + "[Slice]\nCPUWeight=3\nMemoryMin=936378368\nMemoryHigh=infinity\n",
+		"reserved-system.slice/90-nodeadm": header + "[Slice]\nCPUWeight=4\nMemoryMin=104857600\nMemoryHigh=104857600\n",
+		"containerd/80-nodeadm-slice":      header + "[Service]\nSlice=runtime.slice\n",
+		"kubelet/80-nodeadm-slice":         header + "[Service]\nSlice=runtime.slice\n",
+		"sshd/80-nodeadm-slice":            header + "[Service]\nSlice=reserved-system.slice\n",
+	}, daemonManager.DropIns)
+	assert.Equal(t, []string{sshdDaemonName}, daemonManager.Restarted)
+}
+
+func TestMilliCPUToWeight(t *testing.T) {
+	assert.Equal(t, int64(1), milliCPUToWeight(0))
+	assert.Equal(t, int64(39), milliCPUToWeight(1000))
+	assert.Equal(t, int64(10000), milliCPUToWeight(1000000))
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"go.uber.org/zap"
 )
@@ -15,6 +16,7 @@ var (
 	cpuDirRegExp = regexp.MustCompile(`/cpu(\d+)`)
 	nodeDir      = "/sys/devices/system/node"
 	cpusPath     = "/sys/devices/system/cpu"
+	memInfoPath  = "/proc/meminfo"
 )
 
 const (
@@ -81,6 +83,35 @@ func GetMilliNumCores() (int, error) {
 
 }
 
+// GetMemoryBytes returns the total memory of the host from /proc/meminfo
+func GetMemoryBytes() (int64, error) {
+	data, err := os.ReadFile(memInfoPath)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+		kibibytes, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid MemTotal in %s: %w", memInfoPath, err)
+		}
+		return kibibytes * 1024, nil
+	}
+	return 0, fmt.Errorf("MemTotal not found in %s", memInfoPath)
+}
+
+// GetFilesystemBytes returns the size of the filesystem that contains the path
+func GetFilesystemBytes(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return int64(stat.Blocks) * int64(stat.Bsize), nil
+}
+
 func getCPUCount() (int, error) {
 	cpusPaths, err := getCPUsPaths(cpusPath)
 	if err != nil {
@@ -42,3 +42,35 @@ func GetEniInfoForInstanceType(ec2API EC2API, instanceType string) (EniInfo, err
 	}
 	return EniInfo{}, fmt.Errorf("no instance found for type: %s", instanceType)
 }
+
+// InstanceTypeInfo are the attributes of an instance type that determine how
+// many pods it can run
+type InstanceTypeInfo struct {
+	EniInfo
+	VCpuCount  int32
+	Hypervisor string
+}
+
+func GetInstanceTypeInfo(ec2API EC2API, instanceType string) (InstanceTypeInfo, error) {
+	describeResp, err := ec2API.DescribeInstanceTypes(context.Background(), &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []types.InstanceType{types.InstanceType(instanceType)},
+	})
+	if err != nil {
+		return InstanceTypeInfo{}, fmt.Errorf("error describing instance type %s: %w", instanceType, err)
+	}
+	if len(describeResp.InstanceTypes) == 0 {
+		return InstanceTypeInfo{}, fmt.Errorf("no instance found for type: %s", instanceType)
+	}
+	instanceTypeInfo := describeResp.InstanceTypes[0]
+	if instanceTypeInfo.NetworkInfo == nil || instanceTypeInfo.VCpuInfo == nil {
+		return InstanceTypeInfo{}, fmt.Errorf("incomplete instance type information for type: %s", instanceType)
+	}
+	return InstanceTypeInfo{
+		EniInfo: EniInfo{
+			EniCount:        *instanceTypeInfo.NetworkInfo.MaximumNetworkInterfaces,
+			PodsPerEniCount: *instanceTypeInfo.NetworkInfo.Ipv4AddressesPerInterface,
+		},
+		VCpuCount:  *instanceTypeInfo.VCpuInfo.DefaultVCpus,
+		Hypervisor: string(instanceTypeInfo.Hypervisor),
+	}, nil
+}
@@ -7,15 +7,101 @@ import (
 	"path"
 )
 
-// Wraps os.WriteFile to automatically create parent directories such that the
-// caller does not need to ensure the existence of the file's directory
+// dirPerm is the permission of directories created for files
+const dirPerm = 0755
+
+// Atomically writes a file, creating parent directories such that the caller
+// does not need to ensure the existence of the file's directory. The previous
+// version of the file is recorded in the current FileTransaction, if any.
 func WriteFileWithDir(filePath string, data []byte, perm fs.FileMode) error {
-	if err := os.MkdirAll(path.Dir(filePath), perm); err != nil {
+	transactionMu.Lock()
+	defer transactionMu.Unlock()
+	if err := recordFile(filePath); err != nil {
+		return err
+	}
+	return writeFileAtomic(filePath, data, perm)
+}
+
+// Writes a file in place, for files that cannot be replaced by renaming a new
+// file over them, such as files that are bind mounted. The previous version of
+// the file is recorded in the current FileTransaction, if any, and is restored
+// in place as well.
+func WriteFileInPlace(filePath string, data []byte, perm fs.FileMode) error {
+	transactionMu.Lock()
+	defer transactionMu.Unlock()
+	if err := recordFile(filePath); err != nil {
+		return err
+	}
+	if err := recordInPlace(filePath); err != nil {
 		return err
 	}
 	return os.WriteFile(filePath, data, perm)
 }
 
+// RemoveFile removes a file if it exists. The previous version of the file is
+// recorded in the current FileTransaction, if any.
+func RemoveFile(filePath string) error {
+	transactionMu.Lock()
+	defer transactionMu.Unlock()
+	if err := recordFile(filePath); err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// RemoveEmptyDir removes a directory if it exists and is empty. It does not
+// need to be recorded in the current FileTransaction, because rolling back the
+// removal of the files that were in it recreates the directory.
+func RemoveEmptyDir(dirPath string) error {
+	if err := os.Remove(dirPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// writeFileAtomic writes the data to a temporary file in the same directory
+// and renames it over the destination, so that readers never observe a
+// partially written file.
+func writeFileAtomic(filePath string, data []byte, perm fs.FileMode) error {
+	dir := path.Dir(filePath)
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+path.Base(filePath)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return err
+	}
+	// sync the directory so that the rename itself is durable
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
 // IsFilePathExists checks whether specific file path exists
 func IsFilePathExists(filePath string) (bool, error) {
 	_, err := os.Stat(filePath)
@@ -1,6 +1,9 @@
 package util
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 func RetryExponentialBackoff(attempts int, initial time.Duration, f func() error) error {
 	var err error
@@ -14,3 +17,26 @@ func RetryExponentialBackoff(attempts int, initial time.Duration, f func() error
 	}
 	return err
 }
+
+// RetryExponentialBackoffWithContext is RetryExponentialBackoff for functions
+// that take a context. It stops retrying once the context is done, and does
+// not wait after the last attempt.
+func RetryExponentialBackoffWithContext(ctx context.Context, attempts int, initial time.Duration, f func(context.Context) error) error {
+	var err error
+	wait := initial
+	for i := 0; i < attempts; i++ {
+		if err = f(ctx); err == nil {
+			return nil
+		}
+		if i == attempts-1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+var (
+	transactionMu sync.Mutex
+	transaction   *FileTransaction
+)
+
+// FileTransaction records every file written or removed through this package
+// while it is active, so that the files can be restored to their previous
+// versions if the overall operation fails.
+type FileTransaction struct {
+	backupDir string
+	// files maps the path of each touched file to whether it existed before
+	files map[string]bool
+	order []string
+	// inPlace holds the files that must be restored in place
+	inPlace map[string]bool
+}
+
+// BeginFileTransaction starts recording file changes. Backups of the previous
+// versions of files are kept in backupDir, replacing the backups of an earlier
+// transaction once the first file is changed.
+func BeginFileTransaction(backupDir string) (*FileTransaction, error) {
+	transactionMu.Lock()
+	defer transactionMu.Unlock()
+	if transaction != nil {
+		return nil, fmt.Errorf("a file transaction is already in progress")
+	}
+	transaction = &FileTransaction{
+		backupDir: backupDir,
+		files:     make(map[string]bool),
+		inPlace:   make(map[string]bool),
+	}
+	return transaction, nil
+}
+
+// recordFile backs up the file before its first change in the current
+// transaction. The caller must hold transactionMu.
+func recordFile(filePath string) error {
+	if transaction == nil {
+		return nil
+	}
+	filePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return err
+	}
+	if _, ok := transaction.files[filePath]; ok {
+		return nil
+	}
+	if len(transaction.files) == 0 {
+		if err := os.RemoveAll(transaction.backupDir); err != nil {
+			return fmt.Errorf("failed to clear backups: %w", err)
+		}
+	}
+	info, err := os.Stat(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		transaction.files[filePath] = false
+		transaction.order = append(transaction.order, filePath)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(transaction.backupPath(filePath), data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", filePath, err)
+	}
+	transaction.files[filePath] = true
+	transaction.order = append(transaction.order, filePath)
+	return nil
+}
+
+// recordInPlace marks a recorded file to be restored in place, rather than by
+// renaming the backup over it. The caller must hold transactionMu.
+func recordInPlace(filePath string) error {
+	if transaction == nil {
+		return nil
+	}
+	filePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return err
+	}
+	transaction.inPlace[filePath] = true
+	return nil
+}
+
+func (t *FileTransaction) backupPath(filePath string) string {
+	return path.Join(t.backupDir, filePath)
+}
+
+// Files returns the files changed in the transaction, in the order in which
+// they were first changed.
+func (t *FileTransaction) Files() []string {
+	transactionMu.Lock()
+	defer transactionMu.Unlock()
+	return slices.Clone(t.order)
+}
+
+// Commit stops recording file changes and keeps the changes. The backups of
+// the previous versions are left in place.
+func (t *FileTransaction) Commit() {
+	transactionMu.Lock()
+	defer transactionMu.Unlock()
+	if transaction == t {
+		transaction = nil
+	}
+}
+
+// Rollback stops recording file changes and restores every file changed in
+// the transaction to its previous version, removing files that did not exist.
+func (t *FileTransaction) Rollback() error {
+	transactionMu.Lock()
+	defer transactionMu.Unlock()
+	if transaction == t {
+		transaction = nil
+	}
+	var errs []error
+	for i := len(t.order) - 1; i >= 0; i-- {
+		filePath := t.order[i]
+		if !t.files[filePath] {
+			zap.L().Info("Removing file created during failed operation", zap.String("path", filePath))
+			if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		zap.L().Info("Restoring previous version of file", zap.String("path", filePath))
+		backupPath := t.backupPath(filePath)
+		info, err := os.Stat(backupPath)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		data, err := os.ReadFile(backupPath)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if t.inPlace[filePath] {
+			err = os.WriteFile(filePath, data, info.Mode().Perm())
+		} else {
+			err = writeFileAtomic(filePath, data, info.Mode().Perm())
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,119 @@
+package util
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileTransactionRollback(t *testing.T) {
+	dir := t.TempDir()
+	existing := path.Join(dir, "existing")
+	removed := path.Join(dir, "removed")
+	created := path.Join(dir, "nested", "created")
+	assert.NoError(t, os.WriteFile(existing, []byte("old"), 0600))
+	assert.NoError(t, os.WriteFile(removed, []byte("keep me"), 0644))
+
+	transaction, err := BeginFileTransaction(path.Join(dir, "backup"))
+	assert.NoError(t, err)
+	assert.NoError(t, WriteFileWithDir(existing, []byte("new"), 0644))
+	assert.NoError(t, WriteFileWithDir(existing, []byte("newer"), 0644))
+	assert.NoError(t, WriteFileWithDir(created, []byte("created"), 0644))
+	assert.NoError(t, RemoveFile(removed))
+	assert.Equal(t, []string{existing, created, removed}, transaction.Files())
+
+	data, err := os.ReadFile(existing)
+	assert.NoError(t, err)
+	assert.Equal(t, "newer", string(data))
+	info, err := os.Stat(path.Dir(created))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	assert.NoError(t, transaction.Rollback())
+
+	data, err = os.ReadFile(existing)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+	info, err = os.Stat(existing)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, err = os.ReadFile(removed)
+	assert.NoError(t, err)
+	assert.Equal(t, "keep me", string(data))
+	_, err = os.Stat(created)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileTransactionRollbackRemovedDir(t *testing.T) {
+	dir := t.TempDir()
+	removed := path.Join(dir, "removed", "file")
+	assert.NoError(t, os.MkdirAll(path.Dir(removed), 0755))
+	assert.NoError(t, os.WriteFile(removed, []byte("keep me"), 0644))
+
+	transaction, err := BeginFileTransaction(path.Join(dir, "backup"))
+	assert.NoError(t, err)
+	assert.NoError(t, RemoveFile(removed))
+	assert.NoError(t, RemoveEmptyDir(path.Dir(removed)))
+	_, err = os.Stat(path.Dir(removed))
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, transaction.Rollback())
+
+	data, err := os.ReadFile(removed)
+	assert.NoError(t, err)
+	assert.Equal(t, "keep me", string(data))
+}
+
+func TestFileTransactionRollbackInPlace(t *testing.T) {
+	dir := t.TempDir()
+	file := path.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(file, []byte("old"), 0644))
+	before, err := os.Stat(file)
+	assert.NoError(t, err)
+
+	transaction, err := BeginFileTransaction(path.Join(dir, "backup"))
+	assert.NoError(t, err)
+	assert.NoError(t, WriteFileInPlace(file, []byte("new"), 0644))
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	assert.NoError(t, transaction.Rollback())
+
+	data, err = os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+	// the file is restored without replacing it, as a mount point would be
+	after, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.True(t, os.SameFile(before, after))
+}
+
+func TestFileTransactionCommit(t *testing.T) {
+	dir := t.TempDir()
+	file := path.Join(dir, "file")
+	backupDir := path.Join(dir, "backup")
+	assert.NoError(t, os.WriteFile(file, []byte("old"), 0644))
+
+	transaction, err := BeginFileTransaction(backupDir)
+	assert.NoError(t, err)
+	_, err = BeginFileTransaction(backupDir)
+	assert.Error(t, err, "nested transactions are not supported")
+	assert.NoError(t, WriteFileWithDir(file, []byte("new"), 0644))
+	transaction.Commit()
+
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	data, err = os.ReadFile(path.Join(backupDir, file))
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+
+	// writes outside of a transaction are not recorded
+	assert.NoError(t, WriteFileWithDir(file, []byte("newer"), 0644))
+	data, err = os.ReadFile(path.Join(backupDir, file))
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+}
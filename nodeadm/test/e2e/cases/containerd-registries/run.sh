@@ -0,0 +1,24 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+source /helpers.sh
+
+mock::aws
+mock::kubelet 1.29.0
+wait::dbus-ready
+
+HOSTS_DIR=/etc/containerd/certs.d
+# a registry configured by another tool, and one from a previous nodeadm run that is no longer configured
+mkdir -p $HOSTS_DIR/quay.io $HOSTS_DIR/ghcr.io
+echo 'server = "https://quay.io"' > $HOSTS_DIR/quay.io/hosts.toml
+echo '# Generated by nodeadm from the NodeConfig, do not edit.' > $HOSTS_DIR/ghcr.io/hosts.toml
+
+nodeadm init --skip run --config-source file://config.yaml
+
+assert::files-equal $HOSTS_DIR/docker.io/hosts.toml expected-docker-io-hosts.toml
+assert::files-equal $HOSTS_DIR/docker.io/nodeadm-cache.example.com_5000.crt <(echo -n certificateAuthority)
+assert::files-equal $HOSTS_DIR/registry.dev.example.com:5000/hosts.toml expected-dev-hosts.toml
+assert::files-equal <(ls $HOSTS_DIR) <(printf '%s\n' docker.io quay.io registry.dev.example.com:5000)
@@ -0,0 +1,23 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+source /helpers.sh
+
+mock::aws
+mock::kubelet 1.29.0
+mock::containerd 1.7.20
+wait::dbus-ready
+
+# the shim binaries of the runtimes
+printf '#!/usr/bin/env bash\n' > /usr/bin/containerd-shim-runsc-v1
+mkdir -p /opt/kata/bin
+printf '#!/usr/bin/env bash\n' > /opt/kata/bin/containerd-shim-kata-v2
+chmod +x /usr/bin/containerd-shim-runsc-v1 /opt/kata/bin/containerd-shim-kata-v2
+
+nodeadm init --skip run --config-source file://config.yaml
+
+assert::files-equal /etc/containerd/config.toml expected-containerd-config.toml
+assert::file-contains /etc/containerd/base-runtime-specs/kata.json '"noNewPrivileges": true'
@@ -0,0 +1,18 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+source /helpers.sh
+
+mock::aws
+mock::kubelet 1.29.0
+mock::containerd 1.7.20
+wait::dbus-ready
+
+nodeadm init --skip run --config-source file://config.yaml
+
+assert::files-equal /etc/containerd/config.toml expected-containerd-config.toml
+assert::file-contains /etc/containerd/base-runtime-spec.json '"soft": 1048576'
+assert::file-not-contains /etc/containerd/base-runtime-spec.json CAP_NET_RAW
@@ -20,3 +20,11 @@ mock::kubelet 1.27.0
 nodeadm init --skip run --config-source file://config.yaml
 
 assert::json-files-equal /etc/eks/image-credential-provider/config.json expected-image-credential-provider-config-127.json
+
+mkdir -p /opt/credential-providers
+touch /opt/credential-providers/ecr-credential-provider /opt/credential-providers/registry-helper
+
+nodeadm init --skip run --config-source file://config-custom-providers.yaml
+
+assert::json-files-equal /etc/eks/image-credential-provider/config.json expected-image-credential-provider-config-custom.json
+assert::file-contains /etc/eks/kubelet/environment '--image-credential-provider-bin-dir=/opt/credential-providers'
@@ -0,0 +1,16 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+source /helpers.sh
+
+mock::aws
+wait::dbus-ready
+
+mock::kubelet 1.29.0
+nodeadm init --skip run --config-source file://config.yaml
+assert::files-equal /var/lib/kubelet/bootstrap-kubeconfig expected-bootstrap-kubeconfig.yaml
+assert::file-contains /etc/eks/kubelet/environment '--bootstrap-kubeconfig=/var/lib/kubelet/bootstrap-kubeconfig'
+assert::file-contains /etc/eks/kubelet/environment '--kubeconfig=/var/lib/kubelet/kubeconfig'
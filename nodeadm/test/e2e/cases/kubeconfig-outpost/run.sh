@@ -13,3 +13,21 @@ mock::kubelet 1.28.0
 nodeadm init --skip run --config-source file://config.yaml
 assert::file-contains /etc/hosts $'127.0.0.1\tlocalhost'
 assert::file-contains /etc/hosts $'::1\tlocalhost'
+assert::file-contains /etc/hosts '# BEGIN nodeadm managed block'
+
+# reruns rewrite the managed block instead of appending to it
+managed_entries=$(sed -n '/^# BEGIN nodeadm managed block/,/^# END nodeadm managed block/p' /etc/hosts)
+nodeadm init --skip run --config-source file://config.yaml
+if [ "$(sed -n '/^# BEGIN nodeadm managed block/,/^# END nodeadm managed block/p' /etc/hosts)" != "$managed_entries" ]; then
+  echo "managed block of /etc/hosts changed on rerun"
+  cat /etc/hosts
+  exit 1
+fi
+if [ "$(grep -c '^# BEGIN nodeadm managed block' /etc/hosts)" -ne 1 ]; then
+  echo "managed block of /etc/hosts is duplicated"
+  cat /etc/hosts
+  exit 1
+fi
+
+assert::file-contains /etc/systemd/system/nodeadm-hosts-refresh.service.d/90-nodeadm.conf 'Environment="NODEADM_HOSTS_HOSTNAME=localhost"'
+assert::file-contains /etc/systemd/system/nodeadm-hosts-refresh.service.d/90-nodeadm.conf 'Environment="NODEADM_HOSTS_REFRESH_INTERVAL=5m0s"'
@@ -0,0 +1,24 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+source /helpers.sh
+
+mock::aws
+mock::kubelet 1.29.0
+wait::dbus-ready
+
+DROP_IN_DIR=/etc/kubernetes/kubelet/config.json.d
+mkdir -p $DROP_IN_DIR
+# a drop-in from another tool, and drop-ins from a previous nodeadm run that are no longer configured
+echo '{"kind": "KubeletConfiguration", "apiVersion": "kubelet.config.k8s.io/v1beta1"}' > $DROP_IN_DIR/20-gpu-operator.conf
+echo '{}' > $DROP_IN_DIR/00-nodeadm.conf
+echo '{}' > $DROP_IN_DIR/30-nodeadm-removed.conf
+
+nodeadm init --skip run --config-source file://config.yaml
+
+assert::json-files-equal $DROP_IN_DIR/10-nodeadm-eviction.conf expected-eviction-drop-in.json
+assert::json-files-equal $DROP_IN_DIR/50-nodeadm-logging.conf expected-logging-drop-in.json
+assert::files-equal <(ls $DROP_IN_DIR) <(printf '%s\n' 10-nodeadm-eviction.conf 20-gpu-operator.conf 50-nodeadm-logging.conf)
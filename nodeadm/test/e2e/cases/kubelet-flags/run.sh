@@ -12,5 +12,11 @@ wait::dbus-ready
 
 nodeadm init --skip run --config-source file://config.yaml
 
-assert::file-contains /etc/eks/kubelet/environment '--v=5 --node-labels=foo=bar,foo2=baz --register-with-taints=foo=bar:NoSchedule"$'
-assert::file-contains /etc/eks/kubelet/environment '--hostname-override=i-1234567890abcdef0'
+# flags are sorted by name, and user flags replace generated flags of the same name
+assert::file-contains /etc/eks/kubelet/environment '--hostname-override=my-node --image-credential-provider-bin-dir'
+assert::file-not-contains /etc/eks/kubelet/environment '--hostname-override=i-1234567890abcdef0'
+assert::file-contains /etc/eks/kubelet/environment '--node-labels=foo=bar,foo2=baz --pod-infra-container-image'
+assert::file-contains /etc/eks/kubelet/environment '--v=5"$'
+# flags with a kubelet config field are moved into the config
+assert::file-not-contains /etc/eks/kubelet/environment 'register-with-taints'
+assert::file-contains /etc/kubernetes/kubelet/config.json '"key": "foo"'
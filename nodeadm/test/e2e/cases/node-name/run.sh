@@ -0,0 +1,17 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+source /helpers.sh
+
+mock::aws
+mock::kubelet 1.29.0
+wait::dbus-ready
+
+nodeadm init --skip run --config-source file://config.resource-name.yaml
+assert::file-contains /etc/eks/kubelet/environment '--hostname-override=ip-172-16-34-43.ec2.internal '
+
+nodeadm init --skip run --config-source file://config.template.yaml
+assert::file-contains /etc/eks/kubelet/environment '--hostname-override=i-1234567890abcdef0.us-west-2.compute.internal '
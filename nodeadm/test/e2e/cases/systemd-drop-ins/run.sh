@@ -0,0 +1,24 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+source /helpers.sh
+
+mock::aws
+mock::kubelet 1.27.0
+wait::dbus-ready
+
+nodeadm init --skip run --config-source file://config.yaml
+
+assert::files-equal /etc/systemd/system/containerd.service.d/90-nodeadm.conf expected-containerd-drop-in.conf
+assert::files-equal /etc/systemd/system/kubelet.service.d/90-nodeadm.conf expected-kubelet-drop-in.conf
+
+# removing the options from the config removes the drop-in
+nodeadm init --skip run --config-source file://config-without-systemd.yaml
+
+if [ -f /etc/systemd/system/containerd.service.d/90-nodeadm.conf ]; then
+  echo "containerd drop-in was not removed"
+  exit 1
+fi
@@ -71,6 +71,15 @@ function mock::kubelet() {
   chmod +x /usr/bin/kubelet
 }
 
+function mock::containerd() {
+  if [ "$#" -ne 1 ]; then
+    echo "Usage: mock::containerd VERSION"
+    exit 1
+  fi
+  printf "#!/usr/bin/env bash\necho containerd github.com/containerd/containerd v%s\n" "$1" > /usr/bin/containerd
+  chmod +x /usr/bin/containerd
+}
+
 function mock::setup-local-disks() {
   mkdir -p /var/log
   printf '#!/usr/bin/env bash\necho "$1" >> /var/log/setup-local-disks.log' > /usr/bin/setup-local-disks